@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/KsaweryZietara/garage/internal/catalog"
+	"github.com/KsaweryZietara/garage/internal/storage"
+	"github.com/KsaweryZietara/garage/internal/storage/postgres"
+
+	"github.com/sethvargo/go-envconfig"
+)
+
+type Config struct {
+	Postgres postgres.Config `env:", prefix=POSTGRES_"`
+}
+
+func main() {
+	log := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	path := flag.String("file", "", "path to a CSV or JSON catalog file")
+	flag.Parse()
+	if *path == "" {
+		log.Error("catalog file is required")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	var cfg Config
+	if err := envconfig.Process(ctx, &cfg); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	entries, err := catalog.ParseFile(*path)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	storage, err := storage.New(cfg.Postgres.ConnectionURL(), log)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	result, err := catalog.Import(storage.Cars(), entries)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	log.Info("catalog imported", "makes", result.Makes, "models", result.Models)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/KsaweryZietara/garage/internal/api"
 	"github.com/KsaweryZietara/garage/internal/auth"
+	"github.com/KsaweryZietara/garage/internal/events"
 	"github.com/KsaweryZietara/garage/internal/mail"
 	"github.com/KsaweryZietara/garage/internal/storage"
 	"github.com/KsaweryZietara/garage/internal/storage/postgres"
@@ -41,6 +42,12 @@ func main() {
 
 	mail := mail.New(cfg.Mail)
 
-	api := api.New(cfg.Server, log, storage, auth, mail)
+	events := events.New(log)
+	if err = events.Listen(cfg.Postgres.ConnectionURL()); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+
+	api := api.New(cfg.Server, log, storage, auth, mail, events)
 	api.Start()
 }
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) ListAdditionalItems(writer http.ResponseWriter, request *http.Request) {
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	var appointment internal.Appointment
+	var garage internal.Garage
+	if role == internal.CustomerRole {
+		appointment, garage, ok = a.customerAppointment(writer, request)
+	} else {
+		appointment, garage, ok = a.staffAppointment(writer, request)
+	}
+	if !ok {
+		return
+	}
+
+	items, err := a.storage.AdditionalItems().ListByAppointmentID(appointment.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewAdditionalItemDTOs(items, garage.Pricing()), 200)
+}
+
+// CreateAdditionalItem lets the mechanic propose extra work on an appointment
+// in progress. The customer is asked by email to approve or reject it.
+func (a *API) CreateAdditionalItem(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateAdditionalItemDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateAdditionalItemDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, garage, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	if now.Before(appointment.StartTime) || now.After(appointment.EndTime) {
+		a.handleError(writer, errors.New("appointment is not in progress"), 400)
+		return
+	}
+
+	item, err := a.storage.AdditionalItems().Insert(internal.NewAdditionalItem(dto, appointment.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByID(appointment.CustomerID)
+	if err == nil {
+		err = a.mail.Send(
+			customer.Email,
+			"Dodatkowa praca do akceptacji",
+			mail.AdditionalItemTemplate,
+			mail.AdditionalItem{
+				GarageName:  garage.Name,
+				Description: item.Description,
+				Price:       internal.FormatMoney(item.Price, garage.Currency),
+				Time:        item.Time,
+			},
+		)
+	}
+	if err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.publishAppointmentEvent(events.AppointmentUpdated, appointment)
+
+	a.sendResponse(writer, internal.NewAdditionalItemDTO(item, garage.Pricing()), 201)
+}
+
+func (a *API) ApproveAdditionalItem(writer http.ResponseWriter, request *http.Request) {
+	a.decideAdditionalItem(writer, request, internal.ApprovedItem)
+}
+
+func (a *API) RejectAdditionalItem(writer http.ResponseWriter, request *http.Request) {
+	a.decideAdditionalItem(writer, request, internal.RejectedItem)
+}
+
+// decideAdditionalItem records the customer's decision and notifies the
+// mechanic. Approved work extends the appointment when the mechanic has no
+// other appointment in the added time.
+func (a *API) decideAdditionalItem(writer http.ResponseWriter, request *http.Request, status internal.AdditionalItemStatus) {
+	itemIDStr := request.PathValue("itemId")
+	itemID, err := strconv.Atoi(itemIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, garage, ok := a.customerAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	item, err := a.storage.AdditionalItems().GetByID(itemID)
+	if err != nil || item.AppointmentID != appointment.ID {
+		a.handleError(writer, errors.New("additional item not found"), 404)
+		return
+	}
+
+	if item.Status != internal.PendingItem {
+		a.handleError(writer, errors.New("additional item has already been decided"), 409)
+		return
+	}
+
+	if time.Now().After(appointment.EndTime) {
+		a.handleError(writer, errors.New("appointment is already completed"), 400)
+		return
+	}
+
+	decision := mail.AdditionalItemDecision{
+		Description: item.Description,
+		Approved:    status == internal.ApprovedItem,
+	}
+
+	if status == internal.ApprovedItem && item.Time > 0 {
+		slot := internal.TimeSlot{
+			StartTime: appointment.EndTime,
+			EndTime:   addWorkingHours(appointment.EndTime, item.Time),
+		}
+		appointments, err := a.storage.Appointments().GetByTimeSlot(slot, appointment.EmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+
+		for _, other := range appointments {
+			if other.ID != appointment.ID {
+				decision.Conflict = true
+				break
+			}
+		}
+
+		if !decision.Conflict {
+			appointment.EndTime = slot.EndTime
+			if err = a.storage.Appointments().Update(appointment); err != nil {
+				a.handleError(writer, err, 500)
+				return
+			}
+			decision.Extended = true
+		}
+	}
+
+	now := time.Now()
+	item.Status = status
+	item.DecidedAt = &now
+	if err = a.storage.AdditionalItems().Update(item); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	decision.EndTime = appointment.EndTime.Format("2006-01-02 15:04")
+	employee, err := a.storage.Employees().GetByID(appointment.EmployeeID)
+	if err == nil {
+		err = a.mail.Send(
+			employee.Email,
+			"Decyzja klienta",
+			mail.AdditionalItemDecisionTemplate,
+			decision,
+		)
+	}
+	if err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.publishAppointmentEvent(events.AppointmentUpdated, appointment)
+
+	a.sendResponse(writer, internal.NewAdditionalItemDTO(item, garage.Pricing()), 200)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdditionalItemEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	endTime := time.Now().Add(time.Hour).Truncate(time.Second)
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-time.Hour),
+			EndTime:    endTime,
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	var items []internal.AdditionalItemDTO
+	for _, description := range []string{"Replace brake discs", "Replace wiper blades"} {
+		itemJSON, err := json.Marshal(internal.CreateAdditionalItemDTO{
+			Description: description,
+			Price:       internal.MoneyDTO{Gross: 24600},
+			Time:        1,
+		})
+		require.NoError(t, err)
+
+		response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/additional-items", appointment.ID), itemJSON, &ownerToken)
+		require.Equal(t, http.StatusCreated, response.StatusCode)
+		var item internal.AdditionalItemDTO
+		suite.ParseResponse(t, response, &item)
+		assert.Equal(t, internal.PendingItem, item.Status)
+		assert.Equal(t, 20000, item.Price.Net)
+		items = append(items, item)
+	}
+
+	response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/additional-items/%v/approve", appointment.ID, items[0].ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/additional-items/%v/approve", appointment.ID, items[0].ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/additional-items/%v/reject", appointment.ID, items[0].ID), []byte{}, &customerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/additional-items/%v/reject", appointment.ID, items[1].ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	retrievedAppointment, err := suite.api.storage.Appointments().GetByID(appointment.ID)
+	require.NoError(t, err)
+	assert.True(t, retrievedAppointment.EndTime.After(endTime))
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/additional-items", appointment.ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &items)
+	require.Len(t, items, 2)
+	assert.Equal(t, internal.ApprovedItem, items[0].Status)
+	assert.Equal(t, internal.RejectedItem, items[1].Status)
+}
@@ -3,46 +3,61 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/KsaweryZietara/garage/internal"
 	"github.com/KsaweryZietara/garage/internal/auth"
+	"github.com/KsaweryZietara/garage/internal/events"
 	"github.com/KsaweryZietara/garage/internal/mail"
 	"github.com/KsaweryZietara/garage/internal/storage"
+	"github.com/KsaweryZietara/garage/internal/webhook"
 
 	"github.com/rs/cors"
 )
 
 const (
-	bearerPrefix = "Bearer "
-	emailKey     = "email"
-	roleKey      = "role"
+	bearerPrefix   = "Bearer "
+	emailKey       = "email"
+	roleKey        = "role"
+	garageIDHeader = "X-Garage-ID"
 )
 
 type Config struct {
-	Port string `env:"PORT"`
+	Port          string `env:"PORT"`
+	AdminEmail    string `env:"ADMIN_EMAIL"`
+	AdminPassword string `env:"ADMIN_PASSWORD"`
 }
 
 type API struct {
-	server  *http.Server
-	log     *slog.Logger
-	storage storage.Storage
-	auth    *auth.Auth
-	mail    *mail.Mail
+	server        *http.Server
+	log           *slog.Logger
+	storage       storage.Storage
+	auth          *auth.Auth
+	mail          *mail.Mail
+	events        *events.Bus
+	webhooks      *webhook.Sender
+	adminEmail    string
+	adminPassword string
 }
 
-func New(cfg Config, log *slog.Logger, storage storage.Storage, auth *auth.Auth, mail *mail.Mail) *API {
+func New(cfg Config, log *slog.Logger, storage storage.Storage, auth *auth.Auth, mail *mail.Mail, events *events.Bus) *API {
 	return &API{
 		server: &http.Server{
 			Addr: fmt.Sprintf(":%s", cfg.Port),
 		},
-		log:     log,
-		storage: storage,
-		auth:    auth,
-		mail:    mail,
+		log:           log,
+		storage:       storage,
+		auth:          auth,
+		mail:          mail,
+		events:        events,
+		webhooks:      webhook.New(webhookAttempts, webhookBackoff),
+		adminEmail:    cfg.AdminEmail,
+		adminPassword: cfg.AdminPassword,
 	}
 }
 
@@ -53,10 +68,12 @@ func (a *API) Start() {
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"http://localhost:8081"},
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		AllowedHeaders: []string{"Content-Type", "Authorization", garageIDHeader},
 	})
 	a.server.Handler = c.Handler(router)
 
+	go a.runHoldSweeper()
+
 	a.log.Info("starting garage")
 	log.Fatal(a.server.ListenAndServe())
 }
@@ -69,38 +86,148 @@ func (a *API) attachRoutes(router *http.ServeMux) {
 	router.Handle("GET /api/employees/garages", a.authMiddleware(http.HandlerFunc(a.GetEmployeeGarage), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
 	router.Handle("GET /api/employees/appointments", a.authMiddleware(http.HandlerFunc(a.GetEmployeeAppointments), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
 	router.Handle("POST /api/employees/profile-picture", a.authMiddleware(http.HandlerFunc(a.UpdateProfilePicture), []internal.Role{internal.MechanicRole}))
+	router.Handle("GET /api/employees/locations", a.authMiddleware(http.HandlerFunc(a.ListEmployeeLocations), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
+	router.Handle("PUT /api/employees/locations/active", a.authMiddleware(http.HandlerFunc(a.UpdateActiveLocation), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
 
 	// Admin panel
-	router.Handle("GET /api/employees", a.authMiddleware(http.HandlerFunc(a.ListEmployees), []internal.Role{internal.OwnerRole}))
-	router.Handle("POST /api/employees", a.authMiddleware(http.HandlerFunc(a.CreateEmployee), []internal.Role{internal.OwnerRole}))
-	router.Handle("GET /api/employees/{id}/confirmation", a.authMiddleware(http.HandlerFunc(a.ResendConfirmationEmail), []internal.Role{internal.OwnerRole}))
-	router.Handle("DELETE /api/employees/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteEmployee), []internal.Role{internal.OwnerRole}))
-	router.Handle("POST /api/garages/logo", a.authMiddleware(http.HandlerFunc(a.UpdateLogo), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/employees", a.permissionMiddleware(http.HandlerFunc(a.ListEmployees), internal.StaffManagePermission))
+	router.Handle("POST /api/employees", a.permissionMiddleware(http.HandlerFunc(a.CreateEmployee), internal.StaffManagePermission))
+	router.Handle("GET /api/employees/{id}/confirmation", a.permissionMiddleware(http.HandlerFunc(a.ResendConfirmationEmail), internal.StaffManagePermission))
+	router.Handle("DELETE /api/employees/{id}", a.permissionMiddleware(http.HandlerFunc(a.DeleteEmployee), internal.StaffManagePermission))
+	router.Handle("GET /api/employees/{id}/services", a.permissionMiddleware(http.HandlerFunc(a.ListEmployeeServices), internal.StaffManagePermission))
+	router.Handle("PUT /api/employees/{id}/services", a.permissionMiddleware(http.HandlerFunc(a.UpdateEmployeeServices), internal.StaffManagePermission))
+	router.Handle("PUT /api/employees/{id}/role", a.authMiddleware(http.HandlerFunc(a.UpdateEmployeeRole), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/garages/logo", a.permissionMiddleware(http.HandlerFunc(a.UpdateLogo), internal.GarageWritePermission))
+	router.Handle("GET /api/garages/roles", a.authMiddleware(http.HandlerFunc(a.ListGarageRoles), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/garages/roles", a.authMiddleware(http.HandlerFunc(a.CreateGarageRole), []internal.Role{internal.OwnerRole}))
+	router.Handle("PUT /api/garages/roles/{id}", a.authMiddleware(http.HandlerFunc(a.UpdateGarageRole), []internal.Role{internal.OwnerRole}))
+	router.Handle("DELETE /api/garages/roles/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteGarageRole), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/garages/calendar-token", a.permissionMiddleware(http.HandlerFunc(a.GetGarageCalendarToken), internal.AppointmentsManagePermission))
+	router.Handle("POST /api/garages/calendar-token", a.permissionMiddleware(http.HandlerFunc(a.CreateGarageCalendarToken), internal.AppointmentsManagePermission))
+	router.Handle("DELETE /api/garages/calendar-token", a.permissionMiddleware(http.HandlerFunc(a.DeleteGarageCalendarToken), internal.AppointmentsManagePermission))
+	router.Handle("GET /api/employees/calendar-token", a.authMiddleware(http.HandlerFunc(a.GetEmployeeCalendarToken), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/employees/calendar-token", a.authMiddleware(http.HandlerFunc(a.CreateEmployeeCalendarToken), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("DELETE /api/employees/calendar-token", a.authMiddleware(http.HandlerFunc(a.DeleteEmployeeCalendarToken), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.HandleFunc("GET /api/calendars/{token}", a.GetCalendar)
+	router.Handle("GET /api/webhooks/event-types", a.authMiddleware(http.HandlerFunc(a.ListWebhookEventTypes), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/webhooks", a.authMiddleware(http.HandlerFunc(a.ListWebhooks), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/webhooks", a.authMiddleware(http.HandlerFunc(a.CreateWebhook), []internal.Role{internal.OwnerRole}))
+	router.Handle("PUT /api/webhooks/{id}", a.authMiddleware(http.HandlerFunc(a.UpdateWebhook), []internal.Role{internal.OwnerRole}))
+	router.Handle("DELETE /api/webhooks/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteWebhook), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/webhooks/{id}/deliveries", a.authMiddleware(http.HandlerFunc(a.ListWebhookDeliveries), []internal.Role{internal.OwnerRole}))
+	router.HandleFunc("GET /api/permissions", a.ListPermissions)
+	router.Handle("GET /api/garages/owners", a.authMiddleware(http.HandlerFunc(a.ListGarageOwners), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/garages/owners", a.authMiddleware(http.HandlerFunc(a.AddGarageOwner), []internal.Role{internal.OwnerRole}))
+	router.Handle("DELETE /api/garages/owners/{id}", a.authMiddleware(http.HandlerFunc(a.RemoveGarageOwner), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/garages/transfers", a.authMiddleware(http.HandlerFunc(a.CreateOwnershipTransfer), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/garages/transfers", a.authMiddleware(http.HandlerFunc(a.ListOwnershipTransfers), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
+	router.Handle("POST /api/garages/transfers/{id}/confirm", a.authMiddleware(http.HandlerFunc(a.ConfirmOwnershipTransfer), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
+	router.Handle("DELETE /api/garages/transfers/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteOwnershipTransfer), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
+	router.Handle("PUT /api/employees/{id}/locations/{garageId}", a.permissionMiddleware(http.HandlerFunc(a.AddEmployeeLocation), internal.StaffManagePermission))
+	router.Handle("DELETE /api/employees/{id}/locations/{garageId}", a.permissionMiddleware(http.HandlerFunc(a.RemoveEmployeeLocation), internal.StaffManagePermission))
+	router.Handle("POST /api/companies", a.authMiddleware(http.HandlerFunc(a.CreateCompany), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/companies", a.authMiddleware(http.HandlerFunc(a.GetCompany), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/companies", a.authMiddleware(http.HandlerFunc(a.UpdateCompany), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/companies/members", a.authMiddleware(http.HandlerFunc(a.CreateCompanyMember), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/companies/members/{customerId}", a.authMiddleware(http.HandlerFunc(a.UpdateCompanyMember), []internal.Role{internal.CustomerRole}))
+	router.Handle("DELETE /api/companies/members/{customerId}", a.authMiddleware(http.HandlerFunc(a.DeleteCompanyMember), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/companies/appointments", a.authMiddleware(http.HandlerFunc(a.ListCompanyAppointments), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/companies/invoices", a.authMiddleware(http.HandlerFunc(a.ListCompanyInvoices), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/companies/invoices/{id}/pdf", a.authMiddleware(http.HandlerFunc(a.DownloadCompanyInvoice), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/organizations", a.authMiddleware(http.HandlerFunc(a.CreateOrganization), []internal.Role{internal.OwnerRole}))
+	router.Handle("GET /api/organizations", a.authMiddleware(http.HandlerFunc(a.GetOrganization), []internal.Role{internal.OwnerRole, internal.MechanicRole}))
+	router.Handle("GET /api/organizations/report", a.authMiddleware(http.HandlerFunc(a.GetOrganizationReport), []internal.Role{internal.OwnerRole}))
 
 	router.HandleFunc("POST /api/customers/register", a.CreateCustomer)
+	router.HandleFunc("POST /api/customers/register/{code}", a.ClaimCustomer)
 	router.HandleFunc("POST /api/customers/login", a.LoginCustomer)
 	router.Handle("GET /api/customers/appointments", a.authMiddleware(http.HandlerFunc(a.GetCustomerAppointments), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/customers/profile", a.authMiddleware(http.HandlerFunc(a.GetCustomerProfile), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/customers/profile", a.authMiddleware(http.HandlerFunc(a.UpdateCustomerProfile), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/customers/vehicles", a.authMiddleware(http.HandlerFunc(a.ListVehicles), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/customers/vehicles", a.authMiddleware(http.HandlerFunc(a.CreateVehicle), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/customers/vehicles/{id}", a.authMiddleware(http.HandlerFunc(a.UpdateVehicle), []internal.Role{internal.CustomerRole}))
+	router.Handle("DELETE /api/customers/vehicles/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteVehicle), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/vehicles/{id}/history", a.authMiddleware(http.HandlerFunc(a.GetVehicleHistory), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
 
 	router.Handle("POST /api/garages", a.authMiddleware(http.HandlerFunc(a.CreateGarage), []internal.Role{internal.OwnerRole}))
-	router.Handle("PUT /api/garages", a.authMiddleware(http.HandlerFunc(a.UpdateGarage), []internal.Role{internal.OwnerRole}))
+	router.Handle("PUT /api/garages", a.permissionMiddleware(http.HandlerFunc(a.UpdateGarage), internal.GarageWritePermission))
 	router.HandleFunc("GET /api/garages", a.ListGarages)
 	router.HandleFunc("GET /api/garages/{id}", a.GetGarage)
 	router.HandleFunc("GET /api/garages/{id}/services", a.ListServices)
 	router.HandleFunc("GET /api/garages/{id}/employees", a.ListConfirmedEmployees)
 	router.HandleFunc("GET /api/garages/{id}/reviews", a.ListReviews)
 
+	router.HandleFunc("GET /api/services", a.SearchServices)
 	router.HandleFunc("GET /api/services/{id}", a.GetService)
-	router.Handle("POST /api/services", a.authMiddleware(http.HandlerFunc(a.CreateService), []internal.Role{internal.OwnerRole}))
-	router.Handle("DELETE /api/services/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteService), []internal.Role{internal.OwnerRole}))
+	router.Handle("POST /api/services", a.permissionMiddleware(http.HandlerFunc(a.CreateService), internal.ServicesWritePermission))
+	router.Handle("PUT /api/services/{id}", a.permissionMiddleware(http.HandlerFunc(a.UpdateService), internal.ServicesWritePermission))
+	router.Handle("DELETE /api/services/{id}", a.permissionMiddleware(http.HandlerFunc(a.DeleteService), internal.ServicesWritePermission))
+	router.Handle("GET /api/services/{id}/versions", a.permissionMiddleware(http.HandlerFunc(a.ListServiceVersions), internal.ServicesWritePermission))
+	router.HandleFunc("GET /api/services/{id}/rules", a.ListServiceRules)
+	router.Handle("POST /api/services/{id}/rules", a.permissionMiddleware(http.HandlerFunc(a.CreateServiceRule), internal.ServicesWritePermission))
+	router.Handle("DELETE /api/services/{id}/rules/{ruleId}", a.permissionMiddleware(http.HandlerFunc(a.DeleteServiceRule), internal.ServicesWritePermission))
+	router.HandleFunc("GET /api/service-categories", a.ListServiceCategories)
+	router.Handle("POST /api/service-categories", a.permissionMiddleware(http.HandlerFunc(a.CreateServiceCategory), internal.ServicesWritePermission))
+	router.Handle("PUT /api/service-categories/{id}", a.permissionMiddleware(http.HandlerFunc(a.UpdateServiceCategory), internal.ServicesWritePermission))
+	router.Handle("DELETE /api/service-categories/{id}", a.permissionMiddleware(http.HandlerFunc(a.DeleteServiceCategory), internal.ServicesWritePermission))
+
+	router.Handle("GET /api/parts", a.authMiddleware(http.HandlerFunc(a.ListParts), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/parts", a.permissionMiddleware(http.HandlerFunc(a.CreatePart), internal.InventoryManagePermission))
+	router.Handle("PUT /api/parts/{id}", a.permissionMiddleware(http.HandlerFunc(a.UpdatePart), internal.InventoryManagePermission))
+	router.Handle("DELETE /api/parts/{id}", a.permissionMiddleware(http.HandlerFunc(a.DeletePart), internal.InventoryManagePermission))
+	router.Handle("GET /api/parts/report", a.permissionMiddleware(http.HandlerFunc(a.GetInventoryReport), internal.InventoryManagePermission))
 
 	router.Handle("POST /api/appointments", a.authMiddleware(http.HandlerFunc(a.CreateAppointment), []internal.Role{internal.CustomerRole}))
 	router.Handle("DELETE /api/appointments/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteAppointment), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
 	router.Handle("PUT /api/appointments/{id}/reviews", a.authMiddleware(http.HandlerFunc(a.CreateReview), []internal.Role{internal.CustomerRole}))
 	router.Handle("DELETE /api/appointments/{id}/reviews", a.authMiddleware(http.HandlerFunc(a.DeleteReview), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/appointments/{id}/schedule", a.authMiddleware(http.HandlerFunc(a.RescheduleAppointment), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/appointments/{id}/notes", a.authMiddleware(http.HandlerFunc(a.UpdateAppointmentNotes), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/invoice", a.permissionMiddleware(http.HandlerFunc(a.CreateInvoice), internal.InvoicesWritePermission))
+	router.Handle("GET /api/appointments/{id}/invoice", a.authMiddleware(http.HandlerFunc(a.GetInvoice), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("GET /api/appointments/{id}/invoice/pdf", a.authMiddleware(http.HandlerFunc(a.DownloadInvoice), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/invoice/email", a.permissionMiddleware(http.HandlerFunc(a.SendInvoice), internal.InvoicesWritePermission))
+	router.Handle("GET /api/appointments/{id}/work-order", a.authMiddleware(http.HandlerFunc(a.GetWorkOrder), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("PUT /api/appointments/{id}/work-order", a.authMiddleware(http.HandlerFunc(a.UpdateWorkOrder), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/work-order/photos", a.authMiddleware(http.HandlerFunc(a.CreateWorkOrderPhoto), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("DELETE /api/appointments/{id}/work-order/photos/{photoId}", a.authMiddleware(http.HandlerFunc(a.DeleteWorkOrderPhoto), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("GET /api/appointments/{id}/additional-items", a.authMiddleware(http.HandlerFunc(a.ListAdditionalItems), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/additional-items", a.authMiddleware(http.HandlerFunc(a.CreateAdditionalItem), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/additional-items/{itemId}/approve", a.authMiddleware(http.HandlerFunc(a.ApproveAdditionalItem), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/appointments/{id}/additional-items/{itemId}/reject", a.authMiddleware(http.HandlerFunc(a.RejectAdditionalItem), []internal.Role{internal.CustomerRole}))
+	router.Handle("GET /api/appointments/{id}/messages", a.authMiddleware(http.HandlerFunc(a.ListMessages), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/messages", a.authMiddleware(http.HandlerFunc(a.CreateMessage), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("GET /api/appointments/{id}/parts", a.authMiddleware(http.HandlerFunc(a.ListAppointmentParts), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("POST /api/appointments/{id}/parts", a.authMiddleware(http.HandlerFunc(a.CreateAppointmentPart), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
+	router.Handle("DELETE /api/appointments/{id}/parts/{appointmentPartId}", a.authMiddleware(http.HandlerFunc(a.DeleteAppointmentPart), []internal.Role{internal.MechanicRole, internal.OwnerRole}))
 	router.HandleFunc("GET /api/appointments/availableSlots", a.GetAvailableSlots)
+	router.Handle("GET /api/appointment-series/{id}", a.authMiddleware(http.HandlerFunc(a.GetAppointmentSeries), []internal.Role{internal.CustomerRole}))
+	router.Handle("PUT /api/appointment-series/{id}", a.authMiddleware(http.HandlerFunc(a.RescheduleAppointmentSeries), []internal.Role{internal.CustomerRole}))
+	router.Handle("DELETE /api/appointment-series/{id}", a.authMiddleware(http.HandlerFunc(a.CancelAppointmentSeries), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/holds", a.authMiddleware(http.HandlerFunc(a.CreateSlotHold), []internal.Role{internal.CustomerRole}))
+	router.Handle("DELETE /api/holds/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteSlotHold), []internal.Role{internal.CustomerRole}))
+
+	router.Handle("GET /api/waitlist", a.authMiddleware(http.HandlerFunc(a.ListWaitlistEntries), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/waitlist", a.authMiddleware(http.HandlerFunc(a.CreateWaitlistEntry), []internal.Role{internal.CustomerRole}))
+	router.Handle("DELETE /api/waitlist/{id}", a.authMiddleware(http.HandlerFunc(a.DeleteWaitlistEntry), []internal.Role{internal.CustomerRole}))
+	router.Handle("POST /api/garages/company-invoices", a.permissionMiddleware(http.HandlerFunc(a.CreateCompanyInvoice), internal.InvoicesWritePermission))
+	router.Handle("POST /api/garages/appointments", a.permissionMiddleware(http.HandlerFunc(a.CreateStaffAppointment), internal.AppointmentsManagePermission))
+	router.Handle("GET /api/garages/waitlist", a.permissionMiddleware(http.HandlerFunc(a.ListGarageWaitlist), internal.AppointmentsManagePermission))
+
+	router.Handle("GET /api/events", queryTokenMiddleware(a.authMiddleware(http.HandlerFunc(a.StreamEvents), []internal.Role{internal.CustomerRole, internal.MechanicRole, internal.OwnerRole})))
 
 	router.HandleFunc("GET /api/makes", a.ListMakes)
 	router.HandleFunc("GET /api/makes/{id}/models", a.ListModels)
+
+	// Vehicle catalog
+	router.HandleFunc("POST /api/admin/login", a.LoginAdmin)
+	router.Handle("POST /api/makes", a.authMiddleware(http.HandlerFunc(a.CreateMake), []internal.Role{internal.AdminRole}))
+	router.Handle("PUT /api/makes/{id}", a.authMiddleware(http.HandlerFunc(a.UpdateMake), []internal.Role{internal.AdminRole}))
+	router.Handle("POST /api/makes/{id}/merge", a.authMiddleware(http.HandlerFunc(a.MergeMakes), []internal.Role{internal.AdminRole}))
+	router.Handle("POST /api/makes/{id}/models", a.authMiddleware(http.HandlerFunc(a.CreateModel), []internal.Role{internal.AdminRole}))
+	router.Handle("PUT /api/models/{id}", a.authMiddleware(http.HandlerFunc(a.UpdateModel), []internal.Role{internal.AdminRole}))
+	router.Handle("POST /api/models/{id}/merge", a.authMiddleware(http.HandlerFunc(a.MergeModels), []internal.Role{internal.AdminRole}))
 }
 
 func (a *API) authMiddleware(next http.Handler, roles []internal.Role) http.Handler {
@@ -123,6 +250,17 @@ func (a *API) authMiddleware(next http.Handler, roles []internal.Role) http.Hand
 			return
 		}
 
+		// Employee roles change when ownership moves between employees, so the
+		// current one is taken from storage rather than from the token.
+		if tokenRole == internal.OwnerRole || tokenRole == internal.MechanicRole {
+			employee, err := a.storage.Employees().GetByEmail(email)
+			if err != nil {
+				a.sendResponse(w, nil, 401)
+				return
+			}
+			tokenRole = employee.Role
+		}
+
 		roleFound := false
 		for _, role := range roles {
 			if role == tokenRole {
@@ -141,6 +279,107 @@ func (a *API) authMiddleware(next http.Handler, roles []internal.Role) http.Hand
 	})
 }
 
+func (a *API) permissionMiddleware(next http.Handler, permission internal.Permission) http.Handler {
+	return a.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email, ok := a.emailFromContext(r.Context())
+		if !ok {
+			a.sendResponse(w, nil, 401)
+			return
+		}
+
+		employee, err := a.storage.Employees().GetByEmail(email)
+		if err != nil {
+			a.sendResponse(w, nil, 401)
+			return
+		}
+
+		garage, err := a.employeeGarage(r, employee)
+		if err != nil {
+			a.handleError(w, err, 404)
+			return
+		}
+
+		allowed, err := a.hasPermission(employee, garage, permission)
+		if err != nil {
+			a.handleError(w, err, 500)
+			return
+		}
+		if !allowed {
+			a.sendResponse(w, nil, 403)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}), []internal.Role{internal.OwnerRole, internal.MechanicRole})
+}
+
+// hasPermission reports whether the employee may perform the action guarded by
+// the permission at the garage. Owners of the garage are granted every
+// permission, other employees only those listed in their garage role, which
+// applies solely to the garage it belongs to.
+func (a *API) hasPermission(employee internal.Employee, garage internal.Garage, permission internal.Permission) (bool, error) {
+	if employee.Role == internal.OwnerRole {
+		owners, err := a.storage.Employees().ListOwnersByGarageID(garage.ID)
+		if err != nil {
+			return false, err
+		}
+		return containsEmployee(owners, employee.ID), nil
+	}
+	if employee.GarageRoleID == nil {
+		return false, nil
+	}
+
+	role, err := a.storage.GarageRoles().GetByID(*employee.GarageRoleID)
+	if err != nil {
+		return false, err
+	}
+	if role.GarageID != garage.ID {
+		return false, nil
+	}
+
+	return role.HasPermission(permission), nil
+}
+
+// employeeGarage resolves the garage an employee is acting on. The location
+// can be chosen per request with the X-Garage-ID header or the garageId query
+// parameter, otherwise the employee's active location is used.
+func (a *API) employeeGarage(request *http.Request, employee internal.Employee) (internal.Garage, error) {
+	garages, err := a.storage.Garages().ListByEmployeeID(employee.ID)
+	if err != nil {
+		return internal.Garage{}, err
+	}
+	if len(garages) == 0 {
+		return internal.Garage{}, errors.New("employee is not assigned to any garage")
+	}
+
+	garageIDStr := request.Header.Get(garageIDHeader)
+	if garageIDStr == "" {
+		garageIDStr = request.URL.Query().Get("garageId")
+	}
+	if garageIDStr != "" {
+		garageID, err := strconv.Atoi(garageIDStr)
+		if err != nil {
+			return internal.Garage{}, err
+		}
+		for _, garage := range garages {
+			if garage.ID == garageID {
+				return garage, nil
+			}
+		}
+		return internal.Garage{}, errors.New("garage not found")
+	}
+
+	if employee.ActiveGarageID != nil {
+		for _, garage := range garages {
+			if garage.ID == *employee.ActiveGarageID {
+				return garage, nil
+			}
+		}
+	}
+
+	return garages[0], nil
+}
+
 func (a *API) emailFromContext(ctx context.Context) (string, bool) {
 	email, ok := ctx.Value(emailKey).(string)
 	return email, ok
@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/ical"
+	"github.com/KsaweryZietara/garage/internal/mail"
 	"github.com/KsaweryZietara/garage/internal/validate"
 )
 
@@ -42,81 +47,204 @@ func (a *API) CreateAppointment(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
+	booking, ok := a.checkBooking(writer, &dto, customer)
+	if !ok {
+		return
+	}
+
+	if dto.Recurrence != nil {
+		a.createAppointmentSeries(writer, dto, customer, booking)
+		return
+	}
+
+	appointment := internal.NewAppointment(dto, customer.ID)
+	appointment.CompanyID = booking.companyID
+	appointment.Services = appointmentLineItems(booking.services, booking.garage)
+	appointment, err = a.storage.Appointments().Insert(appointment)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.releaseSlotHolds(internal.TimeSlot{StartTime: dto.StartTime, EndTime: dto.EndTime}, booking.employee.ID, customer.ID)
+
+	a.publishAppointmentEvent(events.AppointmentCreated, appointment)
+
+	if err = a.sendAppointmentConfirmation(customer, booking.garage, appointment, booking.services); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, nil, 201)
+}
+
+// booking is what checkBooking loads for an appointment to book.
+type booking struct {
+	employee internal.Employee
+	garage   internal.Garage
+	services []internal.Service
+	// companyID is set when a vehicle of a company is booked.
+	companyID *int
+}
+
+// checkBooking loads the employee, garage and services of the booking and
+// makes sure its slot is free for the customer. The model of the booked
+// vehicle is filled in the DTO.
+func (a *API) checkBooking(writer http.ResponseWriter, dto *internal.CreateAppointmentDTO, customer internal.Customer) (booking, bool) {
+	var companyID *int
+	if dto.VehicleID != 0 {
+		vehicle, err := a.storage.Vehicles().GetByID(dto.VehicleID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return booking{}, false
+		}
+		if !a.canUseVehicle(customer, vehicle) {
+			a.handleError(writer, errors.New("vehicle not found"), 404)
+			return booking{}, false
+		}
+		dto.ModelID = vehicle.ModelID
+		companyID = vehicle.CompanyID
+	}
+
 	employee, err := a.storage.Employees().GetConfirmedByID(dto.EmployeeID)
 	if err != nil {
 		a.handleError(writer, err, 404)
-		return
+		return booking{}, false
 	}
 
-	service, err := a.storage.Services().GetByID(dto.ServiceID)
+	model, err := a.storage.Cars().GetModelByID(dto.ModelID)
 	if err != nil {
 		a.handleError(writer, err, 404)
-		return
+		return booking{}, false
 	}
 
-	if service.IsDeleted {
-		a.sendResponse(writer, nil, 404)
-		return
+	services, ok := a.bookedServices(writer, dto.BookedServiceIDs(), &employee, &model)
+	if !ok {
+		return booking{}, false
 	}
 
+	garage, err := a.storage.Garages().GetByID(services[0].GarageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return booking{}, false
+	}
+
+	slot := internal.TimeSlot{StartTime: dto.StartTime, EndTime: dto.EndTime}
+	available, err := a.slotAvailable(slot, servicesDuration(services), employee.ID, customer.ID)
+	if err != nil || !available {
+		a.handleError(writer, errors.New("time slot not available"), 400)
+		return booking{}, false
+	}
+
+	return booking{
+		employee:  employee,
+		garage:    garage,
+		services:  services,
+		companyID: companyID,
+	}, true
+}
+
+// slotAvailable reports whether the employee can take a booking of the given
+// duration in the slot. It has to be one of the slots of its day and must not
+// overlap appointments, other than the excepted ones, or holds of other
+// customers.
+func (a *API) slotAvailable(slot internal.TimeSlot, duration, employeeID, customerID int, except ...int) (bool, error) {
 	slotFound := false
-	for _, slot := range createTimeSlots(dto.StartTime, service.Time) {
-		if slot.StartTime.Equal(dto.StartTime) && slot.EndTime.Equal(dto.EndTime) {
+	for _, timeSlot := range createTimeSlots(slot.StartTime, duration) {
+		if timeSlot.StartTime.Equal(slot.StartTime) && timeSlot.EndTime.Equal(slot.EndTime) {
 			slotFound = true
 			break
 		}
 	}
 	if !slotFound {
-		a.handleError(writer, errors.New("time slot not available"), 400)
-		return
+		return false, nil
 	}
 
-	appointments, err := a.storage.Appointments().GetByTimeSlot(internal.TimeSlot{
-		StartTime: dto.StartTime,
-		EndTime:   dto.EndTime,
-	}, employee.ID)
-	if err != nil || len(appointments) != 0 {
-		a.handleError(writer, errors.New("time slot not available"), 400)
-		return
+	appointments, err := a.storage.Appointments().GetByTimeSlot(slot, employeeID)
+	if err != nil {
+		return false, err
+	}
+	for _, appointment := range appointments {
+		if !slices.Contains(except, appointment.ID) {
+			return false, nil
+		}
 	}
 
-	appointment := internal.NewAppointment(dto, customer.ID)
-	_, err = a.storage.Appointments().Insert(appointment)
+	held, err := a.slotHeld(slot, employeeID, customerID)
 	if err != nil {
-		a.handleError(writer, err, 500)
-		return
+		return false, err
 	}
 
-	a.sendResponse(writer, nil, 201)
+	return !held, nil
+}
+
+func servicesDuration(services []internal.Service) int {
+	duration := 0
+	for _, service := range services {
+		duration += service.Time
+	}
+	return duration
+}
+
+func appointmentLineItems(services []internal.Service, garage internal.Garage) []internal.AppointmentService {
+	lineItems := make([]internal.AppointmentService, len(services))
+	for i, service := range services {
+		lineItems[i] = internal.NewAppointmentService(service, garage.Pricing())
+	}
+	return lineItems
 }
 
 func (a *API) GetAvailableSlots(writer http.ResponseWriter, request *http.Request) {
 	queryParams := request.URL.Query()
 
-	serviceIDStr := queryParams.Get("serviceId")
-	serviceID, err := strconv.Atoi(serviceIDStr)
+	employeeIDStr := queryParams.Get("employeeId")
+	employeeID, err := strconv.Atoi(employeeIDStr)
 	if err != nil {
 		a.handleError(writer, err, 400)
 		return
 	}
-	service, err := a.storage.Services().GetByID(serviceID)
+	employee, err := a.storage.Employees().GetConfirmedByID(employeeID)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
 	}
 
-	employeeIDStr := queryParams.Get("employeeId")
-	employeeID, err := strconv.Atoi(employeeIDStr)
-	if err != nil {
-		a.handleError(writer, err, 400)
+	var serviceIDs []int
+	for _, serviceIDStr := range queryParams["serviceId"] {
+		serviceID, err := strconv.Atoi(serviceIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	if len(serviceIDs) == 0 {
+		a.handleError(writer, errors.New("service ID is required"), 400)
 		return
 	}
-	employee, err := a.storage.Employees().GetConfirmedByID(employeeID)
-	if err != nil {
-		a.handleError(writer, err, 404)
+
+	var model *internal.Model
+	modelIDStr := queryParams.Get("modelId")
+	if modelIDStr != "" {
+		modelID, err := strconv.Atoi(modelIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+		carModel, err := a.storage.Cars().GetModelByID(modelID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		model = &carModel
+	}
+
+	services, ok := a.bookedServices(writer, serviceIDs, &employee, model)
+	if !ok {
 		return
 	}
 
+	duration := servicesDuration(services)
+
 	dateStr := queryParams.Get("date")
 	layout := "2006-01-02"
 	date, err := time.Parse(layout, dateStr)
@@ -125,10 +253,16 @@ func (a *API) GetAvailableSlots(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
+	customerID := a.requestCustomerID(request)
+
 	var timeSlots []internal.TimeSlot
-	for _, timeSlot := range createTimeSlots(date, service.Time) {
+	for _, timeSlot := range createTimeSlots(date, duration) {
 		appointments, err := a.storage.Appointments().GetByTimeSlot(timeSlot, employee.ID)
-		if err == nil && len(appointments) == 0 {
+		if err != nil || len(appointments) != 0 {
+			continue
+		}
+		held, err := a.slotHeld(timeSlot, employee.ID, customerID)
+		if err == nil && !held {
 			timeSlots = append(timeSlots, timeSlot)
 		}
 	}
@@ -136,6 +270,65 @@ func (a *API) GetAvailableSlots(writer http.ResponseWriter, request *http.Reques
 	a.sendResponse(writer, timeSlots, 200)
 }
 
+// bookedServices loads the services of a booking with the price and time that
+// apply to the vehicle model. All services have to belong to the same garage
+// and the employee has to be qualified for each of them. Vehicle rules are
+// skipped when no model is given.
+func (a *API) bookedServices(writer http.ResponseWriter, serviceIDs []int, employee *internal.Employee, model *internal.Model) ([]internal.Service, bool) {
+	services := make([]internal.Service, 0, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		for _, service := range services {
+			if service.ID == serviceID {
+				a.handleError(writer, errors.New("services cannot repeat"), 400)
+				return nil, false
+			}
+		}
+
+		service, err := a.storage.Services().GetByID(serviceID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return nil, false
+		}
+
+		if service.IsDeleted {
+			a.sendResponse(writer, nil, 404)
+			return nil, false
+		}
+
+		if len(services) > 0 && service.GarageID != services[0].GarageID {
+			a.handleError(writer, errors.New("services must belong to the same garage"), 400)
+			return nil, false
+		}
+
+		qualified, err := a.storage.Employees().IsQualified(employee.ID, service.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return nil, false
+		}
+		if !qualified {
+			a.handleError(writer, errors.New("employee is not qualified for this service"), 400)
+			return nil, false
+		}
+
+		if model != nil {
+			var ok bool
+			service, ok, err = a.serviceForModel(service, *model)
+			if err != nil {
+				a.handleError(writer, err, 500)
+				return nil, false
+			}
+			if !ok {
+				a.handleError(writer, errors.New("service is not available for this vehicle"), 400)
+				return nil, false
+			}
+		}
+
+		services = append(services, service)
+	}
+
+	return services, true
+}
+
 func (a *API) GetEmployeeAppointments(writer http.ResponseWriter, request *http.Request) {
 	queryParams := request.URL.Query()
 
@@ -164,16 +357,20 @@ func (a *API) GetEmployeeAppointments(writer http.ResponseWriter, request *http.
 		return
 	}
 
-	var appointments []internal.Appointment
-	switch employee.Role {
-	case internal.OwnerRole:
-		garage, err := a.storage.Garages().GetByOwnerID(employee.ID)
+	canManage := false
+	garage, err := a.employeeGarage(request, employee)
+	if err == nil {
+		canManage, err = a.hasPermission(employee, garage, internal.AppointmentsManagePermission)
 		if err != nil {
-			a.handleError(writer, err, 404)
+			a.handleError(writer, err, 500)
 			return
 		}
+	}
+
+	var appointments []internal.Appointment
+	if canManage {
 		appointments, err = a.storage.Appointments().GetByGarageID(garage.ID, date)
-	case internal.MechanicRole:
+	} else {
 		appointments, err = a.storage.Appointments().GetByEmployeeID(employee.ID, date)
 	}
 	if err != nil {
@@ -190,19 +387,46 @@ func (a *API) GetEmployeeAppointments(writer http.ResponseWriter, request *http.
 			a.handleError(writer, err, 404)
 			return
 		}
+		serviceGarage, err := a.storage.Garages().GetByID(service.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
 		car, err := a.storage.Cars().GetByModelID(appointment.ModelID)
 		if err != nil {
 			a.handleError(writer, err, 404)
 			return
 		}
+		services, err := a.storage.Appointments().ListServices(appointment.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
 		appointmentDTOs[i] = internal.AppointmentDTO{
-			ID:        appointment.ID,
-			StartTime: appointment.StartTime,
-			EndTime:   appointment.EndTime,
-			Service:   internal.NewServiceDTO(service),
-			Car:       car,
+			ID:         appointment.ID,
+			StartTime:  appointment.StartTime,
+			EndTime:    appointment.EndTime,
+			Service:    internal.NewServiceDTO(service, serviceGarage.Pricing()),
+			Services:   internal.NewAppointmentServiceDTOs(services),
+			TotalPrice: internal.NewTotalPriceDTO(services),
+			Mileage:    appointment.Mileage,
+			Notes:      appointment.Notes,
+			CompanyID:  appointment.CompanyID,
+			Car:        car,
+		}
+		customer, err := a.storage.Customers().GetByID(appointment.CustomerID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
 		}
-		if employee.Role == internal.OwnerRole {
+		customerDTO := internal.NewCustomerDTO(customer)
+		appointmentDTOs[i].Customer = &customerDTO
+		appointmentDTOs[i].Vehicle, err = a.appointmentVehicle(appointment)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		if canManage {
 			mechanic, err := a.storage.Employees().GetConfirmedByID(appointment.EmployeeID)
 			mechanicDTO := internal.NewEmployeeDTO(mechanic, false)
 			appointmentDTOs[i].Employee = &mechanicDTO
@@ -213,6 +437,11 @@ func (a *API) GetEmployeeAppointments(writer http.ResponseWriter, request *http.
 		}
 	}
 
+	if err = a.setUnreadMessages(appointmentDTOs, true); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
 	a.sendResponse(writer, appointmentDTOs, 200)
 }
 
@@ -247,7 +476,7 @@ func (a *API) GetCustomerAppointments(writer http.ResponseWriter, request *http.
 			a.handleError(writer, err, 404)
 			return
 		}
-		garage, err := a.storage.Garages().GetByID(*employee.GarageID)
+		garage, err := a.storage.Garages().GetByID(service.GarageID)
 		if err != nil {
 			a.handleError(writer, err, 404)
 			return
@@ -257,12 +486,47 @@ func (a *API) GetCustomerAppointments(writer http.ResponseWriter, request *http.
 			a.handleError(writer, err, 404)
 			return
 		}
-		appointmentDTOs[i] = internal.NewAppointmentDTO(appointment, service, employee, garage, car)
+		services, err := a.storage.Appointments().ListServices(appointment.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		appointmentDTOs[i] = internal.NewAppointmentDTO(appointment, service, services, employee, garage, car)
+		appointmentDTOs[i].Vehicle, err = a.appointmentVehicle(appointment)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+	}
+
+	if err = a.setUnreadMessages(appointmentDTOs, false); err != nil {
+		a.handleError(writer, err, 500)
+		return
 	}
 
 	a.sendResponse(writer, internal.NewCustomerAppointmentDTOs(appointmentDTOs), 200)
 }
 
+// setUnreadMessages fills in the number of unread messages sent by the
+// customer, or by the garage staff when fromCustomer is false.
+func (a *API) setUnreadMessages(appointmentDTOs []internal.AppointmentDTO, fromCustomer bool) error {
+	appointmentIDs := make([]int, len(appointmentDTOs))
+	for i, appointmentDTO := range appointmentDTOs {
+		appointmentIDs[i] = appointmentDTO.ID
+	}
+
+	counts, err := a.storage.Messages().CountUnread(appointmentIDs, fromCustomer)
+	if err != nil {
+		return err
+	}
+
+	for i := range appointmentDTOs {
+		appointmentDTOs[i].UnreadMessages = counts[appointmentDTOs[i].ID]
+	}
+
+	return nil
+}
+
 func (a *API) DeleteAppointment(writer http.ResponseWriter, request *http.Request) {
 	idStr := request.PathValue("id")
 	id, err := strconv.Atoi(idStr)
@@ -306,39 +570,23 @@ func (a *API) DeleteAppointment(writer http.ResponseWriter, request *http.Reques
 			a.handleError(writer, err, 401)
 			return
 		}
-		if customer.ID != appointment.CustomerID {
+		if customer.ID != appointment.CustomerID && !a.isCompanyAppointment(customer, appointment) {
 			a.handleError(writer, errors.New("appointment not found for this customer"), 404)
 			return
 		}
 
-	case internal.MechanicRole:
+	case internal.OwnerRole, internal.MechanicRole:
 		employee, err := a.storage.Employees().GetByEmail(email)
 		if err != nil {
 			a.handleError(writer, err, 401)
 			return
 		}
-		if employee.ID != appointment.EmployeeID {
-			a.handleError(writer, errors.New("appointment not found for this employee"), 404)
-			return
-		}
-
-	case internal.OwnerRole:
-		owner, err := a.storage.Employees().GetByEmail(email)
-		if err != nil {
-			a.handleError(writer, err, 401)
-			return
-		}
-		garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+		canHandle, err := a.canHandleAppointment(request, employee, appointment)
 		if err != nil {
-			a.handleError(writer, err, 404)
+			a.handleError(writer, err, 500)
 			return
 		}
-		employee, err := a.storage.Employees().GetByID(appointment.EmployeeID)
-		if err != nil {
-			a.handleError(writer, err, 404)
-			return
-		}
-		if *employee.GarageID != garage.ID {
+		if !canHandle {
 			a.handleError(writer, errors.New("appointment not found for this employee"), 404)
 			return
 		}
@@ -350,9 +598,245 @@ func (a *API) DeleteAppointment(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
+	a.publishAppointmentEvent(events.AppointmentCancelled, appointment)
+
+	a.offerFreedSlot(appointment)
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// RescheduleAppointment moves the appointment of the customer to another
+// start time, keeping its services and employee.
+func (a *API) RescheduleAppointment(writer http.ResponseWriter, request *http.Request) {
+	appointment, _, ok := a.customerAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	var dto internal.RescheduleAppointmentDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.RescheduleAppointmentDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if !appointmentChangeable(appointment) {
+		a.handleError(writer, errors.New("appointment cannot be rescheduled less than 24 hours before it starts"), 400)
+		return
+	}
+
+	_, moved, err := a.moveAppointment(appointment, dto.StartTime)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+	if !moved {
+		a.handleError(writer, errors.New("time slot not available"), 400)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// moveAppointment moves the appointment to start at the given time if the
+// slot is available, offering the freed one to the waitlist. It returns the
+// requested slot and whether the appointment was moved.
+func (a *API) moveAppointment(appointment internal.Appointment, start time.Time) (internal.TimeSlot, bool, error) {
+	lineItems, err := a.storage.Appointments().ListServices(appointment.ID)
+	if err != nil {
+		return internal.TimeSlot{}, false, err
+	}
+
+	duration := 0
+	for _, lineItem := range lineItems {
+		duration += lineItem.Time
+	}
+
+	slot := internal.TimeSlot{StartTime: start, EndTime: addWorkingHours(start, duration)}
+	available, err := a.slotAvailable(slot, duration, appointment.EmployeeID, appointment.CustomerID, appointment.ID)
+	if err != nil || !available {
+		return slot, false, err
+	}
+
+	freed := appointment
+	appointment.StartTime = slot.StartTime
+	appointment.EndTime = slot.EndTime
+	if err = a.storage.Appointments().Update(appointment); err != nil {
+		return slot, false, err
+	}
+
+	a.releaseSlotHolds(slot, appointment.EmployeeID, appointment.CustomerID)
+
+	a.publishAppointmentEvent(events.AppointmentUpdated, appointment)
+
+	a.offerFreedSlot(freed)
+
+	return slot, true, nil
+}
+
+// isCompanyAppointment reports whether the appointment was booked for a
+// vehicle of the company the customer belongs to.
+func (a *API) isCompanyAppointment(customer internal.Customer, appointment internal.Appointment) bool {
+	if appointment.CompanyID == nil {
+		return false
+	}
+
+	member, err := a.storage.Companies().GetMember(customer.ID)
+	return err == nil && member.CompanyID == *appointment.CompanyID
+}
+
+// appointmentChangeable reports whether the appointment can still be
+// cancelled or rescheduled by the customer.
+func appointmentChangeable(appointment internal.Appointment) bool {
+	return time.Until(appointment.StartTime) > 24*time.Hour
+}
+
+func (a *API) UpdateAppointmentNotes(writer http.ResponseWriter, request *http.Request) {
+	idStr := request.PathValue("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.AppointmentNotesDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.AppointmentNotesDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	appointment, err := a.storage.Appointments().GetByID(id)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	canHandle, err := a.canHandleAppointment(request, employee, appointment)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+	if !canHandle {
+		a.handleError(writer, errors.New("appointment not found for this employee"), 404)
+		return
+	}
+
+	if time.Now().Before(appointment.StartTime) {
+		a.handleError(writer, errors.New("appointment has not started yet"), 400)
+		return
+	}
+
+	appointment.Mileage = dto.Mileage
+	appointment.Notes = &dto.Notes
+
+	if err = a.storage.Appointments().Update(appointment); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.publishAppointmentEvent(events.AppointmentUpdated, appointment)
+
 	a.sendResponse(writer, nil, 200)
 }
 
+// canHandleAppointment reports whether the employee may act on the appointment:
+// either it is assigned to them or they manage appointments of its garage.
+func (a *API) canHandleAppointment(request *http.Request, employee internal.Employee, appointment internal.Appointment) (bool, error) {
+	if employee.ID == appointment.EmployeeID {
+		return true, nil
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		return false, nil
+	}
+
+	canManage, err := a.hasPermission(employee, garage, internal.AppointmentsManagePermission)
+	if err != nil || !canManage {
+		return false, err
+	}
+
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		return false, err
+	}
+
+	return service.GarageID == garage.ID, nil
+}
+
+// customerAppointment loads the appointment from the request path together
+// with its garage, provided it belongs to the customer or their company.
+func (a *API) customerAppointment(writer http.ResponseWriter, request *http.Request) (internal.Appointment, internal.Garage, bool) {
+	appointmentIDStr := request.PathValue("id")
+	appointmentID, err := strconv.Atoi(appointmentIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	appointment, err := a.storage.Appointments().GetByID(appointmentID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	if appointment.CustomerID != customer.ID && !a.isCompanyAppointment(customer, appointment) {
+		a.handleError(writer, errors.New("appointment not found for this customer"), 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	return appointment, garage, true
+}
+
 func createTimeSlots(date time.Time, serviceDuration int) []internal.TimeSlot {
 	var timeSlots []internal.TimeSlot
 
@@ -363,24 +847,9 @@ func createTimeSlots(date time.Time, serviceDuration int) []internal.TimeSlot {
 	startTime := time.Date(date.Year(), date.Month(), date.Day(), openingTime, 0, 0, 0, date.Location())
 
 	for startTime.Hour() < closingTime+1 {
-		endTime := startTime
-		timeLeft := time.Duration(serviceDuration) * time.Hour
-
-		for timeLeft > 0 {
-			if endTime.Hour() == closingTime {
-				if endTime.Weekday() == time.Friday {
-					endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day()+3, openingTime, 0, 0, 0, endTime.Location())
-				} else {
-					endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day()+1, openingTime, 0, 0, 0, endTime.Location())
-				}
-			}
-			endTime = endTime.Add(time.Hour)
-			timeLeft = timeLeft - time.Hour
-		}
-
 		timeSlot := internal.TimeSlot{
 			StartTime: startTime,
-			EndTime:   endTime,
+			EndTime:   addWorkingHours(startTime, serviceDuration),
 		}
 
 		timeSlots = append(timeSlots, timeSlot)
@@ -391,6 +860,27 @@ func createTimeSlots(date time.Time, serviceDuration int) []internal.TimeSlot {
 	return timeSlots
 }
 
+// addWorkingHours moves the time forward by the given number of working
+// hours, continuing on the next working day after closing time.
+func addWorkingHours(start time.Time, hours int) time.Time {
+	endTime := start
+	timeLeft := time.Duration(hours) * time.Hour
+
+	for timeLeft > 0 {
+		if endTime.Hour() == closingTime {
+			if endTime.Weekday() == time.Friday {
+				endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day()+3, openingTime, 0, 0, 0, endTime.Location())
+			} else {
+				endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day()+1, openingTime, 0, 0, 0, endTime.Location())
+			}
+		}
+		endTime = endTime.Add(time.Hour)
+		timeLeft = timeLeft - time.Hour
+	}
+
+	return endTime
+}
+
 func appointmentsWithWorkingHours(appointments []internal.Appointment, date time.Time) []internal.Appointment {
 	for i := range appointments {
 		if !appointments[i].StartTime.Truncate(24 * time.Hour).Equal(date.Truncate(24 * time.Hour)) {
@@ -409,3 +899,39 @@ func isWeekend(date time.Time) bool {
 	}
 	return false
 }
+
+// sendAppointmentConfirmation emails the customer the details of the booked
+// appointment with an .ics file adding it to their calendar. Guests without
+// an email are skipped.
+func (a *API) sendAppointmentConfirmation(customer internal.Customer, garage internal.Garage, appointment internal.Appointment, services []internal.Service) error {
+	if customer.Email == "" {
+		return nil
+	}
+
+	calendarEvents, err := a.calendarEvents([]internal.Appointment{appointment})
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = service.Name
+	}
+
+	return a.mail.Send(
+		customer.Email,
+		"Potwierdzenie wizyty",
+		mail.AppointmentConfirmationTemplate,
+		mail.AppointmentConfirmation{
+			GarageName: garage.Name,
+			Services:   strings.Join(names, ", "),
+			StartTime:  appointment.StartTime.Format("2006-01-02 15:04"),
+			Address:    garageAddress(garage),
+		},
+		mail.Attachment{
+			Name:        "wizyta.ics",
+			ContentType: ical.ContentType,
+			Data:        ical.Encode(garage.Name, calendarEvents, time.Now()),
+		},
+	)
+}
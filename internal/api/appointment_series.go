@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+// createAppointmentSeries books the occurrences of a recurring appointment.
+// The first one is checked by CreateAppointment, the following ones that are
+// not available are skipped and reported as conflicts.
+func (a *API) createAppointmentSeries(writer http.ResponseWriter, dto internal.CreateAppointmentDTO, customer internal.Customer, booking booking) {
+	series, err := a.storage.AppointmentSeries().Insert(internal.NewAppointmentSeries(*dto.Recurrence, customer.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	duration := servicesDuration(booking.services)
+	var appointments []internal.Appointment
+	var conflicts []internal.TimeSlot
+	for i, start := range series.Occurrences(dto.StartTime, series.Count) {
+		slot := internal.TimeSlot{StartTime: start, EndTime: addWorkingHours(start, duration)}
+		if i > 0 {
+			available, err := a.slotAvailable(slot, duration, booking.employee.ID, customer.ID)
+			if err != nil {
+				a.handleError(writer, err, 500)
+				return
+			}
+			if !available {
+				conflicts = append(conflicts, slot)
+				continue
+			}
+		}
+
+		occurrence := dto
+		occurrence.StartTime = slot.StartTime
+		occurrence.EndTime = slot.EndTime
+		appointment := internal.NewAppointment(occurrence, customer.ID)
+		appointment.SeriesID = &series.ID
+		appointment.CompanyID = booking.companyID
+		appointment.Services = appointmentLineItems(booking.services, booking.garage)
+		appointment, err = a.storage.Appointments().Insert(appointment)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+
+		a.releaseSlotHolds(slot, booking.employee.ID, customer.ID)
+
+		a.publishAppointmentEvent(events.AppointmentCreated, appointment)
+
+		appointments = append(appointments, appointment)
+	}
+
+	if err = a.sendAppointmentConfirmation(customer, booking.garage, appointments[0], booking.services); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, internal.NewAppointmentSeriesDTO(series, appointments, conflicts), 201)
+}
+
+func (a *API) GetAppointmentSeries(writer http.ResponseWriter, request *http.Request) {
+	series, appointments, ok := a.customerSeries(writer, request)
+	if !ok {
+		return
+	}
+
+	a.sendResponse(writer, internal.NewAppointmentSeriesDTO(series, appointments, nil), 200)
+}
+
+// RescheduleAppointmentSeries moves the appointments of the series that can
+// still be changed so that the first of them starts at the given time and
+// the following ones keep the recurrence. Appointments whose new slot is not
+// available stay where they were and are reported as conflicts.
+func (a *API) RescheduleAppointmentSeries(writer http.ResponseWriter, request *http.Request) {
+	series, appointments, ok := a.customerSeries(writer, request)
+	if !ok {
+		return
+	}
+
+	var dto internal.RescheduleAppointmentDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.RescheduleAppointmentDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var upcoming []internal.Appointment
+	for _, appointment := range appointments {
+		if appointmentChangeable(appointment) {
+			upcoming = append(upcoming, appointment)
+		}
+	}
+	if len(upcoming) == 0 {
+		a.handleError(writer, errors.New("appointment series has no appointments that can be rescheduled"), 400)
+		return
+	}
+
+	// Moving later starts from the last appointment and moving earlier from
+	// the first one, so that the slots of the following appointments are
+	// already free when they are needed and an appointment that cannot be
+	// moved still blocks its slot.
+	starts := series.Occurrences(dto.StartTime, len(upcoming))
+	order := make([]int, len(upcoming))
+	for i := range order {
+		order[i] = i
+	}
+	if dto.StartTime.After(upcoming[0].StartTime) {
+		slices.Reverse(order)
+	}
+
+	var conflicts []internal.TimeSlot
+	for _, i := range order {
+		slot, moved, err := a.moveAppointment(upcoming[i], starts[i])
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		if !moved {
+			conflicts = append(conflicts, slot)
+		}
+	}
+	slices.SortFunc(conflicts, func(first, second internal.TimeSlot) int {
+		return first.StartTime.Compare(second.StartTime)
+	})
+
+	appointments, err = a.storage.Appointments().ListBySeriesID(series.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewAppointmentSeriesDTO(series, appointments, conflicts), 200)
+}
+
+// CancelAppointmentSeries cancels the appointments of the series that can
+// still be changed, the ones starting within 24 hours are kept.
+func (a *API) CancelAppointmentSeries(writer http.ResponseWriter, request *http.Request) {
+	_, appointments, ok := a.customerSeries(writer, request)
+	if !ok {
+		return
+	}
+
+	for _, appointment := range appointments {
+		if !appointmentChangeable(appointment) {
+			continue
+		}
+
+		if err := a.storage.Appointments().Delete(appointment.ID); err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+
+		a.publishAppointmentEvent(events.AppointmentCancelled, appointment)
+
+		a.offerFreedSlot(appointment)
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// customerSeries loads the appointment series from the request path together
+// with its appointments, provided it belongs to the customer or their company.
+func (a *API) customerSeries(writer http.ResponseWriter, request *http.Request) (internal.AppointmentSeries, []internal.Appointment, bool) {
+	seriesIDStr := request.PathValue("id")
+	seriesID, err := strconv.Atoi(seriesIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	series, err := a.storage.AppointmentSeries().GetByID(seriesID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	appointments, err := a.storage.Appointments().ListBySeriesID(series.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	if series.CustomerID != customer.ID &&
+		(len(appointments) == 0 || !a.isCompanyAppointment(customer, appointments[0])) {
+		a.handleError(writer, errors.New("appointment series not found for this customer"), 404)
+		return internal.AppointmentSeries{}, nil, false
+	}
+
+	return series, appointments, true
+}
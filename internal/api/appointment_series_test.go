@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppointmentSeriesEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	fleetToken := suite.CreateCustomer(t, internal.Customer{Email: "fleet@example.com", Password: "Password123"})
+	otherToken := suite.CreateCustomer(t, internal.Customer{Email: "other@example.com", Password: "Password123"})
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:         "name",
+			City:         "city",
+			Street:       "street",
+			Number:       "number",
+			PostalCode:   "postalCode",
+			PhoneNumber:  "phoneNumber",
+			OwnerID:      owner.ID,
+			Latitude:     10,
+			Longitude:    10,
+			WaitlistMode: internal.FirstComeWaitlist,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     2,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	book := func(token *internal.Token, start time.Time, recurrence *internal.RecurrenceDTO) *http.Response {
+		appointmentJSON, err := json.Marshal(internal.CreateAppointmentDTO{
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: mechanic.ID,
+			ModelID:    1,
+			Recurrence: recurrence,
+		})
+		require.NoError(t, err)
+		return suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, token)
+	}
+
+	response := book(otherToken, time.Date(2030, 10, 1, 11, 0, 0, 0, time.UTC), nil)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = book(fleetToken, time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC), &internal.RecurrenceDTO{
+		Frequency: internal.WeeklyRecurrence,
+		Interval:  1,
+		Count:     3,
+	})
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var series internal.AppointmentSeriesDTO
+	suite.ParseResponse(t, response, &series)
+	require.Len(t, series.Appointments, 2)
+	assert.True(t, series.Appointments[0].StartTime.Equal(time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC)))
+	assert.True(t, series.Appointments[1].StartTime.Equal(time.Date(2030, 10, 8, 11, 0, 0, 0, time.UTC)))
+	require.Len(t, series.Conflicts, 1)
+	assert.True(t, series.Conflicts[0].StartTime.Equal(time.Date(2030, 10, 1, 11, 0, 0, 0, time.UTC)))
+
+	seriesPath := fmt.Sprintf("/api/appointment-series/%v", series.ID)
+	response = suite.CallAPI(http.MethodGet, seriesPath, []byte{}, otherToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, seriesPath, []byte{}, fleetToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &series)
+	require.Len(t, series.Appointments, 2)
+	assert.Empty(t, series.Conflicts)
+
+	var customerAppointments internal.CustomerAppointmentDTOs
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, fleetToken)
+	suite.ParseResponse(t, response, &customerAppointments)
+	require.Len(t, customerAppointments.Upcoming, 2)
+	assert.Equal(t, series.ID, *customerAppointments.Upcoming[0].SeriesID)
+
+	scheduleJSON, err := json.Marshal(internal.RescheduleAppointmentDTO{StartTime: time.Date(2030, 10, 8, 14, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/appointments/%v/schedule", series.Appointments[1].ID), scheduleJSON, fleetToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	scheduleJSON, err = json.Marshal(internal.RescheduleAppointmentDTO{StartTime: time.Date(2030, 10, 1, 10, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/appointments/%v/schedule", series.Appointments[0].ID), scheduleJSON, fleetToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = book(otherToken, time.Date(2030, 10, 2, 11, 0, 0, 0, time.UTC), nil)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	scheduleJSON, err = json.Marshal(internal.RescheduleAppointmentDTO{StartTime: time.Date(2030, 9, 25, 11, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, seriesPath, scheduleJSON, fleetToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &series)
+	require.Len(t, series.Appointments, 2)
+	assert.True(t, series.Appointments[0].StartTime.Equal(time.Date(2030, 9, 25, 11, 0, 0, 0, time.UTC)))
+	assert.True(t, series.Appointments[1].StartTime.Equal(time.Date(2030, 10, 8, 14, 0, 0, 0, time.UTC)))
+	require.Len(t, series.Conflicts, 1)
+	assert.True(t, series.Conflicts[0].StartTime.Equal(time.Date(2030, 10, 2, 11, 0, 0, 0, time.UTC)))
+
+	response = suite.CallAPI(http.MethodDelete, seriesPath, []byte{}, otherToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, seriesPath, []byte{}, fleetToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, fleetToken)
+	suite.ParseResponse(t, response, &customerAppointments)
+	assert.Empty(t, customerAppointments.Upcoming)
+}
@@ -109,6 +109,102 @@ func TestCreateAppointmentEndpoint(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.StatusCode)
 }
 
+func TestCreateMultiServiceAppointmentEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	token := suite.CreateCustomer(t,
+		internal.Customer{
+			Email:    "john.doe@example.com",
+			Password: "Password123",
+		})
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	oilChange, err := suite.api.storage.Services().Insert(internal.Service{Name: "oil change", Time: 2, Price: 10, GarageID: garage.ID})
+	require.NoError(t, err)
+	tyreSwap, err := suite.api.storage.Services().Insert(internal.Service{Name: "tyre swap", Time: 1, Price: 5, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(
+		http.MethodGet,
+		fmt.Sprintf("/api/appointments/availableSlots?serviceId=%v&serviceId=%v&employeeId=%v&date=2030-09-24", oilChange.ID, tyreSwap.ID, mechanic.ID),
+		[]byte{},
+		nil,
+	)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var slots []internal.TimeSlot
+	suite.ParseResponse(t, response, &slots)
+	require.NotEmpty(t, slots)
+	assert.Equal(t, 3*time.Hour, slots[0].EndTime.Sub(slots[0].StartTime))
+
+	appointment := internal.CreateAppointmentDTO{
+		StartTime:  time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2030, 9, 24, 13, 0, 0, 0, time.UTC),
+		ServiceIDs: []int{oilChange.ID, tyreSwap.ID},
+		EmployeeID: mechanic.ID,
+		ModelID:    1,
+	}
+	appointmentJSON, err := json.Marshal(appointment)
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, token)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	appointment.EndTime = time.Date(2030, 9, 24, 14, 0, 0, 0, time.UTC)
+	appointmentJSON, err = json.Marshal(appointment)
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, token)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var appointments internal.CustomerAppointmentDTOs
+	suite.ParseResponse(t, response, &appointments)
+	require.Len(t, appointments.Upcoming, 1)
+	assert.Equal(t, oilChange.ID, appointments.Upcoming[0].Service.ID)
+	require.Len(t, appointments.Upcoming[0].Services, 2)
+	assert.Equal(t, "tyre swap", appointments.Upcoming[0].Services[1].Name)
+	assert.Equal(t, 15, appointments.Upcoming[0].TotalPrice.Gross)
+}
+
 func TestGetEmployeeAndCustomerAppointmentsEndpoint(t *testing.T) {
 	suite := NewSuite(t)
 	defer suite.Teardown()
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/KsaweryZietara/garage/internal"
@@ -109,6 +111,11 @@ func (a *API) CreateCustomer(writer http.ResponseWriter, request *http.Request)
 	}
 	customer.Password = hash
 
+	if _, err = a.storage.Customers().GetByEmail(dto.Email); err == nil {
+		a.handleError(writer, errors.New("customer already exists"), 409)
+		return
+	}
+
 	_, err = a.storage.Customers().Insert(customer)
 	if err != nil {
 		a.handleError(writer, err, 500)
@@ -118,6 +125,63 @@ func (a *API) CreateCustomer(writer http.ResponseWriter, request *http.Request)
 	a.sendResponse(writer, nil, 201)
 }
 
+// ClaimCustomer turns the guest invited with the code into a full account,
+// keeping the bookings made for them by the staff.
+func (a *API) ClaimCustomer(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateCustomerDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateCustomerDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	invitation, err := a.storage.CustomerInvitations().GetByID(request.PathValue("code"))
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByID(invitation.CustomerID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+	if !customer.Guest {
+		a.handleError(writer, errors.New("invitation not found"), 404)
+		return
+	}
+
+	if existing, err := a.storage.Customers().GetByEmail(dto.Email); err == nil && existing.ID != customer.ID {
+		a.handleError(writer, errors.New("customer already exists"), 409)
+		return
+	}
+
+	hash, err := auth.HashPassword(dto.Password)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+	customer.Email = dto.Email
+	customer.Password = hash
+
+	if err = a.storage.Customers().Claim(customer); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if err = a.storage.CustomerInvitations().DeleteByCustomerID(customer.ID); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
 func (a *API) LoginCustomer(writer http.ResponseWriter, request *http.Request) {
 	var dto internal.LoginDTO
 	err := json.NewDecoder(request.Body).Decode(&dto)
@@ -185,3 +249,35 @@ func (a *API) LoginEmployee(writer http.ResponseWriter, request *http.Request) {
 
 	a.sendResponse(writer, token, 200)
 }
+
+// LoginAdmin signs in the catalog administrator whose credentials come from the
+// server configuration. Admin login is disabled when no password is configured.
+func (a *API) LoginAdmin(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.LoginDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.LoginDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if a.adminPassword == "" ||
+		subtle.ConstantTimeCompare([]byte(dto.Email), []byte(a.adminEmail)) != 1 ||
+		subtle.ConstantTimeCompare([]byte(dto.Password), []byte(a.adminPassword)) != 1 {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	token, err := a.auth.CreateToken(a.adminEmail, internal.AdminRole)
+	if err != nil {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	a.sendResponse(writer, token, 200)
+}
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/ical"
+
+	"github.com/google/uuid"
+)
+
+const (
+	calendarPast   = 30 * 24 * time.Hour
+	calendarFuture = 180 * 24 * time.Hour
+)
+
+// GetCalendar serves the iCalendar feed identified by the token. The token is
+// the only credential, so calendar applications can subscribe to the URL.
+func (a *API) GetCalendar(writer http.ResponseWriter, request *http.Request) {
+	token, err := a.storage.CalendarTokens().GetByID(request.PathValue("token"))
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	start := time.Now().Add(-calendarPast)
+	end := time.Now().Add(calendarFuture)
+
+	var name string
+	var appointments []internal.Appointment
+	if token.EmployeeID != nil {
+		employee, err := a.storage.Employees().GetByID(*token.EmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		if employee.IsDeleted {
+			a.handleError(writer, errors.New("calendar not found"), 404)
+			return
+		}
+		name = employee.Name + " " + employee.Surname
+
+		appointments, err = a.storage.Appointments().ListByEmployeeIDBetween(employee.ID, start, end)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+	} else {
+		garage, err := a.storage.Garages().GetByID(*token.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		name = garage.Name
+
+		appointments, err = a.storage.Appointments().ListByGarageIDBetween(garage.ID, start, end)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+	}
+
+	calendarEvents, err := a.calendarEvents(appointments)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	writer.Header().Set("Content-Type", ical.ContentType)
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(200)
+	if _, err = writer.Write(ical.Encode(name, calendarEvents, time.Now())); err != nil {
+		a.log.Error("unable to write calendar", "error", err)
+	}
+}
+
+func (a *API) GetEmployeeCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	employee, ok := a.calendarEmployee(writer, request)
+	if !ok {
+		return
+	}
+
+	token, err := a.storage.CalendarTokens().GetByEmployeeID(employee.ID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCalendarTokenDTO(token), 200)
+}
+
+// CreateEmployeeCalendarToken issues a new feed URL of the employee, the
+// previous one stops working.
+func (a *API) CreateEmployeeCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	employee, ok := a.calendarEmployee(writer, request)
+	if !ok {
+		return
+	}
+
+	token, err := a.storage.CalendarTokens().Insert(internal.CalendarToken{
+		ID:         uuid.New().String(),
+		EmployeeID: &employee.ID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCalendarTokenDTO(token), 201)
+}
+
+func (a *API) DeleteEmployeeCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	employee, ok := a.calendarEmployee(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := a.storage.CalendarTokens().DeleteByEmployeeID(employee.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) GetGarageCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	garage, ok := a.calendarGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	token, err := a.storage.CalendarTokens().GetByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCalendarTokenDTO(token), 200)
+}
+
+// CreateGarageCalendarToken issues a new feed URL of the garage, the previous
+// one stops working.
+func (a *API) CreateGarageCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	garage, ok := a.calendarGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	token, err := a.storage.CalendarTokens().Insert(internal.CalendarToken{
+		ID:        uuid.New().String(),
+		GarageID:  &garage.ID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCalendarTokenDTO(token), 201)
+}
+
+func (a *API) DeleteGarageCalendarToken(writer http.ResponseWriter, request *http.Request) {
+	garage, ok := a.calendarGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := a.storage.CalendarTokens().DeleteByGarageID(garage.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) calendarEmployee(writer http.ResponseWriter, request *http.Request) (internal.Employee, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Employee{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Employee{}, false
+	}
+
+	return employee, true
+}
+
+func (a *API) calendarGarage(writer http.ResponseWriter, request *http.Request) (internal.Garage, bool) {
+	employee, ok := a.calendarEmployee(writer, request)
+	if !ok {
+		return internal.Garage{}, false
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Garage{}, false
+	}
+
+	return garage, true
+}
+
+// calendarEvents describes the appointments as calendar events. Services,
+// garages and cars are shared by many appointments, so each is loaded once.
+func (a *API) calendarEvents(appointments []internal.Appointment) ([]ical.Event, error) {
+	services := make(map[int]internal.Service)
+	garages := make(map[int]internal.Garage)
+	cars := make(map[int]internal.Car)
+
+	calendarEvents := make([]ical.Event, len(appointments))
+	for i, appointment := range appointments {
+		service, ok := services[appointment.ServiceID]
+		if !ok {
+			var err error
+			if service, err = a.storage.Services().GetByID(appointment.ServiceID); err != nil {
+				return nil, err
+			}
+			services[service.ID] = service
+		}
+
+		garage, ok := garages[service.GarageID]
+		if !ok {
+			var err error
+			if garage, err = a.storage.Garages().GetByID(service.GarageID); err != nil {
+				return nil, err
+			}
+			garages[garage.ID] = garage
+		}
+
+		car, ok := cars[appointment.ModelID]
+		if !ok {
+			var err error
+			if car, err = a.storage.Cars().GetByModelID(appointment.ModelID); err != nil {
+				return nil, err
+			}
+			cars[appointment.ModelID] = car
+		}
+
+		calendarEvents[i] = ical.Event{
+			UID:         fmt.Sprintf("appointment-%d@garage", appointment.ID),
+			Start:       appointment.StartTime,
+			End:         appointment.EndTime,
+			Summary:     fmt.Sprintf("%s – %s %s", service.Name, car.Make, car.Model),
+			Description: garage.Name,
+			Location:    garageAddress(garage),
+		}
+	}
+
+	return calendarEvents, nil
+}
+
+func garageAddress(garage internal.Garage) string {
+	return fmt.Sprintf("%s %s, %s %s", garage.Street, garage.Number, garage.PostalCode, garage.City)
+}
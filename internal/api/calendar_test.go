@@ -0,0 +1,148 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/ical"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name1",
+			Surname:   "surname1",
+			Email:     "email1",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "Oil change",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	for _, employeeID := range []int{owner.ID, mechanic.ID} {
+		_, err = suite.api.storage.Appointments().Insert(
+			internal.Appointment{
+				StartTime:  time.Now().Add(48 * time.Hour),
+				EndTime:    time.Now().Add(49 * time.Hour),
+				ServiceID:  service.ID,
+				EmployeeID: employeeID,
+				CustomerID: customer.ID,
+				ModelID:    1,
+			})
+		require.NoError(t, err)
+	}
+
+	mechanicToken, err := suite.api.auth.CreateToken(mechanic.Email, internal.MechanicRole)
+	require.NoError(t, err)
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodGet, "/api/employees/calendar-token", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/employees/calendar-token", []byte{}, &mechanicToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var employeeToken internal.CalendarTokenDTO
+	suite.ParseResponse(t, response, &employeeToken)
+	assert.Equal(t, "/api/calendars/"+employeeToken.Token, employeeToken.Path)
+
+	response = suite.CallAPI(http.MethodGet, employeeToken.Path, []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, ical.ContentType, response.Header.Get("Content-Type"))
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "X-WR-CALNAME:name1 surname1")
+	assert.Equal(t, 1, strings.Count(string(body), "BEGIN:VEVENT"))
+	assert.Contains(t, string(body), "SUMMARY:Oil change")
+
+	response = suite.CallAPI(http.MethodPost, "/api/employees/calendar-token", []byte{}, &mechanicToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var rotatedToken internal.CalendarTokenDTO
+	suite.ParseResponse(t, response, &rotatedToken)
+	assert.NotEqual(t, employeeToken.Token, rotatedToken.Token)
+
+	response = suite.CallAPI(http.MethodGet, employeeToken.Path, []byte{}, nil)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/calendar-token", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/calendar-token", []byte{}, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var garageToken internal.CalendarTokenDTO
+	suite.ParseResponse(t, response, &garageToken)
+
+	response = suite.CallAPI(http.MethodGet, garageToken.Path, []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	body, err = io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(body), "BEGIN:VEVENT"))
+
+	response = suite.CallAPI(http.MethodDelete, "/api/garages/calendar-token", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/calendars/%s", garageToken.Token), []byte{}, nil)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/employees/%v", mechanic.ID), []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, rotatedToken.Path, []byte{}, nil)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
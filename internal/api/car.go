@@ -1,8 +1,13 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
 )
 
 func (a *API) ListMakes(writer http.ResponseWriter, _ *http.Request) {
@@ -31,3 +36,215 @@ func (a *API) ListModels(writer http.ResponseWriter, request *http.Request) {
 
 	a.sendResponse(writer, models, 200)
 }
+
+func (a *API) CreateMake(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CatalogMakeDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CatalogMakeDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	carMake, err := a.storage.Cars().InsertMake(internal.Make{Name: dto.Name})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, carMake, 201)
+}
+
+func (a *API) UpdateMake(writer http.ResponseWriter, request *http.Request) {
+	makeIDStr := request.PathValue("id")
+	makeID, err := strconv.Atoi(makeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CatalogMakeDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CatalogMakeDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	carMake, err := a.storage.Cars().GetMakeByID(makeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	carMake.Name = dto.Name
+	if err = a.storage.Cars().UpdateMake(carMake); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, carMake, 200)
+}
+
+func (a *API) MergeMakes(writer http.ResponseWriter, request *http.Request) {
+	makeIDStr := request.PathValue("id")
+	makeID, err := strconv.Atoi(makeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.MergeDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if dto.TargetID == makeID {
+		a.handleError(writer, errors.New("cannot merge make into itself"), 400)
+		return
+	}
+
+	if _, err = a.storage.Cars().GetMakeByID(makeID); err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	target, err := a.storage.Cars().GetMakeByID(dto.TargetID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if err = a.storage.Cars().MergeMakes(makeID, target.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, target, 200)
+}
+
+func (a *API) CreateModel(writer http.ResponseWriter, request *http.Request) {
+	makeIDStr := request.PathValue("id")
+	makeID, err := strconv.Atoi(makeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CatalogModelDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CatalogModelDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if _, err = a.storage.Cars().GetMakeByID(makeID); err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	model, err := a.storage.Cars().InsertModel(internal.NewModel(dto, makeID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, model, 201)
+}
+
+func (a *API) UpdateModel(writer http.ResponseWriter, request *http.Request) {
+	modelIDStr := request.PathValue("id")
+	modelID, err := strconv.Atoi(modelIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CatalogModelDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CatalogModelDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	model, err := a.storage.Cars().GetModelByID(modelID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	model.Name = dto.Name
+	model.YearFrom = dto.YearFrom
+	model.YearTo = dto.YearTo
+	model.BodyType = dto.BodyType
+
+	if err = a.storage.Cars().UpdateModel(model); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, model, 200)
+}
+
+func (a *API) MergeModels(writer http.ResponseWriter, request *http.Request) {
+	modelIDStr := request.PathValue("id")
+	modelID, err := strconv.Atoi(modelIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.MergeDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if dto.TargetID == modelID {
+		a.handleError(writer, errors.New("cannot merge model into itself"), 400)
+		return
+	}
+
+	if _, err = a.storage.Cars().GetModelByID(modelID); err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	target, err := a.storage.Cars().GetModelByID(dto.TargetID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if err = a.storage.Cars().MergeModels(modelID, target.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, target, 200)
+}
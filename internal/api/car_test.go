@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -27,3 +28,69 @@ func TestCar(t *testing.T) {
 	suite.ParseResponse(t, response, &models)
 	assert.NotEmpty(t, models)
 }
+
+func TestCarCatalog(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	adminToken, err := suite.api.auth.CreateToken("admin@example.com", internal.AdminRole)
+	require.NoError(t, err)
+	customerToken := suite.CreateCustomer(t, internal.Customer{Email: "john.doe@example.com", Password: "password"})
+
+	makeJSON, err := json.Marshal(internal.CatalogMakeDTO{Name: "Catalog Make"})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/makes", makeJSON, customerToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/makes", makeJSON, &adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var carMake internal.Make
+	suite.ParseResponse(t, response, &carMake)
+
+	bodyType := internal.SedanBody
+	modelJSON, err := json.Marshal(internal.CatalogModelDTO{Name: "Alpha", BodyType: &bodyType})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/makes/%v/models", carMake.ID), modelJSON, &adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var model internal.Model
+	suite.ParseResponse(t, response, &model)
+	require.NotNil(t, model.BodyType)
+	assert.Equal(t, bodyType, *model.BodyType)
+
+	modelJSON, err = json.Marshal(internal.CatalogModelDTO{Name: "Alpha II"})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/makes/%v/models", carMake.ID), modelJSON, &adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var duplicate internal.Model
+	suite.ParseResponse(t, response, &duplicate)
+
+	mergeJSON, err := json.Marshal(internal.MergeDTO{TargetID: duplicate.ID})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/models/%v/merge", duplicate.ID), mergeJSON, &adminToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	mergeJSON, err = json.Marshal(internal.MergeDTO{TargetID: model.ID})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/models/%v/merge", duplicate.ID), mergeJSON, &adminToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/makes/%v/models", carMake.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var models []internal.Model
+	suite.ParseResponse(t, response, &models)
+	require.Len(t, models, 1)
+	assert.Equal(t, model.ID, models[0].ID)
+
+	makeJSON, err = json.Marshal(internal.CatalogMakeDTO{Name: "Renamed Make"})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/makes/%v", carMake.ID), makeJSON, &adminToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &carMake)
+	assert.Equal(t, "Renamed Make", carMake.Name)
+}
@@ -0,0 +1,513 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/pdf"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+// CreateCompany creates a business account with the customer as its admin.
+func (a *API) CreateCompany(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateCompanyDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateCompanyDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	if _, err = a.storage.Companies().GetMember(customer.ID); err == nil {
+		a.handleError(writer, errors.New("customer already belongs to a company"), 409)
+		return
+	}
+
+	company, err := a.storage.Companies().Insert(internal.NewCompany(dto), customer.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	member := internal.CompanyMember{CompanyID: company.ID, CustomerID: customer.ID, Role: internal.CompanyAdmin}
+	a.sendResponse(writer, internal.NewCompanyDTO(company, internal.CompanyAdmin, []internal.CompanyMemberDTO{
+		internal.NewCompanyMemberDTO(member, customer),
+	}), 201)
+}
+
+func (a *API) GetCompany(writer http.ResponseWriter, request *http.Request) {
+	_, member, company, ok := a.companyMember(writer, request)
+	if !ok {
+		return
+	}
+
+	memberDTOs, err := a.companyMemberDTOs(company.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCompanyDTO(company, member.Role, memberDTOs), 200)
+}
+
+func (a *API) UpdateCompany(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateCompanyDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateCompanyDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	_, member, company, ok := a.companyMember(writer, request, internal.CompanyAdmin)
+	if !ok {
+		return
+	}
+
+	company.Name = dto.Name
+	company.TaxID = dto.TaxID
+	company.BillingEmail = dto.BillingEmail
+
+	if err = a.storage.Companies().Update(company); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	memberDTOs, err := a.companyMemberDTOs(company.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCompanyDTO(company, member.Role, memberDTOs), 200)
+}
+
+// CreateCompanyMember adds an existing customer account to the company.
+func (a *API) CreateCompanyMember(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateCompanyMemberDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateCompanyMemberDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	_, _, company, ok := a.companyMember(writer, request, internal.CompanyAdmin)
+	if !ok {
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(dto.Email)
+	if err != nil {
+		a.handleError(writer, errors.New("customer not found"), 404)
+		return
+	}
+
+	if _, err = a.storage.Companies().GetMember(customer.ID); err == nil {
+		a.handleError(writer, errors.New("customer already belongs to a company"), 409)
+		return
+	}
+
+	member := internal.CompanyMember{CompanyID: company.ID, CustomerID: customer.ID, Role: dto.Role}
+	if err = a.storage.Companies().InsertMember(member); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCompanyMemberDTO(member, customer), 201)
+}
+
+func (a *API) UpdateCompanyMember(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.UpdateCompanyMemberDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CompanyRole(dto.Role)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	_, _, company, ok := a.companyMember(writer, request, internal.CompanyAdmin)
+	if !ok {
+		return
+	}
+
+	member, members, ok := a.pathCompanyMember(writer, request, company)
+	if !ok {
+		return
+	}
+
+	if dto.Role != internal.CompanyAdmin && isLastAdmin(members, member) {
+		a.handleError(writer, errors.New("company must have an admin"), 400)
+		return
+	}
+
+	member.Role = dto.Role
+	if err = a.storage.Companies().UpdateMember(member); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByID(member.CustomerID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCompanyMemberDTO(member, customer), 200)
+}
+
+// DeleteCompanyMember removes a member from the company. Admins can remove
+// anyone, other members only themselves.
+func (a *API) DeleteCompanyMember(writer http.ResponseWriter, request *http.Request) {
+	customer, requester, company, ok := a.companyMember(writer, request)
+	if !ok {
+		return
+	}
+
+	member, members, ok := a.pathCompanyMember(writer, request, company)
+	if !ok {
+		return
+	}
+
+	if requester.Role != internal.CompanyAdmin && member.CustomerID != customer.ID {
+		a.handleError(writer, errors.New("insufficient company role"), 403)
+		return
+	}
+
+	if isLastAdmin(members, member) {
+		a.handleError(writer, errors.New("company must have an admin"), 400)
+		return
+	}
+
+	if err := a.storage.Companies().DeleteMember(company.ID, member.CustomerID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// ListCompanyAppointments returns the appointments booked for the company
+// vehicles by all of its members.
+func (a *API) ListCompanyAppointments(writer http.ResponseWriter, request *http.Request) {
+	_, _, company, ok := a.companyMember(writer, request)
+	if !ok {
+		return
+	}
+
+	appointments, err := a.storage.Appointments().ListByCompanyID(company.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	appointmentDTOs := make([]internal.AppointmentDTO, len(appointments))
+	for i, appointment := range appointments {
+		service, err := a.storage.Services().GetByID(appointment.ServiceID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		employee, err := a.storage.Employees().GetConfirmedByID(appointment.EmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		garage, err := a.storage.Garages().GetByID(service.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		car, err := a.storage.Cars().GetByModelID(appointment.ModelID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		services, err := a.storage.Appointments().ListServices(appointment.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		appointmentDTOs[i] = internal.NewAppointmentDTO(appointment, service, services, employee, garage, car)
+		customer, err := a.storage.Customers().GetByID(appointment.CustomerID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		customerDTO := internal.NewCustomerDTO(customer)
+		appointmentDTOs[i].Customer = &customerDTO
+		appointmentDTOs[i].Vehicle, err = a.appointmentVehicle(appointment)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+	}
+
+	a.sendResponse(writer, internal.NewCustomerAppointmentDTOs(appointmentDTOs), 200)
+}
+
+func (a *API) ListCompanyInvoices(writer http.ResponseWriter, request *http.Request) {
+	_, _, company, ok := a.companyMember(writer, request, internal.CompanyAdmin)
+	if !ok {
+		return
+	}
+
+	invoices, err := a.storage.Invoices().ListByCompanyID(company.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	invoiceDTOs := make([]internal.InvoiceDTO, len(invoices))
+	for i, invoice := range invoices {
+		invoiceDTOs[i] = internal.NewInvoiceDTO(invoice)
+	}
+
+	a.sendResponse(writer, invoiceDTOs, 200)
+}
+
+func (a *API) DownloadCompanyInvoice(writer http.ResponseWriter, request *http.Request) {
+	invoiceIDStr := request.PathValue("id")
+	invoiceID, err := strconv.Atoi(invoiceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	_, _, company, ok := a.companyMember(writer, request, internal.CompanyAdmin)
+	if !ok {
+		return
+	}
+
+	invoice, err := a.storage.Invoices().GetByID(invoiceID)
+	if err != nil || invoice.CompanyID == nil || *invoice.CompanyID != company.ID {
+		a.handleError(writer, errors.New("invoice not found"), 404)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoiceFileName(invoice)))
+	writer.WriteHeader(200)
+	if _, err := writer.Write(pdf.Invoice(invoice)); err != nil {
+		a.log.Error("unable to write invoice", "error", err)
+	}
+}
+
+// CreateCompanyInvoice issues the monthly invoice of a company for its
+// appointments at the garage that ended within the month and were not
+// invoiced separately. The invoice is emailed to the billing address of the
+// company.
+func (a *API) CreateCompanyInvoice(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateCompanyInvoiceDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateCompanyInvoiceDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	company, err := a.storage.Companies().GetByID(dto.CompanyID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	// The month is checked by validate.CreateCompanyInvoiceDTO.
+	month, _ := time.Parse("2006-01", dto.Month)
+	appointments, err := a.storage.Appointments().ListUninvoicedByCompanyID(company.ID, garage.ID, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+	if len(appointments) == 0 {
+		a.handleError(writer, errors.New("no appointments to invoice"), 400)
+		return
+	}
+
+	var items []internal.InvoiceItem
+	for _, appointment := range appointments {
+		prefix := appointment.StartTime.Format("2006-01-02") + ": "
+		if appointment.VehicleID != nil {
+			vehicle, err := a.storage.Vehicles().GetByID(*appointment.VehicleID)
+			if err != nil {
+				a.handleError(writer, err, 404)
+				return
+			}
+			prefix = appointment.StartTime.Format("2006-01-02") + " " + vehicle.PlateNumber + ": "
+		}
+
+		appointmentItems, err := a.appointmentInvoiceItems(appointment, garage, prefix)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		items = append(items, appointmentItems...)
+	}
+
+	invoice, err := a.storage.Invoices().Insert(internal.NewCompanyInvoice(company, garage, month, appointments, items))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if err = a.sendInvoice(invoice); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, internal.NewInvoiceDTO(invoice), 201)
+}
+
+// companyMember loads the company of the customer making the request. When
+// roles are given, the customer has to have one of them.
+func (a *API) companyMember(writer http.ResponseWriter, request *http.Request, roles ...internal.CompanyRole) (internal.Customer, internal.CompanyMember, internal.Company, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Customer{}, internal.CompanyMember{}, internal.Company{}, false
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Customer{}, internal.CompanyMember{}, internal.Company{}, false
+	}
+
+	member, err := a.storage.Companies().GetMember(customer.ID)
+	if err != nil {
+		a.handleError(writer, errors.New("company not found"), 404)
+		return internal.Customer{}, internal.CompanyMember{}, internal.Company{}, false
+	}
+
+	if len(roles) != 0 && !slices.Contains(roles, member.Role) {
+		a.handleError(writer, errors.New("insufficient company role"), 403)
+		return internal.Customer{}, internal.CompanyMember{}, internal.Company{}, false
+	}
+
+	company, err := a.storage.Companies().GetByID(member.CompanyID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Customer{}, internal.CompanyMember{}, internal.Company{}, false
+	}
+
+	return customer, member, company, true
+}
+
+// pathCompanyMember finds the member from the request path among the members
+// of the company, which are returned as well.
+func (a *API) pathCompanyMember(writer http.ResponseWriter, request *http.Request, company internal.Company) (internal.CompanyMember, []internal.CompanyMember, bool) {
+	customerIDStr := request.PathValue("customerId")
+	customerID, err := strconv.Atoi(customerIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.CompanyMember{}, nil, false
+	}
+
+	members, err := a.storage.Companies().ListMembers(company.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return internal.CompanyMember{}, nil, false
+	}
+
+	for _, member := range members {
+		if member.CustomerID == customerID {
+			return member, members, true
+		}
+	}
+
+	a.handleError(writer, errors.New("company member not found"), 404)
+	return internal.CompanyMember{}, nil, false
+}
+
+func (a *API) companyMemberDTOs(companyID int) ([]internal.CompanyMemberDTO, error) {
+	members, err := a.storage.Companies().ListMembers(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberDTOs := make([]internal.CompanyMemberDTO, len(members))
+	for i, member := range members {
+		customer, err := a.storage.Customers().GetByID(member.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		memberDTOs[i] = internal.NewCompanyMemberDTO(member, customer)
+	}
+
+	return memberDTOs, nil
+}
+
+// isLastAdmin reports whether the member is the only admin of the company.
+func isLastAdmin(members []internal.CompanyMember, member internal.CompanyMember) bool {
+	if member.Role != internal.CompanyAdmin {
+		return false
+	}
+
+	for _, other := range members {
+		if other.Role == internal.CompanyAdmin && other.CustomerID != member.CustomerID {
+			return false
+		}
+	}
+
+	return true
+}
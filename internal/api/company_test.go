@@ -0,0 +1,212 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanyEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	adminToken := suite.CreateCustomer(t, internal.Customer{Email: "admin@example.com", Password: "Password123"})
+	driverToken := suite.CreateCustomer(t, internal.Customer{Email: "driver@example.com", Password: "Password123"})
+	outsiderToken := suite.CreateCustomer(t, internal.Customer{Email: "outsider@example.com", Password: "Password123"})
+
+	companyJSON, err := json.Marshal(internal.CreateCompanyDTO{Name: "Taxi", TaxID: "5260250274", BillingEmail: "billing@example.com"})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPost, "/api/companies", companyJSON, adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var company internal.CompanyDTO
+	suite.ParseResponse(t, response, &company)
+	assert.Equal(t, internal.CompanyAdmin, company.Role)
+	require.Len(t, company.Members, 1)
+	adminID := company.Members[0].CustomerID
+
+	response = suite.CallAPI(http.MethodPost, "/api/companies", companyJSON, adminToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/companies", []byte{}, outsiderToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	memberJSON, err := json.Marshal(internal.CreateCompanyMemberDTO{Email: "driver@example.com", Role: internal.CompanyDispatcher})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/companies/members", memberJSON, adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var member internal.CompanyMemberDTO
+	suite.ParseResponse(t, response, &member)
+	assert.Equal(t, internal.CompanyDispatcher, member.Role)
+
+	response = suite.CallAPI(http.MethodPost, "/api/companies/members", memberJSON, adminToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	missingJSON, err := json.Marshal(internal.CreateCompanyMemberDTO{Email: "missing@example.com", Role: internal.CompanyDispatcher})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/companies/members", missingJSON, adminToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/companies/members", missingJSON, driverToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	roleJSON, err := json.Marshal(internal.UpdateCompanyMemberDTO{Role: internal.CompanyDispatcher})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/companies/members/%v", adminID), roleJSON, adminToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/companies/members/%v", adminID), []byte{}, driverToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/companies/members/%v", adminID), []byte{}, adminToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	vehicleJSON, err := json.Marshal(internal.CreateVehicleDTO{ModelID: 1, PlateNumber: "WX12345", Shared: true})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/customers/vehicles", vehicleJSON, outsiderToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/customers/vehicles", vehicleJSON, adminToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var vehicle internal.VehicleDTO
+	suite.ParseResponse(t, response, &vehicle)
+	assert.True(t, vehicle.Shared)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/vehicles", []byte{}, driverToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var vehicles []internal.VehicleDTO
+	suite.ParseResponse(t, response, &vehicles)
+	require.Len(t, vehicles, 1)
+	assert.Equal(t, vehicle.ID, vehicles[0].ID)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/vehicles/%v/history", vehicle.ID), []byte{}, driverToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/vehicles/%v/history", vehicle.ID), []byte{}, outsiderToken)
+	assert.NotEqual(t, http.StatusOK, response.StatusCode)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "owner@example.com",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	lastMonth := time.Date(time.Now().Year(), time.Now().Month(), 1, 10, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	series, err := suite.api.storage.AppointmentSeries().Insert(internal.AppointmentSeries{
+		CustomerID: member.CustomerID,
+		Frequency:  internal.WeeklyRecurrence,
+		Interval:   1,
+		Count:      2,
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	var appointment internal.Appointment
+	for _, day := range []int{1, 10} {
+		appointment, err = suite.api.storage.Appointments().Insert(
+			internal.Appointment{
+				StartTime:  lastMonth.AddDate(0, 0, day),
+				EndTime:    lastMonth.AddDate(0, 0, day).Add(time.Hour),
+				ServiceID:  service.ID,
+				EmployeeID: owner.ID,
+				CustomerID: member.CustomerID,
+				ModelID:    1,
+				VehicleID:  &vehicle.ID,
+				CompanyID:  &company.ID,
+				SeriesID:   &series.ID,
+			})
+		require.NoError(t, err)
+	}
+
+	response = suite.CallAPI(http.MethodGet, "/api/companies/appointments", []byte{}, adminToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var appointments internal.CustomerAppointmentDTOs
+	suite.ParseResponse(t, response, &appointments)
+	require.Len(t, appointments.Completed, 2)
+	assert.Equal(t, "driver@example.com", appointments.Completed[0].Customer.Email)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), []byte{}, adminToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointment-series/%v", series.ID), []byte{}, adminToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+
+	invoiceJSON, err := json.Marshal(internal.CreateCompanyInvoiceDTO{CompanyID: company.ID, Month: time.Now().Format("2006-01")})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/company-invoices", invoiceJSON, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	invoiceJSON, err = json.Marshal(internal.CreateCompanyInvoiceDTO{CompanyID: company.ID, Month: lastMonth.Format("2006-01")})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/company-invoices", invoiceJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var invoice internal.InvoiceDTO
+	suite.ParseResponse(t, response, &invoice)
+	assert.Len(t, invoice.AppointmentIDs, 2)
+	assert.Len(t, invoice.Items, 2)
+	assert.Equal(t, 24600, invoice.Total.Gross)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/company-invoices", invoiceJSON, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/companies/invoices", []byte{}, driverToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/companies/invoices", []byte{}, adminToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var invoices []internal.InvoiceDTO
+	suite.ParseResponse(t, response, &invoices)
+	require.Len(t, invoices, 1)
+	assert.Equal(t, invoice.ID, invoices[0].ID)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/companies/invoices/%v/pdf", invoice.ID), []byte{}, adminToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/companies/members/%v", member.CustomerID), []byte{}, driverToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/vehicles", []byte{}, driverToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	vehicles = nil
+	suite.ParseResponse(t, response, &vehicles)
+	assert.Empty(t, vehicles)
+}
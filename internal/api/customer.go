@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) GetCustomerProfile(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCustomerDTO(customer), 200)
+}
+
+func (a *API) UpdateCustomerProfile(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CustomerProfileDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CustomerProfileDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	customer.Name = dto.Name
+	customer.Surname = dto.Surname
+	customer.PhoneNumber = dto.PhoneNumber
+	customer.PreferredContact = dto.PreferredContact
+
+	if err = a.storage.Customers().UpdateProfile(customer); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewCustomerDTO(customer), 200)
+}
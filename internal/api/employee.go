@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -24,7 +25,18 @@ func (a *API) ListConfirmedEmployees(writer http.ResponseWriter, request *http.R
 		return
 	}
 
-	employees, err := a.storage.Employees().ListConfirmedByGarageID(garageID)
+	var employees []internal.Employee
+	serviceIDStr := request.URL.Query().Get("serviceId")
+	if serviceIDStr != "" {
+		serviceID, err := strconv.Atoi(serviceIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+		employees, err = a.storage.Employees().ListQualifiedByServiceID(garageID, serviceID)
+	} else {
+		employees, err = a.storage.Employees().ListConfirmedByGarageID(garageID)
+	}
 	if err != nil {
 		a.handleError(writer, err, 500)
 		return
@@ -40,13 +52,13 @@ func (a *API) ListEmployees(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	employee, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -102,13 +114,13 @@ func (a *API) CreateEmployee(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	manager, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, manager)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -165,13 +177,13 @@ func (a *API) ResendConfirmationEmail(writer http.ResponseWriter, request *http.
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	manager, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, manager)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -183,7 +195,7 @@ func (a *API) ResendConfirmationEmail(writer http.ResponseWriter, request *http.
 		return
 	}
 
-	if employee.GarageID != nil && *employee.GarageID != garage.ID {
+	if employee.GarageID == nil || *employee.GarageID != garage.ID {
 		a.handleError(writer, errors.New("employee not found"), 404)
 		return
 	}
@@ -232,13 +244,13 @@ func (a *API) DeleteEmployee(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	manager, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, manager)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -250,7 +262,7 @@ func (a *API) DeleteEmployee(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 
-	if employee.GarageID != nil && *employee.GarageID != garage.ID {
+	if employee.GarageID == nil || *employee.GarageID != garage.ID {
 		a.handleError(writer, errors.New("employee not found"), 404)
 		return
 	}
@@ -261,6 +273,10 @@ func (a *API) DeleteEmployee(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 
+	if err = a.storage.CalendarTokens().DeleteByEmployeeID(employee.ID); err != nil {
+		a.log.Error(err.Error())
+	}
+
 	a.sendResponse(writer, nil, 200)
 }
 
@@ -312,3 +328,121 @@ func (a *API) UpdateProfilePicture(writer http.ResponseWriter, request *http.Req
 
 	a.sendResponse(writer, nil, 200)
 }
+
+func (a *API) ListEmployeeServices(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	employee, garage, ok := a.garageEmployee(writer, request, employeeID)
+	if !ok {
+		return
+	}
+
+	serviceIDs, err := a.storage.Employees().ListServiceIDs(employee.ID, garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if serviceIDs == nil {
+		serviceIDs = []int{}
+	}
+
+	a.sendResponse(writer, internal.EmployeeServicesDTO{ServiceIDs: serviceIDs}, 200)
+}
+
+// UpdateEmployeeServices assigns the services of the current garage the
+// employee is qualified for. An empty list lets the employee perform every
+// service of the garage.
+func (a *API) UpdateEmployeeServices(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.EmployeeServicesDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.EmployeeServicesDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	employee, garage, ok := a.garageEmployee(writer, request, employeeID)
+	if !ok {
+		return
+	}
+
+	serviceIDs := make([]int, 0, len(dto.ServiceIDs))
+	for _, serviceID := range dto.ServiceIDs {
+		if slices.Contains(serviceIDs, serviceID) {
+			continue
+		}
+		service, err := a.storage.Services().GetByID(serviceID)
+		if err != nil || service.IsDeleted || service.GarageID != garage.ID {
+			a.handleError(writer, errors.New("service not found"), 404)
+			return
+		}
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+
+	if err = a.storage.Employees().UpdateServices(employee.ID, garage.ID, serviceIDs); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.EmployeeServicesDTO{ServiceIDs: serviceIDs}, 200)
+}
+
+// garageEmployee loads an employee working at the garage the requesting
+// manager is currently acting on, including employees assigned to it from
+// other locations.
+func (a *API) garageEmployee(writer http.ResponseWriter, request *http.Request, employeeID int) (internal.Employee, internal.Garage, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	manager, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	garage, err := a.employeeGarage(request, manager)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByID(employeeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	garages, err := a.storage.Garages().ListByEmployeeID(employee.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	if employee.IsDeleted || !containsGarage(garages, garage.ID) {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return internal.Employee{}, internal.Garage{}, false
+	}
+
+	return employee, garage, true
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
 
@@ -329,3 +330,111 @@ func TestUpdateProfilePictureEndpoint(t *testing.T) {
 	response := suite.CallAPI(http.MethodPost, "/api/employees/profile-picture", profilePictureJSON, &token)
 	assert.Equal(t, http.StatusOK, response.StatusCode)
 }
+
+func TestEmployeeServicesEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+
+	mechanic1, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	mechanic2, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email3",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service1, err := suite.api.storage.Services().Insert(internal.Service{Name: "oil", Time: 1, Price: 100, GarageID: garage.ID})
+	require.NoError(t, err)
+	service2, err := suite.api.storage.Services().Insert(internal.Service{Name: "bodywork", Time: 1, Price: 100, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
+	require.NoError(t, err)
+
+	servicesJSON, err := json.Marshal(internal.EmployeeServicesDTO{ServiceIDs: []int{service1.ID}})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/employees/%v/services", mechanic1.ID), servicesJSON, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/employees/%v/services", mechanic1.ID), []byte{}, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var servicesDTO internal.EmployeeServicesDTO
+	suite.ParseResponse(t, response, &servicesDTO)
+	assert.Equal(t, []int{service1.ID}, servicesDTO.ServiceIDs)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/employees?serviceId=%v", garage.ID, service1.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var employeeDTOs []internal.EmployeeDTO
+	suite.ParseResponse(t, response, &employeeDTOs)
+	assert.Len(t, employeeDTOs, 2)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/employees?serviceId=%v", garage.ID, service2.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &employeeDTOs)
+	require.Len(t, employeeDTOs, 1)
+	assert.Equal(t, mechanic2.ID, employeeDTOs[0].ID)
+
+	customerToken := suite.CreateCustomer(t, internal.Customer{Email: "john.doe@example.com", Password: "password"})
+	appointmentJSON, err := json.Marshal(internal.CreateAppointmentDTO{
+		StartTime:  time.Now().Add(48 * time.Hour),
+		EndTime:    time.Now().Add(49 * time.Hour),
+		ServiceID:  service2.ID,
+		EmployeeID: mechanic1.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, customerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	servicesJSON, err = json.Marshal(internal.EmployeeServicesDTO{ServiceIDs: []int{}})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/employees/%v/services", mechanic1.ID), servicesJSON, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/employees?serviceId=%v", garage.ID, service2.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &employeeDTOs)
+	assert.Len(t, employeeDTOs, 2)
+}
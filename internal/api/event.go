@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+)
+
+const heartbeatInterval = 30 * time.Second
+
+// StreamEvents sends the appointment events relevant to the user as
+// Server-Sent Events: customers receive events of their appointments,
+// mechanics of the appointments assigned to them and owners of all
+// appointments in their garages.
+func (a *API) StreamEvents(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		a.handleError(writer, errors.New("streaming is not supported"), 500)
+		return
+	}
+
+	relevant, err := a.eventFilter(request)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	subscription, unsubscribe := a.events.Subscribe()
+	defer unsubscribe()
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.WriteHeader(200)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case event, ok := <-subscription:
+			if !ok {
+				return
+			}
+			if !relevant(event) {
+				continue
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				a.log.Error("unable to encode event", "error", err)
+				continue
+			}
+			if _, err = fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (a *API) eventFilter(request *http.Request) (func(events.Event) bool, error) {
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		return nil, errors.New("missing role")
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		return nil, errors.New("missing email")
+	}
+
+	if role == internal.CustomerRole {
+		customer, err := a.storage.Customers().GetByEmail(email)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(event events.Event) bool {
+			return event.CustomerID == customer.ID
+		}, nil
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	garageIDs := make(map[int]bool)
+	if role == internal.OwnerRole {
+		garages, err := a.storage.Garages().ListByEmployeeID(employee.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, garage := range garages {
+			garageIDs[garage.ID] = true
+		}
+	}
+
+	return func(event events.Event) bool {
+		return event.EmployeeID == employee.ID || garageIDs[event.GarageID]
+	}, nil
+}
+
+// publishAppointmentEvent notifies the event stream subscribers and the
+// webhooks concerned by the appointment.
+func (a *API) publishAppointmentEvent(eventType events.Type, appointment internal.Appointment) {
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		a.log.Error("unable to publish event", "error", err)
+		return
+	}
+
+	event := events.Event{
+		Type:          eventType,
+		AppointmentID: appointment.ID,
+		GarageID:      service.GarageID,
+		EmployeeID:    appointment.EmployeeID,
+		CustomerID:    appointment.CustomerID,
+	}
+	a.events.Publish(event)
+	a.dispatchWebhooks(event)
+}
+
+// queryTokenMiddleware accepts the token in the token query parameter, since
+// browsers cannot set headers on EventSource requests.
+func queryTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", bearerPrefix+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(48 * time.Hour),
+			EndTime:    time.Now().Add(49 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodGet, "/api/events", []byte{}, nil)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL+"/api/events?token="+ownerToken.JWT, nil)
+	require.NoError(t, err)
+	stream, err := suite.client.Do(request)
+	require.NoError(t, err)
+	defer stream.Body.Close()
+	require.Equal(t, http.StatusOK, stream.StatusCode)
+	assert.Equal(t, "text/event-stream", stream.Header.Get("Content-Type"))
+
+	messageJSON, err := json.Marshal(internal.CreateMessageDTO{Content: "Can I drop the car off earlier?"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), messageJSON, &customerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	reader := bufio.NewReader(stream.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: "+string(events.MessageCreated), strings.TrimSpace(line))
+
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	var event events.Event
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &event))
+	assert.Equal(t, appointment.ID, event.AppointmentID)
+	assert.Equal(t, garage.ID, event.GarageID)
+}
@@ -46,13 +46,21 @@ func (a *API) CreateGarage(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	_, err = a.storage.Garages().GetByOwnerID(owner.ID)
-	if err == nil {
-		a.handleError(writer, errors.New("cannot create more than one garage"), 400)
+	garages, err := a.storage.Garages().ListByEmployeeID(owner.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
 		return
 	}
 
-	garage := internal.NewGarage(dto, owner.ID)
+	var organizationID *int
+	for _, existing := range garages {
+		if existing.OrganizationID != nil {
+			organizationID = existing.OrganizationID
+			break
+		}
+	}
+
+	garage := internal.NewGarage(dto, owner.ID, organizationID)
 	garage, err = a.storage.Garages().Insert(garage)
 	if err != nil {
 		a.handleError(writer, err, 500)
@@ -124,13 +132,13 @@ func (a *API) UpdateGarage(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	employee, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -144,6 +152,26 @@ func (a *API) UpdateGarage(writer http.ResponseWriter, request *http.Request) {
 	garage.PhoneNumber = dto.PhoneNumber
 	garage.Latitude = dto.Latitude
 	garage.Longitude = dto.Longitude
+	if dto.Currency != "" && dto.Currency != garage.Currency {
+		// Prices are stored in minor units of the garage currency and are
+		// not converted.
+		services, err := a.storage.Services().ListByGarageID(garage.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		if len(services) > 0 {
+			a.handleError(writer, errors.New("currency cannot be changed once services exist"), 400)
+			return
+		}
+		garage.Currency = dto.Currency
+	}
+	if dto.TaxRate != nil {
+		garage.TaxRate = *dto.TaxRate
+	}
+	if dto.WaitlistMode != "" {
+		garage.WaitlistMode = dto.WaitlistMode
+	}
 
 	err = a.storage.Garages().Update(garage)
 	if err != nil {
@@ -167,13 +195,7 @@ func (a *API) GetEmployeeGarage(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
-	var garage internal.Garage
-	switch employee.Role {
-	case internal.OwnerRole:
-		garage, err = a.storage.Garages().GetByOwnerID(employee.ID)
-	case internal.MechanicRole:
-		garage, err = a.storage.Garages().GetByID(*employee.GarageID)
-	}
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -264,13 +286,13 @@ func (a *API) UpdateLogo(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	employee, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
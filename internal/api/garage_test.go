@@ -39,12 +39,12 @@ func TestCreateGarageEndpoint(t *testing.T) {
 			{
 				Name:  "Oil Change",
 				Time:  30,
-				Price: 50,
+				Price: internal.MoneyDTO{Gross: 50},
 			},
 			{
 				Name:  "Tire Rotation",
 				Time:  15,
-				Price: 25,
+				Price: internal.MoneyDTO{Gross: 25},
 			},
 		},
 		EmployeeEmails: []string{
@@ -63,7 +63,7 @@ func TestCreateGarageEndpoint(t *testing.T) {
 	require.NoError(t, err)
 
 	response = suite.CallAPI(http.MethodPost, "/api/garages", garageJSON, token)
-	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
 
 	response = suite.CallAPI(http.MethodPost, "/api/garages", garageJSON, nil)
 	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
@@ -91,6 +91,13 @@ func TestCreateGarageEndpoint(t *testing.T) {
 	suite.ParseResponse(t, response, &garageDTO)
 	assert.Equal(t, "new name", garageDTO.Name)
 
+	updatedGarage.Currency = "EUR"
+	updatedGarageJSON, err = json.Marshal(updatedGarage)
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPut, "/api/garages", updatedGarageJSON, token)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
 	logo := internal.LogoDTO{Base64Logo: "logo"}
 	logoJSON, err := json.Marshal(logo)
 	require.NoError(t, err)
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/pdf"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+// CreateInvoice issues an invoice for a completed appointment. The booked
+// services and the additional work approved by the customer are always
+// invoiced, parts and labour from the request are added on top of them.
+// The invoice is emailed to the customer as a PDF.
+func (a *API) CreateInvoice(writer http.ResponseWriter, request *http.Request) {
+	appointmentIDStr := request.PathValue("id")
+	appointmentID, err := strconv.Atoi(appointmentIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CreateInvoiceDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil && !errors.Is(err, io.EOF) {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateInvoiceDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	appointment, err := a.storage.Appointments().GetByID(appointmentID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if service.GarageID != garage.ID {
+		a.handleError(writer, errors.New("appointment not found"), 404)
+		return
+	}
+
+	if time.Now().Before(appointment.EndTime) {
+		a.handleError(writer, errors.New("appointment is not completed yet"), 400)
+		return
+	}
+
+	if _, err = a.storage.Invoices().GetByAppointmentID(appointment.ID); err == nil {
+		a.handleError(writer, errors.New("invoice already exists"), 409)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByID(appointment.CustomerID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	items, err := a.appointmentInvoiceItems(appointment, garage, "")
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+	for _, item := range dto.Items {
+		items = append(items, internal.NewInvoiceItem(item, garage.Pricing()))
+	}
+
+	invoice, err := a.storage.Invoices().Insert(internal.NewInvoice(appointment, garage, customer, items))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if err = a.sendInvoice(invoice); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, internal.NewInvoiceDTO(invoice), 201)
+}
+
+func (a *API) GetInvoice(writer http.ResponseWriter, request *http.Request) {
+	invoice, ok := a.appointmentInvoice(writer, request)
+	if !ok {
+		return
+	}
+
+	a.sendResponse(writer, internal.NewInvoiceDTO(invoice), 200)
+}
+
+func (a *API) DownloadInvoice(writer http.ResponseWriter, request *http.Request) {
+	invoice, ok := a.appointmentInvoice(writer, request)
+	if !ok {
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", invoiceFileName(invoice)))
+	writer.WriteHeader(200)
+	if _, err := writer.Write(pdf.Invoice(invoice)); err != nil {
+		a.log.Error("unable to write invoice", "error", err)
+	}
+}
+
+func (a *API) SendInvoice(writer http.ResponseWriter, request *http.Request) {
+	invoice, ok := a.appointmentInvoice(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := a.sendInvoice(invoice); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// appointmentInvoice loads the invoice of the appointment from the request
+// path. Customers can access invoices of their own appointments, employees
+// those issued by the garage they are acting on.
+func (a *API) appointmentInvoice(writer http.ResponseWriter, request *http.Request) (internal.Invoice, bool) {
+	appointmentIDStr := request.PathValue("id")
+	appointmentID, err := strconv.Atoi(appointmentIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.Invoice{}, false
+	}
+
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Invoice{}, false
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Invoice{}, false
+	}
+
+	invoice, err := a.storage.Invoices().GetByAppointmentID(appointmentID)
+	if err != nil {
+		a.handleError(writer, errors.New("invoice not found"), 404)
+		return internal.Invoice{}, false
+	}
+
+	switch role {
+	case internal.CustomerRole:
+		customer, err := a.storage.Customers().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return internal.Invoice{}, false
+		}
+		if !a.canAccessInvoice(customer, invoice) {
+			a.handleError(writer, errors.New("invoice not found"), 404)
+			return internal.Invoice{}, false
+		}
+
+	default:
+		employee, err := a.storage.Employees().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return internal.Invoice{}, false
+		}
+		garage, err := a.employeeGarage(request, employee)
+		if err != nil || garage.ID != invoice.GarageID {
+			a.handleError(writer, errors.New("invoice not found"), 404)
+			return internal.Invoice{}, false
+		}
+	}
+
+	return invoice, true
+}
+
+// appointmentInvoiceItems lists the booked services and the additional work
+// approved by the customer, the names of the items starting with the prefix.
+func (a *API) appointmentInvoiceItems(appointment internal.Appointment, garage internal.Garage, prefix string) ([]internal.InvoiceItem, error) {
+	services, err := a.storage.Appointments().ListServices(appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalItems, err := a.storage.AdditionalItems().ListByAppointmentID(appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]internal.InvoiceItem, 0, len(services)+len(additionalItems))
+	for _, service := range services {
+		items = append(items, internal.InvoiceItem{
+			Kind:      internal.ServiceItem,
+			Name:      prefix + service.Name,
+			Quantity:  1,
+			UnitPrice: service.Price,
+			TaxRate:   service.TaxRate,
+		})
+	}
+	for _, additionalItem := range additionalItems {
+		if additionalItem.Status != internal.ApprovedItem {
+			continue
+		}
+		items = append(items, internal.InvoiceItem{
+			Kind:      internal.LabourItem,
+			Name:      prefix + additionalItem.Description,
+			Quantity:  1,
+			UnitPrice: additionalItem.Price,
+			TaxRate:   garage.TaxRate,
+		})
+	}
+
+	return items, nil
+}
+
+// canAccessInvoice reports whether the invoice was issued to the customer or,
+// for monthly company invoices, to the company the customer administers.
+func (a *API) canAccessInvoice(customer internal.Customer, invoice internal.Invoice) bool {
+	if invoice.CompanyID == nil {
+		return invoice.CustomerID != nil && *invoice.CustomerID == customer.ID
+	}
+
+	member, err := a.storage.Companies().GetMember(customer.ID)
+	return err == nil && member.CompanyID == *invoice.CompanyID && member.Role == internal.CompanyAdmin
+}
+
+func (a *API) sendInvoice(invoice internal.Invoice) error {
+	total := internal.NewInvoiceDTO(invoice).Total
+
+	return a.mail.Send(
+		invoice.BuyerEmail,
+		"Faktura "+invoice.DisplayNumber(),
+		mail.InvoiceTemplate,
+		mail.Invoice{
+			GarageName: invoice.SellerName,
+			Number:     invoice.DisplayNumber(),
+			Total:      internal.FormatMoney(total.Gross, total.Currency),
+		},
+		mail.Attachment{
+			Name:        invoiceFileName(invoice),
+			ContentType: "application/pdf",
+			Data:        pdf.Invoice(invoice),
+		},
+	)
+}
+
+func invoiceFileName(invoice internal.Invoice) string {
+	return fmt.Sprintf("faktura-%06d-%d.pdf", invoice.Number, invoice.IssuedAt.Year())
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	otherCustomer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "other",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	completed, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-3 * time.Hour),
+			EndTime:    time.Now().Add(-2 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	upcoming, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(2 * time.Hour),
+			EndTime:    time.Now().Add(3 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+	otherToken, err := suite.api.auth.CreateToken(otherCustomer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	invoiceJSON, err := json.Marshal(internal.CreateInvoiceDTO{Items: []internal.InvoiceItemDTO{
+		{Kind: internal.PartItem, Name: "Oil filter", Quantity: 2, UnitPrice: internal.MoneyDTO{Gross: 2460}},
+	}})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/invoice", upcoming.ID), invoiceJSON, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/invoice", completed.ID), invoiceJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var invoice internal.InvoiceDTO
+	suite.ParseResponse(t, response, &invoice)
+	assert.Equal(t, fmt.Sprintf("FV/000001/%d", time.Now().Year()), invoice.Number)
+	require.Len(t, invoice.Items, 2)
+	assert.Equal(t, internal.ServiceItem, invoice.Items[0].Kind)
+	assert.Equal(t, 4920, invoice.Items[1].Total.Gross)
+	assert.Equal(t, 17220, invoice.Total.Gross)
+	assert.Equal(t, 14000, invoice.Total.Net)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/invoice", completed.ID), invoiceJSON, &ownerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/invoice", completed.ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var customerInvoice internal.InvoiceDTO
+	suite.ParseResponse(t, response, &customerInvoice)
+	assert.Equal(t, invoice.ID, customerInvoice.ID)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/invoice", completed.ID), []byte{}, &otherToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/invoice/pdf", completed.ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "application/pdf", response.Header.Get("Content-Type"))
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF-")))
+}
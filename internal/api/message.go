@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+// ListMessages returns the message thread of the appointment and marks the
+// messages from the other side as read.
+func (a *API) ListMessages(writer http.ResponseWriter, request *http.Request) {
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	var appointment internal.Appointment
+	if role == internal.CustomerRole {
+		appointment, _, ok = a.customerAppointment(writer, request)
+	} else {
+		appointment, _, ok = a.staffAppointment(writer, request)
+	}
+	if !ok {
+		return
+	}
+
+	messages, err := a.storage.Messages().ListByAppointmentID(appointment.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if err = a.storage.Messages().MarkAsRead(appointment.ID, role != internal.CustomerRole); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewMessageDTOs(messages), 200)
+}
+
+// CreateMessage adds a message to the thread of the appointment and notifies
+// the other side by email: the assigned mechanic for customer messages, the
+// customer for messages from the garage.
+func (a *API) CreateMessage(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateMessageDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateMessageDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	var message internal.Message
+	var appointment internal.Appointment
+	var garage internal.Garage
+	var recipient string
+	if role == internal.CustomerRole {
+		appointment, garage, ok = a.customerAppointment(writer, request)
+		if !ok {
+			return
+		}
+		message = internal.NewCustomerMessage(dto, appointment.ID, appointment.CustomerID)
+
+		employee, err := a.storage.Employees().GetByID(appointment.EmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		recipient = employee.Email
+	} else {
+		appointment, garage, ok = a.staffAppointment(writer, request)
+		if !ok {
+			return
+		}
+
+		employee, err := a.storage.Employees().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return
+		}
+		message = internal.NewEmployeeMessage(dto, appointment.ID, employee)
+
+		customer, err := a.storage.Customers().GetByID(appointment.CustomerID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		recipient = customer.Email
+	}
+
+	message, err = a.storage.Messages().Insert(message)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if err = a.mail.Send(
+		recipient,
+		"Nowa wiadomość",
+		mail.NewMessageTemplate,
+		mail.NewMessage{
+			GarageName: garage.Name,
+			StartTime:  appointment.StartTime.Format("2006-01-02 15:04"),
+			Content:    message.Content,
+		},
+	); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.publishAppointmentEvent(events.MessageCreated, appointment)
+
+	a.sendResponse(writer, internal.NewMessageDTO(message), 201)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	otherCustomer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "other",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(48 * time.Hour),
+			EndTime:    time.Now().Add(49 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+	otherToken, err := suite.api.auth.CreateToken(otherCustomer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	messageJSON, err := json.Marshal(internal.CreateMessageDTO{Content: "Can I drop the car off earlier?"})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), messageJSON, &otherToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), messageJSON, &customerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var message internal.MessageDTO
+	suite.ParseResponse(t, response, &message)
+	assert.Equal(t, internal.CustomerRole, message.SenderRole)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var customerAppointments internal.CustomerAppointmentDTOs
+	suite.ParseResponse(t, response, &customerAppointments)
+	require.Len(t, customerAppointments.Upcoming, 1)
+	assert.Equal(t, 0, customerAppointments.Upcoming[0].UnreadMessages)
+
+	date := appointment.StartTime.Format("2006-01-02")
+	response = suite.CallAPI(http.MethodGet, "/api/employees/appointments?date="+date, []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var employeeAppointments []internal.AppointmentDTO
+	suite.ParseResponse(t, response, &employeeAppointments)
+	if len(employeeAppointments) == 1 {
+		assert.Equal(t, 1, employeeAppointments[0].UnreadMessages)
+	}
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var messages []internal.MessageDTO
+	suite.ParseResponse(t, response, &messages)
+	require.Len(t, messages, 1)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/messages", appointment.ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &messages)
+	require.Len(t, messages, 1)
+	assert.NotNil(t, messages[0].ReadAt)
+}
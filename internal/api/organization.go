@@ -0,0 +1,347 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) CreateOrganization(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateOrganizationDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateOrganizationDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garages, err := a.storage.Garages().ListByEmployeeID(owner.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if len(garages) == 0 {
+		a.handleError(writer, errors.New("employee is not assigned to any garage"), 404)
+		return
+	}
+
+	for _, garage := range garages {
+		if garage.OrganizationID != nil {
+			a.handleError(writer, errors.New("garage already belongs to an organization"), 400)
+			return
+		}
+	}
+
+	organization, err := a.storage.Organizations().Insert(internal.Organization{Name: dto.Name})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	for i := range garages {
+		if err = a.storage.Garages().UpdateOrganization(garages[i].ID, &organization.ID); err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		garages[i].OrganizationID = &organization.ID
+	}
+
+	a.sendResponse(writer, internal.NewOrganizationDTO(organization, garages), 201)
+}
+
+func (a *API) GetOrganization(writer http.ResponseWriter, request *http.Request) {
+	organization, ok := a.employeeOrganization(writer, request)
+	if !ok {
+		return
+	}
+
+	garages, err := a.storage.Garages().ListByOrganizationID(organization.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewOrganizationDTO(organization, garages), 200)
+}
+
+func (a *API) GetOrganizationReport(writer http.ResponseWriter, request *http.Request) {
+	queryParams := request.URL.Query()
+	layout := "2006-01-02"
+
+	from, err := time.Parse(layout, queryParams.Get("from"))
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	to, err := time.Parse(layout, queryParams.Get("to"))
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if to.Before(from) {
+		a.handleError(writer, errors.New("end date cannot be before start date"), 400)
+		return
+	}
+
+	organization, ok := a.employeeOrganization(writer, request)
+	if !ok {
+		return
+	}
+
+	reports, err := a.storage.Organizations().Report(organization.ID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewOrganizationReportDTO(from, to, reports), 200)
+}
+
+func (a *API) ListEmployeeLocations(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garages, err := a.storage.Garages().ListByEmployeeID(employee.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewGarageDTOs(garages), 200)
+}
+
+func (a *API) UpdateActiveLocation(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.LocationDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garages, err := a.storage.Garages().ListByEmployeeID(employee.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if !containsGarage(garages, dto.GarageID) {
+		a.handleError(writer, errors.New("garage not found"), 404)
+		return
+	}
+
+	if err = a.storage.Employees().UpdateActiveGarage(employee.ID, &dto.GarageID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) AddEmployeeLocation(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garageIDStr := request.PathValue("garageId")
+	garageID, err := strconv.Atoi(garageIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	employee, ok := a.organizationEmployee(writer, request, employeeID, garageID)
+	if !ok {
+		return
+	}
+
+	if *employee.GarageID == garageID {
+		a.handleError(writer, errors.New("employee already works at this garage"), 400)
+		return
+	}
+
+	if err = a.storage.Employees().AddGarage(employee.ID, garageID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 201)
+}
+
+func (a *API) RemoveEmployeeLocation(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garageIDStr := request.PathValue("garageId")
+	garageID, err := strconv.Atoi(garageIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	employee, ok := a.organizationEmployee(writer, request, employeeID, garageID)
+	if !ok {
+		return
+	}
+
+	if *employee.GarageID == garageID {
+		a.handleError(writer, errors.New("cannot remove employee from the main garage"), 400)
+		return
+	}
+
+	if err = a.storage.Employees().RemoveGarage(employee.ID, garageID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if employee.ActiveGarageID != nil && *employee.ActiveGarageID == garageID {
+		if err = a.storage.Employees().UpdateActiveGarage(employee.ID, nil); err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// employeeOrganization returns the organization of the garage the requesting
+// employee is currently acting on.
+func (a *API) employeeOrganization(writer http.ResponseWriter, request *http.Request) (internal.Organization, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Organization{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Organization{}, false
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Organization{}, false
+	}
+
+	if garage.OrganizationID == nil {
+		a.handleError(writer, errors.New("garage does not belong to any organization"), 404)
+		return internal.Organization{}, false
+	}
+
+	organization, err := a.storage.Organizations().GetByID(*garage.OrganizationID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Organization{}, false
+	}
+
+	return organization, true
+}
+
+// organizationEmployee loads an employee of the manager's current garage and
+// checks that the given location belongs to the same organization.
+func (a *API) organizationEmployee(writer http.ResponseWriter, request *http.Request, employeeID, garageID int) (internal.Employee, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Employee{}, false
+	}
+
+	manager, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Employee{}, false
+	}
+
+	garage, err := a.employeeGarage(request, manager)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Employee{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByID(employeeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Employee{}, false
+	}
+
+	if employee.IsDeleted || employee.GarageID == nil || *employee.GarageID != garage.ID {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return internal.Employee{}, false
+	}
+
+	location, err := a.storage.Garages().GetByID(garageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Employee{}, false
+	}
+
+	if garage.OrganizationID == nil || location.OrganizationID == nil || *location.OrganizationID != *garage.OrganizationID {
+		a.handleError(writer, errors.New("garage not found"), 404)
+		return internal.Employee{}, false
+	}
+
+	return employee, true
+}
+
+func containsGarage(garages []internal.Garage, garageID int) bool {
+	for _, garage := range garages {
+		if garage.ID == garageID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+
+	garage := internal.CreateGarageDTO{
+		Name:        "Garage 1",
+		City:        "city",
+		Street:      "street",
+		Number:      "1",
+		PostalCode:  "12-345",
+		PhoneNumber: "123456789",
+		Latitude:    10,
+		Longitude:   10,
+	}
+	garageJSON, err := json.Marshal(garage)
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPost, "/api/garages", garageJSON, &ownerToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/organizations", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	organizationJSON, err := json.Marshal(internal.CreateOrganizationDTO{Name: "Garage Group"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/organizations", organizationJSON, &ownerToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/organizations", organizationJSON, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	garage.Name = "Garage 2"
+	garageJSON, err = json.Marshal(garage)
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages", garageJSON, &ownerToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/organizations", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var organizationDTO internal.OrganizationDTO
+	suite.ParseResponse(t, response, &organizationDTO)
+	assert.Equal(t, "Garage Group", organizationDTO.Name)
+	require.Len(t, organizationDTO.Garages, 2)
+	garage1, garage2 := organizationDTO.Garages[0], organizationDTO.Garages[1]
+
+	response = suite.CallAPI(http.MethodGet, "/api/employees/locations", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var locationDTOs []internal.GarageDTO
+	suite.ParseResponse(t, response, &locationDTOs)
+	assert.Len(t, locationDTOs, 2)
+
+	locationJSON, err := json.Marshal(internal.LocationDTO{GarageID: garage2.ID})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, "/api/employees/locations/active", locationJSON, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/employees/garages", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var garageDTO internal.GarageDTO
+	suite.ParseResponse(t, response, &garageDTO)
+	assert.Equal(t, garage2.ID, garageDTO.ID)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage1.ID,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	path := fmt.Sprintf("/api/employees/%v/locations/%v", mechanic.ID, garage2.ID)
+	response = suite.CallAPI(http.MethodPut, path, []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("%s?garageId=%v", path, garage1.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/organizations/report?from=2024-01-01&to=2024-12-31", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var reportDTO internal.OrganizationReportDTO
+	suite.ParseResponse(t, response, &reportDTO)
+	assert.Len(t, reportDTO.Garages, 2)
+}
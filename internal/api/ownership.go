@@ -0,0 +1,374 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/KsaweryZietara/garage/internal"
+)
+
+func (a *API) ListGarageOwners(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	owners, err := a.storage.Employees().ListOwnersByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewEmployeeDTOs(owners, true), 200)
+}
+
+func (a *API) AddGarageOwner(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.EmployeeIDDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetConfirmedByID(dto.EmployeeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if employee.IsDeleted || employee.GarageID == nil || *employee.GarageID != garage.ID {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return
+	}
+
+	if employee.Role == internal.OwnerRole {
+		a.handleError(writer, errors.New("employee is already an owner"), 400)
+		return
+	}
+
+	if err = a.storage.Garages().AddOwner(garage.ID, employee.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 201)
+}
+
+func (a *API) RemoveGarageOwner(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	owners, err := a.storage.Employees().ListOwnersByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if !containsEmployee(owners, employeeID) {
+		a.handleError(writer, errors.New("owner not found"), 404)
+		return
+	}
+
+	if len(owners) == 1 {
+		a.handleError(writer, errors.New("garage must have at least one owner"), 400)
+		return
+	}
+
+	if err = a.storage.Garages().RemoveOwner(garage.ID, employeeID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) CreateOwnershipTransfer(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.EmployeeIDDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetConfirmedByID(dto.EmployeeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if employee.IsDeleted || employee.GarageID == nil || *employee.GarageID != garage.ID {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return
+	}
+
+	if employee.Role == internal.OwnerRole {
+		a.handleError(writer, errors.New("employee is already an owner"), 400)
+		return
+	}
+
+	pending, err := a.storage.OwnershipTransfers().ListPendingByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+	if len(pending) > 0 {
+		a.handleError(writer, errors.New("garage already has a pending ownership transfer"), 409)
+		return
+	}
+
+	transfer, err := a.storage.OwnershipTransfers().Insert(internal.OwnershipTransfer{
+		GarageID:       garage.ID,
+		FromEmployeeID: owner.ID,
+		ToEmployeeID:   employee.ID,
+	})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewOwnershipTransferDTO(transfer, garage, owner, employee), 201)
+}
+
+func (a *API) ListOwnershipTransfers(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	transfers, err := a.storage.OwnershipTransfers().ListPendingByEmployeeID(employee.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	transferDTOs := make([]internal.OwnershipTransferDTO, len(transfers))
+	for i, transfer := range transfers {
+		garage, err := a.storage.Garages().GetByID(transfer.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		from, err := a.storage.Employees().GetByID(transfer.FromEmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		to, err := a.storage.Employees().GetByID(transfer.ToEmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		transferDTOs[i] = internal.NewOwnershipTransferDTO(transfer, garage, from, to)
+	}
+
+	a.sendResponse(writer, transferDTOs, 200)
+}
+
+func (a *API) ConfirmOwnershipTransfer(writer http.ResponseWriter, request *http.Request) {
+	transferIDStr := request.PathValue("id")
+	transferID, err := strconv.Atoi(transferIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	transfer, err := a.storage.OwnershipTransfers().GetByID(transferID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if transfer.Completed {
+		a.handleError(writer, errors.New("transfer is already completed"), 400)
+		return
+	}
+
+	switch employee.ID {
+	case transfer.FromEmployeeID:
+		transfer.FromConfirmed = true
+	case transfer.ToEmployeeID:
+		transfer.ToConfirmed = true
+	default:
+		a.handleError(writer, errors.New("transfer not found"), 404)
+		return
+	}
+
+	if !transfer.FromConfirmed || !transfer.ToConfirmed {
+		if err = a.storage.OwnershipTransfers().Update(transfer); err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		a.sendResponse(writer, nil, 200)
+		return
+	}
+
+	owners, err := a.storage.Employees().ListOwnersByGarageID(transfer.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if !containsEmployee(owners, transfer.FromEmployeeID) {
+		a.handleError(writer, errors.New("transferring employee is no longer an owner"), 400)
+		return
+	}
+
+	recipient, err := a.storage.Employees().GetConfirmedByID(transfer.ToEmployeeID)
+	if err != nil || recipient.IsDeleted || recipient.Role == internal.OwnerRole ||
+		recipient.GarageID == nil || *recipient.GarageID != transfer.GarageID {
+		a.handleError(writer, errors.New("receiving employee no longer works at the garage"), 400)
+		return
+	}
+
+	if err = a.storage.OwnershipTransfers().Complete(transfer); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) DeleteOwnershipTransfer(writer http.ResponseWriter, request *http.Request) {
+	transferIDStr := request.PathValue("id")
+	transferID, err := strconv.Atoi(transferIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	transfer, err := a.storage.OwnershipTransfers().GetByID(transferID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if employee.ID != transfer.FromEmployeeID && employee.ID != transfer.ToEmployeeID {
+		a.handleError(writer, errors.New("transfer not found"), 404)
+		return
+	}
+
+	if transfer.Completed {
+		a.handleError(writer, errors.New("transfer is already completed"), 400)
+		return
+	}
+
+	if err = a.storage.OwnershipTransfers().Delete(transfer.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func containsEmployee(employees []internal.Employee, employeeID int) bool {
+	for _, employee := range employees {
+		if employee.ID == employeeID {
+			return true
+		}
+	}
+	return false
+}
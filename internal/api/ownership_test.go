@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGarageOwnersEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	assert.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	mechanicToken, err := suite.api.auth.CreateToken(mechanic.Email, internal.MechanicRole)
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/garages/owners/%v", owner.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	employeeJSON, err := json.Marshal(internal.EmployeeIDDTO{EmployeeID: mechanic.ID})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/owners", employeeJSON, &ownerToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/garages/owners", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var ownerDTOs []internal.EmployeeDTO
+	suite.ParseResponse(t, response, &ownerDTOs)
+	assert.Len(t, ownerDTOs, 2)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/garages/owners/%v", owner.ID), []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/garages/owners", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestOwnershipTransferEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	assert.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	mechanicToken, err := suite.api.auth.CreateToken(mechanic.Email, internal.MechanicRole)
+	require.NoError(t, err)
+
+	employeeJSON, err := json.Marshal(internal.EmployeeIDDTO{EmployeeID: mechanic.ID})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPost, "/api/garages/transfers", employeeJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var transfer internal.OwnershipTransferDTO
+	suite.ParseResponse(t, response, &transfer)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/transfers", employeeJSON, &ownerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/garages/transfers", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var transferDTOs []internal.OwnershipTransferDTO
+	suite.ParseResponse(t, response, &transferDTOs)
+	assert.Len(t, transferDTOs, 1)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/garages/transfers/%v/confirm", transfer.ID), []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/employees", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/garages/transfers/%v/confirm", transfer.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/employees", []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/employees", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) ListParts(writer http.ResponseWriter, request *http.Request) {
+	garage, ok := a.partsGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	parts, err := a.storage.Parts().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewPartDTOs(parts, garage.Pricing()), 200)
+}
+
+func (a *API) CreatePart(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.PartDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.PartDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garage, ok := a.partsGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	parts, err := a.storage.Parts().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	for _, part := range parts {
+		if part.SKU == dto.SKU {
+			a.handleError(writer, errors.New("part with this sku already exists"), 409)
+			return
+		}
+	}
+
+	part, err := a.storage.Parts().Insert(internal.NewPart(dto, garage.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewPartDTO(part, garage.Pricing()), 201)
+}
+
+func (a *API) UpdatePart(writer http.ResponseWriter, request *http.Request) {
+	partIDStr := request.PathValue("id")
+	partID, err := strconv.Atoi(partIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.PartDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.PartDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garage, part, ok := a.garagePart(writer, request, partID)
+	if !ok {
+		return
+	}
+
+	if dto.SKU != part.SKU {
+		parts, err := a.storage.Parts().ListByGarageID(garage.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+
+		for _, other := range parts {
+			if other.SKU == dto.SKU {
+				a.handleError(writer, errors.New("part with this sku already exists"), 409)
+				return
+			}
+		}
+	}
+
+	updated := internal.NewPart(dto, garage.ID)
+	updated.ID = part.ID
+	if err = a.storage.Parts().Update(updated); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewPartDTO(updated, garage.Pricing()), 200)
+}
+
+func (a *API) DeletePart(writer http.ResponseWriter, request *http.Request) {
+	partIDStr := request.PathValue("id")
+	partID, err := strconv.Atoi(partIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	_, part, ok := a.garagePart(writer, request, partID)
+	if !ok {
+		return
+	}
+
+	if err = a.storage.Parts().Delete(part.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// GetInventoryReport returns the current stock of the garage together with
+// the parts used by its appointments between the given dates.
+func (a *API) GetInventoryReport(writer http.ResponseWriter, request *http.Request) {
+	queryParams := request.URL.Query()
+	layout := "2006-01-02"
+
+	from, err := time.Parse(layout, queryParams.Get("from"))
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	to, err := time.Parse(layout, queryParams.Get("to"))
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if to.Before(from) {
+		a.handleError(writer, errors.New("end date cannot be before start date"), 400)
+		return
+	}
+
+	garage, ok := a.partsGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	parts, err := a.storage.Parts().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	usages, err := a.storage.Parts().Usage(garage.ID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewInventoryReportDTO(from, to, parts, usages, garage.Pricing()), 200)
+}
+
+func (a *API) ListAppointmentParts(writer http.ResponseWriter, request *http.Request) {
+	appointment, garage, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	appointmentParts, err := a.storage.Parts().ListByAppointmentID(appointment.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	dtos := make([]internal.AppointmentPartDTO, len(appointmentParts))
+	for i, appointmentPart := range appointmentParts {
+		part, err := a.storage.Parts().GetByID(appointmentPart.PartID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		dtos[i] = internal.NewAppointmentPartDTO(appointmentPart, part, garage.Pricing())
+	}
+
+	a.sendResponse(writer, dtos, 200)
+}
+
+// CreateAppointmentPart takes parts from the garage stock and records them
+// on the appointment. The owner is notified when the part runs low.
+func (a *API) CreateAppointmentPart(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateAppointmentPartDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateAppointmentPartDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, garage, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	part, err := a.storage.Parts().GetByID(dto.PartID)
+	if err != nil || part.IsDeleted || part.GarageID != garage.ID {
+		a.handleError(writer, errors.New("part not found"), 404)
+		return
+	}
+
+	appointmentPart, part, err := a.storage.Parts().Use(internal.AppointmentPart{
+		AppointmentID: appointment.ID,
+		PartID:        part.ID,
+		Quantity:      dto.Quantity,
+	})
+	if errors.Is(err, internal.ErrInsufficientStock) {
+		a.handleError(writer, err, 409)
+		return
+	}
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if part.IsLowStock() && part.Quantity+dto.Quantity > part.ReorderThreshold {
+		if err = a.sendLowStockAlert(garage, part); err != nil {
+			a.log.Error(err.Error())
+		}
+	}
+
+	a.sendResponse(writer, internal.NewAppointmentPartDTO(appointmentPart, part, garage.Pricing()), 201)
+}
+
+func (a *API) DeleteAppointmentPart(writer http.ResponseWriter, request *http.Request) {
+	appointmentPartIDStr := request.PathValue("appointmentPartId")
+	appointmentPartID, err := strconv.Atoi(appointmentPartIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, _, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	appointmentPart, err := a.storage.Parts().GetAppointmentPartByID(appointmentPartID)
+	if err != nil || appointmentPart.AppointmentID != appointment.ID {
+		a.handleError(writer, errors.New("part not found"), 404)
+		return
+	}
+
+	err = a.storage.Parts().Return(appointmentPart)
+	if errors.Is(err, internal.ErrNotFound) {
+		a.handleError(writer, errors.New("part not found"), 404)
+		return
+	}
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// partsGarage resolves the garage whose inventory the employee is acting on.
+func (a *API) partsGarage(writer http.ResponseWriter, request *http.Request) (internal.Garage, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Garage{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Garage{}, false
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Garage{}, false
+	}
+
+	return garage, true
+}
+
+func (a *API) garagePart(writer http.ResponseWriter, request *http.Request, partID int) (internal.Garage, internal.Part, bool) {
+	garage, ok := a.partsGarage(writer, request)
+	if !ok {
+		return internal.Garage{}, internal.Part{}, false
+	}
+
+	part, err := a.storage.Parts().GetByID(partID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Garage{}, internal.Part{}, false
+	}
+
+	if part.IsDeleted || part.GarageID != garage.ID {
+		a.handleError(writer, errors.New("part not found"), 404)
+		return internal.Garage{}, internal.Part{}, false
+	}
+
+	return garage, part, true
+}
+
+// staffAppointment loads the appointment from the request path together with
+// its garage, provided the employee may handle it.
+func (a *API) staffAppointment(writer http.ResponseWriter, request *http.Request) (internal.Appointment, internal.Garage, bool) {
+	appointmentIDStr := request.PathValue("id")
+	appointmentID, err := strconv.Atoi(appointmentIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	appointment, err := a.storage.Appointments().GetByID(appointmentID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	canHandle, err := a.canHandleAppointment(request, employee, appointment)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+	if !canHandle {
+		a.handleError(writer, errors.New("appointment not found for this employee"), 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Appointment{}, internal.Garage{}, false
+	}
+
+	return appointment, garage, true
+}
+
+// sendLowStockAlert emails every current owner of the garage that the part
+// is running out.
+func (a *API) sendLowStockAlert(garage internal.Garage, part internal.Part) error {
+	owners, err := a.storage.Employees().ListOwnersByGarageID(garage.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, owner := range owners {
+		err = a.mail.Send(
+			owner.Email,
+			"Niski stan magazynowy",
+			mail.LowStockTemplate,
+			mail.LowStock{
+				GarageName: garage.Name,
+				PartName:   part.Name,
+				SKU:        part.SKU,
+				Quantity:   part.Quantity,
+			},
+		)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
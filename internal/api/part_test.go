@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-2 * time.Hour),
+			EndTime:    time.Now().Add(-time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+
+	partJSON, err := json.Marshal(internal.PartDTO{
+		SKU:              "OF-100",
+		Name:             "Oil filter",
+		Quantity:         5,
+		Cost:             internal.MoneyDTO{Gross: 2460},
+		ReorderThreshold: 2,
+	})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/parts", partJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var part internal.PartDTO
+	suite.ParseResponse(t, response, &part)
+	assert.Equal(t, 2000, part.Cost.Net)
+	assert.False(t, part.LowStock)
+
+	response = suite.CallAPI(http.MethodPost, "/api/parts", partJSON, &ownerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	usageJSON, err := json.Marshal(internal.CreateAppointmentPartDTO{PartID: part.ID, Quantity: 3})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/parts", appointment.ID), usageJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var appointmentPart internal.AppointmentPartDTO
+	suite.ParseResponse(t, response, &appointmentPart)
+	assert.Equal(t, 7380, appointmentPart.Cost.Gross)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/parts", appointment.ID), usageJSON, &ownerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/parts", []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var parts []internal.PartDTO
+	suite.ParseResponse(t, response, &parts)
+	require.Len(t, parts, 1)
+	assert.Equal(t, 2, parts[0].Quantity)
+	assert.True(t, parts[0].LowStock)
+
+	today := time.Now().Format("2006-01-02")
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/parts/report?from=%s&to=%s", today, today), []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var report internal.InventoryReportDTO
+	suite.ParseResponse(t, response, &report)
+	require.Len(t, report.Parts, 1)
+	assert.Equal(t, 3, report.Parts[0].Used)
+	assert.Equal(t, 7380, report.UsedCost.Gross)
+	assert.Equal(t, 4920, report.StockCost.Gross)
+	assert.Equal(t, 1, report.LowStock)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v/parts/%v", appointment.ID, appointmentPart.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	upcoming, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(48 * time.Hour),
+			EndTime:    time.Now().Add(49 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/parts", upcoming.ID), usageJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v", upcoming.ID), []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/parts", []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &parts)
+	require.Len(t, parts, 1)
+	assert.Equal(t, 5, parts[0].Quantity)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/parts/%v", part.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/parts", []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &parts)
+	assert.Len(t, parts, 0)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
 	"github.com/KsaweryZietara/garage/internal/validate"
 )
 
@@ -69,6 +70,8 @@ func (a *API) CreateReview(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	a.publishAppointmentEvent(events.ReviewCreated, appointment)
+
 	a.sendResponse(writer, nil, 201)
 }
 
@@ -146,7 +149,12 @@ func (a *API) ListReviews(writer http.ResponseWriter, request *http.Request) {
 			a.handleError(writer, err, 404)
 			return
 		}
-		reviewDTOs[i] = internal.NewReviewDTO(appointment, service, employee)
+		services, err := a.storage.Appointments().ListServices(appointment.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		reviewDTOs[i] = internal.NewReviewDTO(appointment, service, services, employee)
 	}
 
 	a.sendResponse(writer, reviewDTOs, 200)
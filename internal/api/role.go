@@ -0,0 +1,235 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) ListPermissions(writer http.ResponseWriter, _ *http.Request) {
+	a.sendResponse(writer, internal.Permissions, 200)
+}
+
+func (a *API) ListGarageRoles(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	roles, err := a.storage.GarageRoles().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewGarageRoleDTOs(roles), 200)
+}
+
+func (a *API) CreateGarageRole(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.GarageRoleDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.GarageRoleDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	role, err := a.storage.GarageRoles().Insert(internal.NewGarageRole(dto, garage.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewGarageRoleDTO(role), 201)
+}
+
+func (a *API) UpdateGarageRole(writer http.ResponseWriter, request *http.Request) {
+	roleIDStr := request.PathValue("id")
+	roleID, err := strconv.Atoi(roleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.GarageRoleDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.GarageRoleDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	role, ok := a.ownedGarageRole(writer, request, roleID)
+	if !ok {
+		return
+	}
+
+	role.Name = dto.Name
+	role.Permissions = dto.Permissions
+
+	if err = a.storage.GarageRoles().Update(role); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewGarageRoleDTO(role), 200)
+}
+
+func (a *API) DeleteGarageRole(writer http.ResponseWriter, request *http.Request) {
+	roleIDStr := request.PathValue("id")
+	roleID, err := strconv.Atoi(roleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	role, ok := a.ownedGarageRole(writer, request, roleID)
+	if !ok {
+		return
+	}
+
+	if err = a.storage.GarageRoles().Delete(role.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) UpdateEmployeeRole(writer http.ResponseWriter, request *http.Request) {
+	employeeIDStr := request.PathValue("id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.EmployeeRoleDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByID(employeeID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if employee.GarageID == nil || *employee.GarageID != garage.ID {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return
+	}
+
+	if dto.RoleID != nil {
+		role, err := a.storage.GarageRoles().GetByID(*dto.RoleID)
+		if err != nil || role.GarageID != garage.ID {
+			a.handleError(writer, errors.New("role not found"), 404)
+			return
+		}
+	}
+
+	if err = a.storage.Employees().UpdateGarageRole(employee.ID, dto.RoleID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) ownedGarageRole(writer http.ResponseWriter, request *http.Request, roleID int) (internal.GarageRole, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.GarageRole{}, false
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.GarageRole{}, false
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.GarageRole{}, false
+	}
+
+	role, err := a.storage.GarageRoles().GetByID(roleID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.GarageRole{}, false
+	}
+
+	if role.GarageID != garage.ID {
+		a.handleError(writer, errors.New("role not found"), 404)
+		return internal.GarageRole{}, false
+	}
+
+	return role, true
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGarageRolesEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	assert.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	mechanicToken, err := suite.api.auth.CreateToken(mechanic.Email, internal.MechanicRole)
+	require.NoError(t, err)
+
+	service := internal.ServiceDTO{
+		Name:  "name",
+		Time:  1,
+		Price: internal.MoneyDTO{Gross: 100},
+	}
+	serviceJSON, err := json.Marshal(service)
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/services", serviceJSON, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	role := internal.GarageRoleDTO{
+		Name:        "manager",
+		Permissions: []internal.Permission{internal.ServicesWritePermission},
+	}
+	roleJSON, err := json.Marshal(role)
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/roles", roleJSON, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/roles", roleJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var createdRole internal.GarageRoleDTO
+	suite.ParseResponse(t, response, &createdRole)
+
+	employeeRoleJSON, err := json.Marshal(internal.EmployeeRoleDTO{RoleID: &createdRole.ID})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/employees/%v/role", mechanic.ID), employeeRoleJSON, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/services", serviceJSON, &mechanicToken)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	otherGarage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber2",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+	require.NoError(t, suite.api.storage.Employees().AddGarage(mechanic.ID, otherGarage.ID))
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/services?garageId=%v", otherGarage.ID), serviceJSON, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	logoJSON, err := json.Marshal(internal.LogoDTO{Base64Logo: "bG9nbw=="})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/logo", logoJSON, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/garages/roles", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var roleDTOs []internal.GarageRoleDTO
+	suite.ParseResponse(t, response, &roleDTOs)
+	assert.Len(t, roleDTOs, 1)
+	assert.Equal(t, "manager", roleDTOs[0].Name)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/garages/roles/%v", createdRole.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/services", serviceJSON, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
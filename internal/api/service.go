@@ -2,8 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
 	"github.com/KsaweryZietara/garage/internal/validate"
@@ -17,13 +19,106 @@ func (a *API) ListServices(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	services, err := a.storage.Services().ListByGarageID(garageID)
+	garage, err := a.storage.Garages().GetByID(garageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	services, err := a.storage.Services().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	categoryIDStr := request.URL.Query().Get("categoryId")
+	if categoryIDStr != "" {
+		categoryID, err := strconv.Atoi(categoryIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+
+		var filtered []internal.Service
+		for _, service := range services {
+			if service.CategoryID != nil && *service.CategoryID == categoryID {
+				filtered = append(filtered, service)
+			}
+		}
+		services = filtered
+	}
+
+	modelIDStr := request.URL.Query().Get("modelId")
+	if modelIDStr != "" {
+		modelID, err := strconv.Atoi(modelIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+		model, err := a.storage.Cars().GetModelByID(modelID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+
+		var available []internal.Service
+		for _, service := range services {
+			service, ok, err := a.serviceForModel(service, model)
+			if err != nil {
+				a.handleError(writer, err, 500)
+				return
+			}
+			if ok {
+				available = append(available, service)
+			}
+		}
+		services = available
+	}
+
+	a.sendResponse(writer, internal.NewServiceDTOs(services, garage.Pricing()), 200)
+}
+
+// SearchServices looks up services across all garages by name or
+// description, optionally narrowed down to a single category.
+func (a *API) SearchServices(writer http.ResponseWriter, request *http.Request) {
+	queryParams := request.URL.Query()
+
+	pageStr := queryParams.Get("page")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	categoryID := 0
+	categoryIDStr := queryParams.Get("categoryId")
+	if categoryIDStr != "" {
+		categoryID, err = strconv.Atoi(categoryIDStr)
+		if err != nil {
+			a.handleError(writer, err, 400)
+			return
+		}
+	}
+
+	services, err := a.storage.Services().Search(page, queryParams.Get("query"), categoryID)
 	if err != nil {
 		a.handleError(writer, err, 500)
 		return
 	}
 
-	a.sendResponse(writer, internal.NewServiceDTOs(services), 200)
+	results := make([]internal.ServiceSearchResultDTO, len(services))
+	for i, service := range services {
+		garage, err := a.storage.Garages().GetByID(service.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		results[i] = internal.ServiceSearchResultDTO{
+			Service: internal.NewServiceDTO(service, garage.Pricing()),
+			Garage:  internal.NewGarageDTO(garage),
+		}
+	}
+
+	a.sendResponse(writer, results, 200)
 }
 
 func (a *API) GetService(writer http.ResponseWriter, request *http.Request) {
@@ -40,7 +135,13 @@ func (a *API) GetService(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	a.sendResponse(writer, internal.NewServiceDTO(service), 200)
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewServiceDTO(service, garage.Pricing()), 200)
 }
 
 func (a *API) CreateService(writer http.ResponseWriter, request *http.Request) {
@@ -63,18 +164,23 @@ func (a *API) CreateService(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	employee, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
 	}
 
+	if dto.CategoryID != nil && !a.categoryAvailable(*dto.CategoryID, garage.ID) {
+		a.handleError(writer, errors.New("category not found"), 404)
+		return
+	}
+
 	service := internal.NewService(dto, garage.ID)
 	_, err = a.storage.Services().Insert(service)
 	if err != nil {
@@ -85,6 +191,103 @@ func (a *API) CreateService(writer http.ResponseWriter, request *http.Request) {
 	a.sendResponse(writer, nil, 201)
 }
 
+// UpdateService edits a service in place so that existing appointments keep
+// pointing at it. Price and time changes are stored as a new version, which
+// can be scheduled ahead with an effective date.
+func (a *API) UpdateService(writer http.ResponseWriter, request *http.Request) {
+	serviceIDStr := request.PathValue("id")
+	serviceID, err := strconv.Atoi(serviceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.UpdateServiceDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.UpdateServiceDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	service, ok := a.garageService(writer, request, serviceID)
+	if !ok {
+		return
+	}
+
+	if dto.CategoryID != nil && !a.categoryAvailable(*dto.CategoryID, service.GarageID) {
+		a.handleError(writer, errors.New("category not found"), 404)
+		return
+	}
+
+	service.Name = dto.Name
+	service.Description = dto.Description
+	service.CategoryID = dto.CategoryID
+	if err = a.storage.Services().Update(service); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if dto.EffectiveFrom != nil || dto.Price.Gross != service.Price || dto.Time != service.Time || !equalPrice(dto.MaxPrice.GrossPtr(), service.MaxPrice) {
+		effectiveFrom := time.Now()
+		if dto.EffectiveFrom != nil && dto.EffectiveFrom.After(effectiveFrom) {
+			effectiveFrom = *dto.EffectiveFrom
+		}
+		_, err = a.storage.Services().InsertVersion(internal.NewServiceVersion(dto, service.ID, effectiveFrom))
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+	}
+
+	service, err = a.storage.Services().GetByID(service.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewServiceDTO(service, garage.Pricing()), 200)
+}
+
+func (a *API) ListServiceVersions(writer http.ResponseWriter, request *http.Request) {
+	serviceIDStr := request.PathValue("id")
+	serviceID, err := strconv.Atoi(serviceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	service, ok := a.garageService(writer, request, serviceID)
+	if !ok {
+		return
+	}
+
+	versions, err := a.storage.Services().ListVersions(service.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewServiceVersionDTOs(versions, garage.Pricing()), 200)
+}
+
 func (a *API) DeleteService(writer http.ResponseWriter, request *http.Request) {
 	serviceIDStr := request.PathValue("id")
 	serviceID, err := strconv.Atoi(serviceIDStr)
@@ -99,13 +302,13 @@ func (a *API) DeleteService(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	owner, err := a.storage.Employees().GetByEmail(email)
+	employee, err := a.storage.Employees().GetByEmail(email)
 	if err != nil {
 		a.handleError(writer, err, 401)
 		return
 	}
 
-	garage, err := a.storage.Garages().GetByOwnerID(owner.ID)
+	garage, err := a.employeeGarage(request, employee)
 	if err != nil {
 		a.handleError(writer, err, 404)
 		return
@@ -130,3 +333,161 @@ func (a *API) DeleteService(writer http.ResponseWriter, request *http.Request) {
 
 	a.sendResponse(writer, nil, 200)
 }
+
+func (a *API) ListServiceRules(writer http.ResponseWriter, request *http.Request) {
+	serviceIDStr := request.PathValue("id")
+	serviceID, err := strconv.Atoi(serviceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	rules, err := a.storage.ServiceRules().ListByServiceID(serviceID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewServiceRuleDTOs(rules), 200)
+}
+
+func (a *API) CreateServiceRule(writer http.ResponseWriter, request *http.Request) {
+	serviceIDStr := request.PathValue("id")
+	serviceID, err := strconv.Atoi(serviceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.ServiceRuleDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.ServiceRuleDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	service, ok := a.garageService(writer, request, serviceID)
+	if !ok {
+		return
+	}
+
+	if _, err = a.storage.Cars().GetMakeByID(dto.MakeID); err != nil {
+		a.handleError(writer, errors.New("make not found"), 404)
+		return
+	}
+
+	if dto.ModelID != nil {
+		model, err := a.storage.Cars().GetModelByID(*dto.ModelID)
+		if err != nil || model.MakeID != dto.MakeID {
+			a.handleError(writer, errors.New("model not found"), 404)
+			return
+		}
+	}
+
+	rule, err := a.storage.ServiceRules().Insert(internal.NewServiceRule(dto, service.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewServiceRuleDTO(rule), 201)
+}
+
+func (a *API) DeleteServiceRule(writer http.ResponseWriter, request *http.Request) {
+	serviceIDStr := request.PathValue("id")
+	serviceID, err := strconv.Atoi(serviceIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	ruleIDStr := request.PathValue("ruleId")
+	ruleID, err := strconv.Atoi(ruleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	service, ok := a.garageService(writer, request, serviceID)
+	if !ok {
+		return
+	}
+
+	rule, err := a.storage.ServiceRules().GetByID(ruleID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	if rule.ServiceID != service.ID {
+		a.handleError(writer, errors.New("rule not found"), 404)
+		return
+	}
+
+	if err = a.storage.ServiceRules().Delete(rule.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// garageService loads a service of the garage the requesting employee is
+// currently acting on.
+func (a *API) garageService(writer http.ResponseWriter, request *http.Request, serviceID int) (internal.Service, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Service{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Service{}, false
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Service{}, false
+	}
+
+	service, err := a.storage.Services().GetByID(serviceID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Service{}, false
+	}
+
+	if service.IsDeleted || service.GarageID != garage.ID {
+		a.handleError(writer, errors.New("service not found"), 404)
+		return internal.Service{}, false
+	}
+
+	return service, true
+}
+
+func equalPrice(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// serviceForModel returns the service with the price and time that apply to
+// the given model, or false when the service does not cover the model.
+func (a *API) serviceForModel(service internal.Service, model internal.Model) (internal.Service, bool, error) {
+	rules, err := a.storage.ServiceRules().ListByServiceID(service.ID)
+	if err != nil {
+		return internal.Service{}, false, err
+	}
+
+	service, ok := service.ForModel(rules, model)
+	return service, ok, nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+// ListServiceCategories returns the categories shared by every garage, and the
+// ones of the garage given in the garageId query parameter.
+func (a *API) ListServiceCategories(writer http.ResponseWriter, request *http.Request) {
+	var categories []internal.ServiceCategory
+	var err error
+
+	garageIDStr := request.URL.Query().Get("garageId")
+	if garageIDStr == "" {
+		categories, err = a.storage.ServiceCategories().List()
+	} else {
+		garageID, convErr := strconv.Atoi(garageIDStr)
+		if convErr != nil {
+			a.handleError(writer, convErr, 400)
+			return
+		}
+		categories, err = a.storage.ServiceCategories().ListByGarageID(garageID)
+	}
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if categories == nil {
+		categories = []internal.ServiceCategory{}
+	}
+
+	a.sendResponse(writer, categories, 200)
+}
+
+// CreateServiceCategory lets garage owners add categories for their
+// services. A shared category or one of the garage with the same name is
+// returned instead of creating a duplicate.
+func (a *API) CreateServiceCategory(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.ServiceCategoryDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.ServiceCategoryDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garage, ok := a.categoriesGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	category, err := a.storage.ServiceCategories().Upsert(garage.ID, dto.Name)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, category, 201)
+}
+
+func (a *API) UpdateServiceCategory(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.ServiceCategoryDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.ServiceCategoryDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	category, ok := a.garageCategory(writer, request)
+	if !ok {
+		return
+	}
+
+	category.Name = dto.Name
+	if err = a.storage.ServiceCategories().Update(category); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, category, 200)
+}
+
+func (a *API) DeleteServiceCategory(writer http.ResponseWriter, request *http.Request) {
+	category, ok := a.garageCategory(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := a.storage.ServiceCategories().Delete(category.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// categoriesGarage resolves the garage whose categories the employee is
+// acting on.
+func (a *API) categoriesGarage(writer http.ResponseWriter, request *http.Request) (internal.Garage, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Garage{}, false
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Garage{}, false
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Garage{}, false
+	}
+
+	return garage, true
+}
+
+// garageCategory loads the category from the request path, provided it
+// belongs to the garage of the employee. Shared categories cannot be changed
+// by garages.
+func (a *API) garageCategory(writer http.ResponseWriter, request *http.Request) (internal.ServiceCategory, bool) {
+	categoryIDStr := request.PathValue("id")
+	categoryID, err := strconv.Atoi(categoryIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return internal.ServiceCategory{}, false
+	}
+
+	garage, ok := a.categoriesGarage(writer, request)
+	if !ok {
+		return internal.ServiceCategory{}, false
+	}
+
+	category, err := a.storage.ServiceCategories().GetByID(categoryID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.ServiceCategory{}, false
+	}
+
+	if category.GarageID == nil || *category.GarageID != garage.ID {
+		a.handleError(writer, errors.New("category not found"), 404)
+		return internal.ServiceCategory{}, false
+	}
+
+	return category, true
+}
+
+// categoryAvailable reports whether services of the garage can be put in the
+// category.
+func (a *API) categoryAvailable(categoryID, garageID int) bool {
+	category, err := a.storage.ServiceCategories().GetByID(categoryID)
+	if err != nil {
+		return false
+	}
+
+	return category.GarageID == nil || *category.GarageID == garageID
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
 
@@ -59,7 +60,7 @@ func TestGetServicesEndpoint(t *testing.T) {
 	assert.Equal(t, 1, len(serviceDTOs))
 	assert.Equal(t, "name", serviceDTOs[0].Name)
 	assert.Equal(t, 30, serviceDTOs[0].Time)
-	assert.Equal(t, 100, serviceDTOs[0].Price)
+	assert.Equal(t, 100, serviceDTOs[0].Price.Gross)
 
 	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
 	require.NoError(t, err)
@@ -120,7 +121,7 @@ func TestGetServiceEndpoint(t *testing.T) {
 	assert.Equal(t, service.ID, serviceDTO.ID)
 	assert.Equal(t, service.Name, serviceDTO.Name)
 	assert.Equal(t, service.Time, serviceDTO.Time)
-	assert.Equal(t, service.Price, serviceDTO.Price)
+	assert.Equal(t, service.Price, serviceDTO.Price.Gross)
 
 	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
 	require.NoError(t, err)
@@ -134,7 +135,7 @@ func TestGetServiceEndpoint(t *testing.T) {
 	assert.Equal(t, service.ID, serviceDTO.ID)
 	assert.Equal(t, service.Name, serviceDTO.Name)
 	assert.Equal(t, service.Time, serviceDTO.Time)
-	assert.Equal(t, service.Price, serviceDTO.Price)
+	assert.Equal(t, service.Price, serviceDTO.Price.Gross)
 }
 
 func TestCreateServiceEndpoint(t *testing.T) {
@@ -172,7 +173,7 @@ func TestCreateServiceEndpoint(t *testing.T) {
 	service := internal.ServiceDTO{
 		Name:  "name",
 		Time:  30,
-		Price: 100,
+		Price: internal.MoneyDTO{Gross: 100},
 	}
 	serviceJSON, err := json.Marshal(service)
 	require.NoError(t, err)
@@ -188,5 +189,288 @@ func TestCreateServiceEndpoint(t *testing.T) {
 	assert.Equal(t, 1, len(serviceDTOs))
 	assert.Equal(t, "name", serviceDTOs[0].Name)
 	assert.Equal(t, 30, serviceDTOs[0].Time)
-	assert.Equal(t, 100, serviceDTOs[0].Price)
+	assert.Equal(t, 100, serviceDTOs[0].Price.Gross)
+}
+
+func TestServiceRulesEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(internal.Service{
+		Name:     "name",
+		Time:     2,
+		Price:    100,
+		GarageID: garage.ID,
+	})
+	require.NoError(t, err)
+
+	makes, err := suite.api.storage.Cars().ListMakes()
+	require.NoError(t, err)
+	require.True(t, len(makes) > 1)
+	models1, err := suite.api.storage.Cars().ListModels(makes[0].ID)
+	require.NoError(t, err)
+	models2, err := suite.api.storage.Cars().ListModels(makes[1].ID)
+	require.NoError(t, err)
+	model1, model2 := models1[0], models2[0]
+
+	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
+	require.NoError(t, err)
+
+	price := 150
+	ruleJSON, err := json.Marshal(internal.ServiceRuleDTO{Type: internal.OverrideRule, MakeID: makes[0].ID, Price: &price})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/services/%v/rules", service.ID), ruleJSON, &token)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	ruleJSON, err = json.Marshal(internal.ServiceRuleDTO{Type: internal.ExcludeRule, MakeID: makes[0].ID, ModelID: &model2.ID})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/services/%v/rules", service.ID), ruleJSON, &token)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	ruleJSON, err = json.Marshal(internal.ServiceRuleDTO{Type: internal.ExcludeRule, MakeID: makes[1].ID, ModelID: &model2.ID})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/services/%v/rules", service.ID), ruleJSON, &token)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var excludeRule internal.ServiceRuleDTO
+	suite.ParseResponse(t, response, &excludeRule)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/services/%v/rules", service.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var rules []internal.ServiceRuleDTO
+	suite.ParseResponse(t, response, &rules)
+	assert.Len(t, rules, 2)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/services?modelId=%v", garage.ID, model1.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var serviceDTOs []internal.ServiceDTO
+	suite.ParseResponse(t, response, &serviceDTOs)
+	require.Len(t, serviceDTOs, 1)
+	assert.Equal(t, price, serviceDTOs[0].Price.Gross)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/services?modelId=%v", garage.ID, model2.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &serviceDTOs)
+	assert.Len(t, serviceDTOs, 0)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/availableSlots?serviceId=%v&employeeId=%v&date=2024-10-14&modelId=%v", service.ID, owner.ID, model2.ID), []byte{}, nil)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/services/%v/rules/%v", service.ID, excludeRule.ID), []byte{}, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/services?modelId=%v", garage.ID, model2.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &serviceDTOs)
+	require.Len(t, serviceDTOs, 1)
+	assert.Equal(t, 100, serviceDTOs[0].Price.Gross)
+}
+
+func TestServiceCategoriesEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+
+	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
+	require.NoError(t, err)
+
+	categoryJSON, err := json.Marshal(internal.ServiceCategoryDTO{Name: "Detailing"})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPost, "/api/service-categories", categoryJSON, &token)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var category internal.ServiceCategory
+	suite.ParseResponse(t, response, &category)
+
+	assert.Equal(t, &garage.ID, category.GarageID)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/service-categories?garageId=%v", garage.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var categories []internal.ServiceCategory
+	suite.ParseResponse(t, response, &categories)
+	assert.Contains(t, categories, category)
+
+	response = suite.CallAPI(http.MethodGet, "/api/service-categories", []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &categories)
+	assert.NotContains(t, categories, category)
+
+	renameJSON, err := json.Marshal(internal.ServiceCategoryDTO{Name: "Car detailing"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/service-categories/%v", category.ID), renameJSON, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &category)
+	assert.Equal(t, "Car detailing", category.Name)
+
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/service-categories/%v", categories[0].ID), renameJSON, &token)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	maxPrice := internal.MoneyDTO{Gross: 300}
+	serviceJSON, err := json.Marshal(internal.ServiceDTO{
+		Name:        "Interior cleaning",
+		Description: "Vacuuming and upholstery washing",
+		Time:        60,
+		Price:       internal.MoneyDTO{Gross: 100},
+		MaxPrice:    &maxPrice,
+		CategoryID:  &category.ID,
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/services", serviceJSON, &token)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	missingCategoryID := category.ID + 1000
+	serviceJSON, err = json.Marshal(internal.ServiceDTO{
+		Name:       "Oil change",
+		Time:       30,
+		Price:      internal.MoneyDTO{Gross: 80},
+		CategoryID: &missingCategoryID,
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/services", serviceJSON, &token)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/services?query=upholstery&categoryId=%v", category.ID), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var results []internal.ServiceSearchResultDTO
+	suite.ParseResponse(t, response, &results)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Interior cleaning", results[0].Service.Name)
+	require.NotNil(t, results[0].Service.MaxPrice)
+	assert.Equal(t, maxPrice.Gross, results[0].Service.MaxPrice.Gross)
+	assert.Equal(t, garage.ID, results[0].Garage.ID)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/garages/%v/services?categoryId=%v", garage.ID, category.ID+1), []byte{}, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var serviceDTOs []internal.ServiceDTO
+	suite.ParseResponse(t, response, &serviceDTOs)
+	assert.Len(t, serviceDTOs, 0)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/service-categories/%v", category.ID), []byte{}, &token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestUpdateServiceEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(internal.Service{
+		Name:     "name",
+		Time:     30,
+		Price:    100,
+		GarageID: garage.ID,
+	})
+	require.NoError(t, err)
+
+	token, err := suite.api.auth.CreateToken("email", internal.OwnerRole)
+	require.NoError(t, err)
+
+	serviceJSON, err := json.Marshal(internal.UpdateServiceDTO{
+		Name:  "new name",
+		Time:  45,
+		Price: internal.MoneyDTO{Gross: 120},
+	})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/services/%v", service.ID), serviceJSON, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var serviceDTO internal.ServiceDTO
+	suite.ParseResponse(t, response, &serviceDTO)
+	assert.Equal(t, service.ID, serviceDTO.ID)
+	assert.Equal(t, "new name", serviceDTO.Name)
+	assert.Equal(t, 45, serviceDTO.Time)
+	assert.Equal(t, 120, serviceDTO.Price.Gross)
+
+	effectiveFrom := time.Now().Add(24 * time.Hour)
+	serviceJSON, err = json.Marshal(internal.UpdateServiceDTO{
+		Name:          "new name",
+		Time:          45,
+		Price:         internal.MoneyDTO{Gross: 200},
+		EffectiveFrom: &effectiveFrom,
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/services/%v", service.ID), serviceJSON, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &serviceDTO)
+	assert.Equal(t, 120, serviceDTO.Price.Gross)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/services/%v/versions", service.ID), []byte{}, &token)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var versions []internal.ServiceVersionDTO
+	suite.ParseResponse(t, response, &versions)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 200, versions[2].Price.Gross)
 }
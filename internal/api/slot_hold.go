@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+const (
+	checkoutHoldDuration = 5 * time.Minute
+	holdSweepInterval    = time.Minute
+)
+
+// CreateSlotHold reserves the slot of a booking for the customer while they
+// complete it. The body is the one of CreateAppointment, the hold is turned
+// into the appointment once it is booked. A customer has one such hold at a
+// time, a new one releases the previous.
+func (a *API) CreateSlotHold(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateAppointmentDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateAppointmentDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	if err = a.storage.SlotHolds().DeleteCheckoutByCustomerID(customer.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	booking, ok := a.checkBooking(writer, &dto, customer)
+	if !ok {
+		return
+	}
+
+	hold, err := a.storage.SlotHolds().InsertCheckout(internal.SlotHold{
+		EmployeeID: booking.employee.ID,
+		CustomerID: customer.ID,
+		StartTime:  dto.StartTime,
+		EndTime:    dto.EndTime,
+		ExpiresAt:  time.Now().Add(checkoutHoldDuration),
+		CreatedAt:  time.Now(),
+	})
+	if errors.Is(err, internal.ErrSlotHeld) {
+		a.handleError(writer, err, 409)
+		return
+	}
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewSlotHoldDTO(hold), 201)
+}
+
+func (a *API) DeleteSlotHold(writer http.ResponseWriter, request *http.Request) {
+	holdIDStr := request.PathValue("id")
+	holdID, err := strconv.Atoi(holdIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	hold, err := a.storage.SlotHolds().GetByID(holdID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+	if hold.CustomerID != customer.ID || hold.WaitlistEntryID != nil {
+		a.handleError(writer, errors.New("hold not found"), 404)
+		return
+	}
+
+	if err = a.storage.SlotHolds().Delete(hold.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+// slotHeld reports whether the slot of the employee is held for someone else
+// than the customer.
+func (a *API) slotHeld(slot internal.TimeSlot, employeeID, customerID int) (bool, error) {
+	holds, err := a.storage.SlotHolds().ListActive(slot, employeeID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, hold := range holds {
+		if hold.CustomerID != customerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// releaseSlotHolds removes the holds on a slot booked by the customer. The
+// waitlist entry of the customer is fulfilled, the other customers offered the
+// slot keep waiting.
+func (a *API) releaseSlotHolds(slot internal.TimeSlot, employeeID, customerID int) {
+	holds, err := a.storage.SlotHolds().DeleteActive(slot, employeeID)
+	if err != nil {
+		a.log.Error("unable to release slot holds", "error", err)
+		return
+	}
+
+	for _, hold := range holds {
+		if hold.WaitlistEntryID == nil {
+			continue
+		}
+
+		status := internal.WaitingEntry
+		if hold.CustomerID == customerID {
+			status = internal.BookedEntry
+		}
+		if err = a.storage.WaitlistEntries().UpdateStatus(*hold.WaitlistEntryID, status, internal.OfferedEntry); err != nil {
+			a.log.Error("unable to update waitlist entry", "error", err)
+		}
+	}
+}
+
+// sweepSlotHolds removes the expired holds. Customers who let a waitlist offer
+// expire leave the waitlist and the slot is offered to the next ones.
+func (a *API) sweepSlotHolds() {
+	holds, err := a.storage.SlotHolds().DeleteExpired()
+	if err != nil {
+		a.log.Error("unable to remove expired holds", "error", err)
+		return
+	}
+
+	for _, hold := range holds {
+		if hold.WaitlistEntryID == nil {
+			continue
+		}
+
+		entry, err := a.storage.WaitlistEntries().GetByID(*hold.WaitlistEntryID)
+		if err != nil {
+			continue
+		}
+		if err = a.storage.WaitlistEntries().UpdateStatus(entry.ID, internal.ExpiredEntry, internal.OfferedEntry); err != nil {
+			a.log.Error("unable to update waitlist entry", "error", err)
+			continue
+		}
+
+		garage, err := a.storage.Garages().GetByID(entry.GarageID)
+		if err != nil {
+			continue
+		}
+		a.offerSlot(garage, hold.EmployeeID, internal.TimeSlot{
+			StartTime: hold.StartTime,
+			EndTime:   hold.EndTime,
+		})
+	}
+}
+
+func (a *API) runHoldSweeper() {
+	ticker := time.NewTicker(holdSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		a.sweepSlotHolds()
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotHoldEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	holderToken := suite.CreateCustomer(t, internal.Customer{Email: "holder@example.com", Password: "Password123"})
+	otherToken := suite.CreateCustomer(t, internal.Customer{Email: "other@example.com", Password: "Password123"})
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:         "name",
+			City:         "city",
+			Street:       "street",
+			Number:       "number",
+			PostalCode:   "postalCode",
+			PhoneNumber:  "phoneNumber",
+			OwnerID:      owner.ID,
+			Latitude:     10,
+			Longitude:    10,
+			WaitlistMode: internal.FirstComeWaitlist,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     2,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointmentJSON, err := json.Marshal(internal.CreateAppointmentDTO{
+		StartTime:  time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2030, 9, 24, 13, 0, 0, 0, time.UTC),
+		ServiceID:  service.ID,
+		EmployeeID: mechanic.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/holds", appointmentJSON, nil)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/holds", appointmentJSON, holderToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var hold internal.SlotHoldDTO
+	suite.ParseResponse(t, response, &hold)
+	assert.Equal(t, mechanic.ID, hold.EmployeeID)
+	assert.WithinDuration(t, time.Now().Add(checkoutHoldDuration), hold.ExpiresAt, time.Minute)
+
+	response = suite.CallAPI(http.MethodPost, "/api/holds", appointmentJSON, otherToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	slotsPath := fmt.Sprintf("/api/appointments/availableSlots?serviceId=%v&employeeId=%v&date=2030-09-24", service.ID, mechanic.ID)
+	var slots []internal.TimeSlot
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, otherToken)
+	suite.ParseResponse(t, response, &slots)
+	assert.NotContains(t, slotStarts(slots), 11)
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, holderToken)
+	suite.ParseResponse(t, response, &slots)
+	assert.Contains(t, slotStarts(slots), 11)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, otherToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/holds/%v", hold.ID), []byte{}, otherToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, holderToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	_, err = suite.api.storage.SlotHolds().GetByID(hold.ID)
+	assert.Error(t, err)
+
+	laterJSON, err := json.Marshal(internal.CreateAppointmentDTO{
+		StartTime:  time.Date(2030, 9, 24, 14, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2030, 9, 24, 16, 0, 0, 0, time.UTC),
+		ServiceID:  service.ID,
+		EmployeeID: mechanic.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodPost, "/api/holds", laterJSON, holderToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	suite.ParseResponse(t, response, &hold)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/holds/%v", hold.ID), []byte{}, holderToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, otherToken)
+	suite.ParseResponse(t, response, &slots)
+	assert.Contains(t, slotStarts(slots), 14)
+}
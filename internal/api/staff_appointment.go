@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/validate"
+
+	"github.com/google/uuid"
+)
+
+// CreateStaffAppointment books an appointment at the garage of the employee
+// on behalf of a customer, for walk-ins and bookings taken by phone. The
+// customer is either an existing one, found by email, or a guest created
+// with the booking, who can be invited to claim it into a full account.
+func (a *API) CreateStaffAppointment(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateStaffAppointmentDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateStaffAppointmentDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	var customer internal.Customer
+	if dto.Guest != nil {
+		if dto.Guest.Email != "" {
+			if _, err = a.storage.Customers().GetByEmail(dto.Guest.Email); err == nil {
+				a.handleError(writer, errors.New("customer already exists"), 409)
+				return
+			}
+		}
+		customer = internal.NewGuest(*dto.Guest)
+	} else {
+		customer, err = a.storage.Customers().GetByEmail(dto.CustomerEmail)
+		if err != nil {
+			a.handleError(writer, errors.New("customer not found"), 404)
+			return
+		}
+	}
+
+	booking, ok := a.checkBooking(writer, &dto.Appointment, customer)
+	if !ok {
+		return
+	}
+	if booking.garage.ID != garage.ID {
+		a.handleError(writer, errors.New("employee not found"), 404)
+		return
+	}
+
+	if dto.Guest != nil {
+		customer, err = a.storage.Customers().Insert(customer)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+
+		if dto.Guest.PlateNumber != "" {
+			vehicle, err := a.storage.Vehicles().Insert(internal.Vehicle{
+				CustomerID:  customer.ID,
+				ModelID:     dto.Appointment.ModelID,
+				PlateNumber: dto.Guest.PlateNumber,
+			})
+			if err != nil {
+				a.handleError(writer, err, 500)
+				return
+			}
+			dto.Appointment.VehicleID = vehicle.ID
+		}
+
+		if dto.Guest.Invite {
+			if err = a.inviteGuest(customer, garage); err != nil {
+				a.log.Error(err.Error())
+			}
+		}
+	}
+
+	if dto.Appointment.Recurrence != nil {
+		a.createAppointmentSeries(writer, dto.Appointment, customer, booking)
+		return
+	}
+
+	appointment := internal.NewAppointment(dto.Appointment, customer.ID)
+	appointment.CompanyID = booking.companyID
+	appointment.Services = appointmentLineItems(booking.services, booking.garage)
+	appointment, err = a.storage.Appointments().Insert(appointment)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.releaseSlotHolds(internal.TimeSlot{StartTime: appointment.StartTime, EndTime: appointment.EndTime}, booking.employee.ID, customer.ID)
+
+	a.publishAppointmentEvent(events.AppointmentCreated, appointment)
+
+	if err = a.sendAppointmentConfirmation(customer, booking.garage, appointment, booking.services); err != nil {
+		a.log.Error(err.Error())
+	}
+
+	a.sendResponse(writer, nil, 201)
+}
+
+// inviteGuest emails the guest a link to claim their bookings into a full
+// account.
+func (a *API) inviteGuest(customer internal.Customer, garage internal.Garage) error {
+	invitation, err := a.storage.CustomerInvitations().Insert(
+		internal.CustomerInvitation{
+			ID:         uuid.New().String(),
+			CustomerID: customer.ID,
+		})
+	if err != nil {
+		return err
+	}
+
+	return a.mail.Send(
+		customer.Email,
+		"Zaproszenie",
+		mail.CustomerInvitationTemplate,
+		mail.CustomerInvitation{
+			GarageName: garage.Name,
+			Code:       invitation.ID,
+		},
+	)
+}
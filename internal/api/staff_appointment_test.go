@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffAppointmentEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customerToken := suite.CreateCustomer(t, internal.Customer{Email: "customer@example.com", Password: "Password123"})
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:         "name",
+			City:         "city",
+			Street:       "street",
+			Number:       "number",
+			PostalCode:   "postalCode",
+			PhoneNumber:  "phoneNumber",
+			OwnerID:      owner.ID,
+			Latitude:     10,
+			Longitude:    10,
+			WaitlistMode: internal.FirstComeWaitlist,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     2,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+
+	appointment := func(hour int) internal.CreateAppointmentDTO {
+		return internal.CreateAppointmentDTO{
+			StartTime:  time.Date(2030, 9, 24, hour, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2030, 9, 24, hour+2, 0, 0, 0, time.UTC),
+			ServiceID:  service.ID,
+			EmployeeID: mechanic.ID,
+			ModelID:    1,
+		}
+	}
+
+	bookingJSON, err := json.Marshal(internal.CreateStaffAppointmentDTO{
+		Appointment:   appointment(9),
+		CustomerEmail: "customer@example.com",
+	})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/garages/appointments", bookingJSON, customerToken)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", bookingJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", bookingJSON, &ownerToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var customerAppointments internal.CustomerAppointmentDTOs
+	suite.ParseResponse(t, response, &customerAppointments)
+	assert.Len(t, customerAppointments.Upcoming, 1)
+
+	missingJSON, err := json.Marshal(internal.CreateStaffAppointmentDTO{
+		Appointment:   appointment(11),
+		CustomerEmail: "missing@example.com",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", missingJSON, &ownerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	existingJSON, err := json.Marshal(internal.CreateStaffAppointmentDTO{
+		Appointment: appointment(11),
+		Guest:       &internal.GuestDTO{Name: "John", PhoneNumber: "123456789", Email: "customer@example.com"},
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", existingJSON, &ownerToken)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	for _, hour := range []int{11, 13} {
+		guestJSON, err := json.Marshal(internal.CreateStaffAppointmentDTO{
+			Appointment: appointment(hour),
+			Guest:       &internal.GuestDTO{Name: "Jan", PhoneNumber: "123456789", PlateNumber: fmt.Sprintf("WA%v", hour)},
+		})
+		require.NoError(t, err)
+		response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", guestJSON, &ownerToken)
+		require.Equal(t, http.StatusCreated, response.StatusCode)
+	}
+
+	appointments, err := suite.api.storage.Appointments().GetByEmployeeID(mechanic.ID, time.Date(2030, 9, 24, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, appointments, 3)
+	var guestAppointment internal.Appointment
+	for _, booked := range appointments {
+		if booked.StartTime.Hour() == 13 {
+			guestAppointment = booked
+		}
+	}
+	require.NotNil(t, guestAppointment.VehicleID)
+
+	guest, err := suite.api.storage.Customers().GetByID(guestAppointment.CustomerID)
+	require.NoError(t, err)
+	assert.True(t, guest.Guest)
+	assert.Equal(t, "Jan", guest.Name)
+
+	vehicle, err := suite.api.storage.Vehicles().GetByID(*guestAppointment.VehicleID)
+	require.NoError(t, err)
+	assert.Equal(t, guest.ID, vehicle.CustomerID)
+
+	invitation, err := suite.api.storage.CustomerInvitations().Insert(
+		internal.CustomerInvitation{
+			ID:         uuid.New().String(),
+			CustomerID: guest.ID,
+		})
+	require.NoError(t, err)
+
+	claimJSON, err := json.Marshal(internal.CreateCustomerDTO{
+		Email:           "customer@example.com",
+		Password:        "Password123",
+		ConfirmPassword: "Password123",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/customers/register/%v", invitation.ID), claimJSON, nil)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+
+	claimJSON, err = json.Marshal(internal.CreateCustomerDTO{
+		Email:           "jan@example.com",
+		Password:        "Password123",
+		ConfirmPassword: "Password123",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/customers/register/%v", uuid.New().String()), claimJSON, nil)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/customers/register/%v", invitation.ID), claimJSON, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/customers/register/%v", invitation.ID), claimJSON, nil)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	loginJSON, err := json.Marshal(internal.LoginDTO{Email: "jan@example.com", Password: "Password123"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/customers/login", loginJSON, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var guestToken internal.Token
+	suite.ParseResponse(t, response, &guestToken)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, &guestToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &customerAppointments)
+	require.Len(t, customerAppointments.Upcoming, 1)
+	assert.Equal(t, guestAppointment.ID, customerAppointments.Upcoming[0].ID)
+
+	emailGuestJSON, err := json.Marshal(internal.CreateStaffAppointmentDTO{
+		Appointment: internal.CreateAppointmentDTO{
+			StartTime:  time.Date(2030, 9, 25, 9, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2030, 9, 25, 11, 0, 0, 0, time.UTC),
+			ServiceID:  service.ID,
+			EmployeeID: mechanic.ID,
+			ModelID:    1,
+		},
+		Guest: &internal.GuestDTO{Name: "Anna", PhoneNumber: "987654321", Email: "anna@example.com"},
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/garages/appointments", emailGuestJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	registerJSON, err := json.Marshal(internal.CreateCustomerDTO{
+		Email:           "anna@example.com",
+		Password:        "Password123",
+		ConfirmPassword: "Password123",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/customers/register", registerJSON, nil)
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+}
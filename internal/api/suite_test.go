@@ -14,6 +14,7 @@ import (
 
 	"github.com/KsaweryZietara/garage/internal"
 	"github.com/KsaweryZietara/garage/internal/auth"
+	"github.com/KsaweryZietara/garage/internal/events"
 	"github.com/KsaweryZietara/garage/internal/mail"
 	"github.com/KsaweryZietara/garage/internal/storage"
 
@@ -63,8 +64,9 @@ func NewSuite(t *testing.T) *Suite {
 	require.NoError(t, err)
 	auth := auth.New("secret-key")
 	mail := mail.New(mail.Config{})
+	events := events.New(log)
 
-	api := New(Config{}, log, storage, auth, mail)
+	api := New(Config{}, log, storage, auth, mail, events)
 
 	router := http.NewServeMux()
 	api.attachRoutes(router)
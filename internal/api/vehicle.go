@@ -0,0 +1,381 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+func (a *API) ListVehicles(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	vehicles, err := a.storage.Vehicles().ListByCustomerID(customer.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	if member, err := a.storage.Companies().GetMember(customer.ID); err == nil {
+		fleet, err := a.storage.Vehicles().ListByCompanyID(member.CompanyID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		for _, vehicle := range fleet {
+			if vehicle.CustomerID != customer.ID {
+				vehicles = append(vehicles, vehicle)
+			}
+		}
+	}
+
+	vehicleDTOs := make([]internal.VehicleDTO, len(vehicles))
+	for i, vehicle := range vehicles {
+		car, err := a.storage.Cars().GetByModelID(vehicle.ModelID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		vehicleDTOs[i] = internal.NewVehicleDTO(vehicle, car)
+	}
+
+	a.sendResponse(writer, vehicleDTOs, 200)
+}
+
+func (a *API) CreateVehicle(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateVehicleDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateVehicleDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	car, err := a.storage.Cars().GetByModelID(dto.ModelID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	vehicle := internal.NewVehicle(dto, customer.ID)
+	if dto.Shared {
+		member, err := a.storage.Companies().GetMember(customer.ID)
+		if err != nil {
+			a.handleError(writer, errors.New("only company members can share vehicles"), 400)
+			return
+		}
+		vehicle.CompanyID = &member.CompanyID
+	}
+
+	vehicle, err = a.storage.Vehicles().Insert(vehicle)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewVehicleDTO(vehicle, car), 201)
+}
+
+func (a *API) UpdateVehicle(writer http.ResponseWriter, request *http.Request) {
+	vehicleIDStr := request.PathValue("id")
+	vehicleID, err := strconv.Atoi(vehicleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CreateVehicleDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateVehicleDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	vehicle, ok := a.customerVehicle(writer, request, vehicleID)
+	if !ok {
+		return
+	}
+
+	car, err := a.storage.Cars().GetByModelID(dto.ModelID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	switch {
+	case dto.Shared && vehicle.CompanyID == nil:
+		member, err := a.storage.Companies().GetMember(vehicle.CustomerID)
+		if err != nil {
+			a.handleError(writer, errors.New("only company members can share vehicles"), 400)
+			return
+		}
+		vehicle.CompanyID = &member.CompanyID
+	case !dto.Shared:
+		vehicle.CompanyID = nil
+	}
+
+	vehicle.ModelID = dto.ModelID
+	vehicle.Year = dto.Year
+	vehicle.PlateNumber = dto.PlateNumber
+	vehicle.VIN = dto.VIN
+	vehicle.ShareHistory = dto.ShareHistory
+
+	if err = a.storage.Vehicles().Update(vehicle); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewVehicleDTO(vehicle, car), 200)
+}
+
+func (a *API) DeleteVehicle(writer http.ResponseWriter, request *http.Request) {
+	vehicleIDStr := request.PathValue("id")
+	vehicleID, err := strconv.Atoi(vehicleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	vehicle, ok := a.customerVehicle(writer, request, vehicleID)
+	if !ok {
+		return
+	}
+
+	if err = a.storage.Vehicles().Delete(vehicle.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) GetVehicleHistory(writer http.ResponseWriter, request *http.Request) {
+	vehicleIDStr := request.PathValue("id")
+	vehicleID, err := strconv.Atoi(vehicleIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	vehicle, err := a.storage.Vehicles().GetByID(vehicleID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	appointments, err := a.storage.Appointments().ListByVehicleID(vehicle.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	switch role {
+	case internal.CustomerRole:
+		customer, err := a.storage.Customers().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return
+		}
+		if vehicle.CustomerID != customer.ID && !a.canUseVehicle(customer, vehicle) {
+			a.handleError(writer, errors.New("vehicle not found"), 404)
+			return
+		}
+
+	case internal.OwnerRole, internal.MechanicRole:
+		employee, err := a.storage.Employees().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return
+		}
+		if !vehicle.ShareHistory {
+			a.handleError(writer, errors.New("vehicle history is not shared"), 403)
+			return
+		}
+		servicing, err := a.isServicingVehicle(request, employee, appointments)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		if !servicing {
+			a.handleError(writer, errors.New("vehicle not found"), 404)
+			return
+		}
+	}
+
+	car, err := a.storage.Cars().GetByModelID(vehicle.ModelID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	history := internal.VehicleHistoryDTO{
+		Vehicle: internal.NewVehicleDTO(vehicle, car),
+		Entries: []internal.ServiceHistoryEntryDTO{},
+	}
+	now := time.Now()
+	for _, appointment := range appointments {
+		if appointment.EndTime.After(now) {
+			continue
+		}
+		service, err := a.storage.Services().GetByID(appointment.ServiceID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		garage, err := a.storage.Garages().GetByID(service.GarageID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		employee, err := a.storage.Employees().GetByID(appointment.EmployeeID)
+		if err != nil {
+			a.handleError(writer, err, 404)
+			return
+		}
+		services, err := a.storage.Appointments().ListServices(appointment.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		history.Entries = append(history.Entries, internal.NewServiceHistoryEntryDTO(appointment, service, services, garage, employee))
+	}
+
+	a.sendResponse(writer, history, 200)
+}
+
+// isServicingVehicle reports whether the employee's garage has an upcoming or
+// ongoing appointment among the vehicle's appointments.
+func (a *API) isServicingVehicle(request *http.Request, employee internal.Employee, appointments []internal.Appointment) (bool, error) {
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	for _, appointment := range appointments {
+		if appointment.EndTime.Before(now) {
+			continue
+		}
+		service, err := a.storage.Services().GetByID(appointment.ServiceID)
+		if err != nil {
+			return false, err
+		}
+		if service.GarageID == garage.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (a *API) customerVehicle(writer http.ResponseWriter, request *http.Request, vehicleID int) (internal.Vehicle, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Vehicle{}, false
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Vehicle{}, false
+	}
+
+	vehicle, err := a.storage.Vehicles().GetByID(vehicleID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Vehicle{}, false
+	}
+
+	if !a.canUseVehicle(customer, vehicle) {
+		a.handleError(writer, errors.New("vehicle not found"), 404)
+		return internal.Vehicle{}, false
+	}
+
+	return vehicle, true
+}
+
+// canUseVehicle reports whether the customer owns the vehicle or, for company
+// vehicles, is a member of the company.
+func (a *API) canUseVehicle(customer internal.Customer, vehicle internal.Vehicle) bool {
+	if vehicle.IsDeleted {
+		return false
+	}
+
+	if vehicle.CompanyID == nil {
+		return vehicle.CustomerID == customer.ID
+	}
+
+	member, err := a.storage.Companies().GetMember(customer.ID)
+	return err == nil && member.CompanyID == *vehicle.CompanyID
+}
+
+// appointmentVehicle returns the saved vehicle the appointment was booked for,
+// or nil for appointments made with a bare car model.
+func (a *API) appointmentVehicle(appointment internal.Appointment) (*internal.VehicleDTO, error) {
+	if appointment.VehicleID == nil {
+		return nil, nil
+	}
+
+	vehicle, err := a.storage.Vehicles().GetByID(*appointment.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := a.storage.Cars().GetByModelID(vehicle.ModelID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicleDTO := internal.NewVehicleDTO(vehicle, car)
+	return &vehicleDTO, nil
+}
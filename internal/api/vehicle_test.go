@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerProfileAndVehiclesEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	token := suite.CreateCustomer(t,
+		internal.Customer{
+			Email:    "john.doe@example.com",
+			Password: "Password123",
+		})
+
+	profile := internal.CustomerProfileDTO{
+		Name:             "John",
+		Surname:          "Doe",
+		PhoneNumber:      "123456789",
+		PreferredContact: internal.PhoneContact,
+	}
+	profileJSON, err := json.Marshal(profile)
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPut, "/api/customers/profile", profileJSON, token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/profile", []byte{}, token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var customerDTO internal.CustomerDTO
+	suite.ParseResponse(t, response, &customerDTO)
+	assert.Equal(t, "John", customerDTO.Name)
+	assert.Equal(t, "123456789", customerDTO.PhoneNumber)
+	assert.Equal(t, internal.PhoneContact, customerDTO.PreferredContact)
+
+	vehicleJSON, err := json.Marshal(internal.CreateVehicleDTO{ModelID: 1, PlateNumber: "WA12345"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/customers/vehicles", vehicleJSON, token)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	var vehicleDTO internal.VehicleDTO
+	suite.ParseResponse(t, response, &vehicleDTO)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/vehicles", []byte{}, token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var vehicleDTOs []internal.VehicleDTO
+	suite.ParseResponse(t, response, &vehicleDTOs)
+	assert.Len(t, vehicleDTOs, 1)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	assert.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     2,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	assert.NoError(t, err)
+
+	appointment := internal.CreateAppointmentDTO{
+		StartTime:  time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2030, 9, 24, 13, 0, 0, 0, time.UTC),
+		ServiceID:  service.ID,
+		EmployeeID: owner.ID,
+		VehicleID:  vehicleDTO.ID,
+	}
+	appointmentJSON, err := json.Marshal(appointment)
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, token)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodGet, "/api/employees/appointments?date=2030-09-24", []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var appointmentDTOs []internal.AppointmentDTO
+	suite.ParseResponse(t, response, &appointmentDTOs)
+	require.Len(t, appointmentDTOs, 1)
+	assert.Equal(t, "123456789", appointmentDTOs[0].Customer.PhoneNumber)
+	assert.Equal(t, "WA12345", appointmentDTOs[0].Vehicle.PlateNumber)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/customers/vehicles/%v", vehicleDTO.ID), []byte{}, token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, token)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestVehicleHistoryEndpoint(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	token := suite.CreateCustomer(t,
+		internal.Customer{
+			Email:    "john.doe@example.com",
+			Password: "Password123",
+		})
+	customer, err := suite.api.storage.Customers().GetByEmail("john.doe@example.com")
+	require.NoError(t, err)
+
+	vehicle, err := suite.api.storage.Vehicles().Insert(
+		internal.Vehicle{
+			CustomerID:  customer.ID,
+			ModelID:     1,
+			PlateNumber: "WA12345",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+	assert.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	assert.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	assert.NoError(t, err)
+
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-48 * time.Hour),
+			EndTime:    time.Now().Add(-47 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: mechanic.ID,
+			CustomerID: customer.ID,
+			ModelID:    vehicle.ModelID,
+			VehicleID:  &vehicle.ID,
+		})
+	assert.NoError(t, err)
+
+	mechanicToken, err := suite.api.auth.CreateToken(mechanic.Email, internal.MechanicRole)
+	require.NoError(t, err)
+
+	mileage := 120000
+	notesJSON, err := json.Marshal(internal.AppointmentNotesDTO{Mileage: &mileage, Notes: "Replaced oil filter"})
+	require.NoError(t, err)
+	response := suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/appointments/%v/notes", appointment.ID), notesJSON, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	historyPath := fmt.Sprintf("/api/vehicles/%v/history", vehicle.ID)
+	response = suite.CallAPI(http.MethodGet, historyPath, []byte{}, token)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var historyDTO internal.VehicleHistoryDTO
+	suite.ParseResponse(t, response, &historyDTO)
+	require.Len(t, historyDTO.Entries, 1)
+	assert.Equal(t, mileage, *historyDTO.Entries[0].Mileage)
+	assert.Equal(t, "Replaced oil filter", *historyDTO.Entries[0].Notes)
+
+	response = suite.CallAPI(http.MethodGet, historyPath, []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	vehicle.ShareHistory = true
+	err = suite.api.storage.Vehicles().Update(vehicle)
+	require.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodGet, historyPath, []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	_, err = suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(48 * time.Hour),
+			EndTime:    time.Now().Add(49 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: mechanic.ID,
+			CustomerID: customer.ID,
+			ModelID:    vehicle.ModelID,
+			VehicleID:  &vehicle.ID,
+		})
+	assert.NoError(t, err)
+
+	response = suite.CallAPI(http.MethodGet, historyPath, []byte{}, &mechanicToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	suite.ParseResponse(t, response, &historyDTO)
+	assert.Len(t, historyDTO.Entries, 1)
+}
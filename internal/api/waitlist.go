@@ -0,0 +1,284 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/mail"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+const waitlistHoldDuration = 2 * time.Hour
+
+func (a *API) CreateWaitlistEntry(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateWaitlistEntryDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateWaitlistEntryDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	customer, ok := a.waitlistCustomer(writer, request)
+	if !ok {
+		return
+	}
+
+	service, err := a.storage.Services().GetByID(dto.ServiceID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+	if service.IsDeleted {
+		a.handleError(writer, errors.New("service not found"), 404)
+		return
+	}
+
+	if dto.EmployeeID != 0 {
+		qualified, err := a.storage.Employees().IsQualified(dto.EmployeeID, service.ID)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		if !qualified {
+			a.handleError(writer, errors.New("employee does not perform this service"), 400)
+			return
+		}
+	}
+
+	entry, err := a.storage.WaitlistEntries().Insert(internal.NewWaitlistEntry(dto, customer.ID, service.GarageID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWaitlistEntryDTO(entry), 201)
+}
+
+func (a *API) ListWaitlistEntries(writer http.ResponseWriter, request *http.Request) {
+	customer, ok := a.waitlistCustomer(writer, request)
+	if !ok {
+		return
+	}
+
+	entries, err := a.storage.WaitlistEntries().ListByCustomerID(customer.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWaitlistEntryDTOs(entries), 200)
+}
+
+func (a *API) DeleteWaitlistEntry(writer http.ResponseWriter, request *http.Request) {
+	entryIDStr := request.PathValue("id")
+	entryID, err := strconv.Atoi(entryIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	customer, ok := a.waitlistCustomer(writer, request)
+	if !ok {
+		return
+	}
+
+	entry, err := a.storage.WaitlistEntries().GetByID(entryID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+	if entry.CustomerID != customer.ID {
+		a.handleError(writer, errors.New("waitlist entry not found"), 404)
+		return
+	}
+
+	if err = a.storage.WaitlistEntries().Delete(entry.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) ListGarageWaitlist(writer http.ResponseWriter, request *http.Request) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	employee, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return
+	}
+
+	garage, err := a.employeeGarage(request, employee)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	entries, err := a.storage.WaitlistEntries().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWaitlistEntryDTOs(entries), 200)
+}
+
+func (a *API) waitlistCustomer(writer http.ResponseWriter, request *http.Request) (internal.Customer, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Customer{}, false
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Customer{}, false
+	}
+
+	return customer, true
+}
+
+// offerFreedSlot offers the time of a cancelled appointment to the waitlist
+// of its garage.
+func (a *API) offerFreedSlot(appointment internal.Appointment) {
+	service, err := a.storage.Services().GetByID(appointment.ServiceID)
+	if err != nil {
+		a.log.Error("unable to offer freed slot", "error", err)
+		return
+	}
+
+	garage, err := a.storage.Garages().GetByID(service.GarageID)
+	if err != nil {
+		a.log.Error("unable to offer freed slot", "error", err)
+		return
+	}
+
+	a.offerSlot(garage, appointment.EmployeeID, internal.TimeSlot{
+		StartTime: appointment.StartTime,
+		EndTime:   appointment.EndTime,
+	})
+}
+
+// offerSlot holds the free slot of the employee for the customers waiting
+// for it whose service fits in the slot. In first-come mode only the customer
+// waiting the longest gets the offer, in broadcast mode all of them do and the
+// first one to book takes the slot.
+func (a *API) offerSlot(garage internal.Garage, employeeID int, slot internal.TimeSlot) {
+	if slot.StartTime.Before(time.Now()) {
+		return
+	}
+
+	holds, err := a.storage.SlotHolds().ListActive(slot, employeeID)
+	if err != nil || len(holds) != 0 {
+		return
+	}
+
+	appointments, err := a.storage.Appointments().GetByTimeSlot(slot, employeeID)
+	if err != nil || len(appointments) != 0 {
+		return
+	}
+
+	entries, err := a.storage.WaitlistEntries().ListWaiting(garage.ID, employeeID, slot.StartTime)
+	if err != nil {
+		a.log.Error("unable to list waitlist", "error", err)
+		return
+	}
+
+	for _, entry := range entries {
+		service, err := a.storage.Services().GetByID(entry.ServiceID)
+		if err != nil || service.IsDeleted {
+			continue
+		}
+		if addWorkingHours(slot.StartTime, service.Time).After(slot.EndTime) {
+			continue
+		}
+		qualified, err := a.storage.Employees().IsQualified(employeeID, service.ID)
+		if err != nil || !qualified {
+			continue
+		}
+
+		hold, err := a.storage.SlotHolds().Insert(internal.SlotHold{
+			EmployeeID:      employeeID,
+			CustomerID:      entry.CustomerID,
+			WaitlistEntryID: &entry.ID,
+			StartTime:       slot.StartTime,
+			EndTime:         slot.EndTime,
+			ExpiresAt:       time.Now().Add(waitlistHoldDuration),
+			CreatedAt:       time.Now(),
+		})
+		if err != nil {
+			a.log.Error("unable to hold slot", "error", err)
+			return
+		}
+
+		err = a.storage.WaitlistEntries().UpdateStatus(entry.ID, internal.OfferedEntry, internal.WaitingEntry)
+		if err != nil {
+			a.log.Error("unable to update waitlist entry", "error", err)
+		}
+
+		if err = a.sendWaitlistOffer(entry, garage, service, hold); err != nil {
+			a.log.Error(err.Error())
+		}
+
+		if garage.WaitlistMode != internal.BroadcastWaitlist {
+			return
+		}
+	}
+}
+
+func (a *API) sendWaitlistOffer(entry internal.WaitlistEntry, garage internal.Garage, service internal.Service, hold internal.SlotHold) error {
+	customer, err := a.storage.Customers().GetByID(entry.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	return a.mail.Send(
+		customer.Email,
+		"Zwolnił się termin",
+		mail.WaitlistOfferTemplate,
+		mail.WaitlistOffer{
+			GarageName: garage.Name,
+			Service:    service.Name,
+			StartTime:  hold.StartTime.Format("2006-01-02 15:04"),
+			ExpiresAt:  hold.ExpiresAt.Format("2006-01-02 15:04"),
+		},
+	)
+}
+
+// requestCustomerID returns the ID of the customer authenticated by the
+// request, or zero for anonymous requests. It lets public endpoints tailor
+// their response to customers.
+func (a *API) requestCustomerID(request *http.Request) int {
+	authHeader := request.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, bearerPrefix) {
+		return 0
+	}
+
+	email, role, err := a.auth.VerifyToken(authHeader[len(bearerPrefix):])
+	if err != nil || role != internal.CustomerRole {
+		return 0
+	}
+
+	customer, err := a.storage.Customers().GetByEmail(email)
+	if err != nil {
+		return 0
+	}
+	return customer.ID
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitlistEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	bookingToken := suite.CreateCustomer(t, internal.Customer{Email: "booking@example.com", Password: "Password123"})
+	firstToken := suite.CreateCustomer(t, internal.Customer{Email: "first@example.com", Password: "Password123"})
+	secondToken := suite.CreateCustomer(t, internal.Customer{Email: "second@example.com", Password: "Password123"})
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:         "name",
+			City:         "city",
+			Street:       "street",
+			Number:       "number",
+			PostalCode:   "postalCode",
+			PhoneNumber:  "phoneNumber",
+			OwnerID:      owner.ID,
+			Latitude:     10,
+			Longitude:    10,
+			WaitlistMode: internal.FirstComeWaitlist,
+		})
+	require.NoError(t, err)
+
+	mechanic, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email2",
+			Password:  "password",
+			Role:      internal.MechanicRole,
+			GarageID:  &garage.ID,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     2,
+			Price:    10,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointmentJSON, err := json.Marshal(internal.CreateAppointmentDTO{
+		StartTime:  time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2030, 9, 24, 13, 0, 0, 0, time.UTC),
+		ServiceID:  service.ID,
+		EmployeeID: mechanic.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, bookingToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	entryJSON, err := json.Marshal(internal.CreateWaitlistEntryDTO{
+		ServiceID:  service.ID,
+		EmployeeID: mechanic.ID,
+		From:       "2030-09-23",
+		To:         "2030-09-27",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/waitlist", entryJSON, firstToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var firstEntry internal.WaitlistEntryDTO
+	suite.ParseResponse(t, response, &firstEntry)
+	assert.Equal(t, internal.WaitingEntry, firstEntry.Status)
+	assert.Equal(t, "2030-09-27", firstEntry.To)
+
+	entryJSON, err = json.Marshal(internal.CreateWaitlistEntryDTO{
+		ServiceID: service.ID,
+		From:      "2030-09-24",
+		To:        "2030-09-24",
+	})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPost, "/api/waitlist", entryJSON, secondToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var secondEntry internal.WaitlistEntryDTO
+	suite.ParseResponse(t, response, &secondEntry)
+
+	var customerAppointments internal.CustomerAppointmentDTOs
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, bookingToken)
+	suite.ParseResponse(t, response, &customerAppointments)
+	require.Len(t, customerAppointments.Upcoming, 1)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v", customerAppointments.Upcoming[0].ID), []byte{}, bookingToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	entry, err := suite.api.storage.WaitlistEntries().GetByID(firstEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.OfferedEntry, entry.Status)
+	entry, err = suite.api.storage.WaitlistEntries().GetByID(secondEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.WaitingEntry, entry.Status)
+
+	slotsPath := fmt.Sprintf("/api/appointments/availableSlots?serviceId=%v&employeeId=%v&date=2030-09-24", service.ID, mechanic.ID)
+	var slots []internal.TimeSlot
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, secondToken)
+	suite.ParseResponse(t, response, &slots)
+	assert.NotContains(t, slotStarts(slots), 11)
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, firstToken)
+	suite.ParseResponse(t, response, &slots)
+	assert.Contains(t, slotStarts(slots), 11)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, secondToken)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/appointments", appointmentJSON, firstToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	var entries []internal.WaitlistEntryDTO
+	response = suite.CallAPI(http.MethodGet, "/api/waitlist", []byte{}, firstToken)
+	suite.ParseResponse(t, response, &entries)
+	require.Len(t, entries, 1)
+	assert.Equal(t, internal.BookedEntry, entries[0].Status)
+
+	response = suite.CallAPI(http.MethodGet, "/api/customers/appointments", []byte{}, firstToken)
+	suite.ParseResponse(t, response, &customerAppointments)
+	require.Len(t, customerAppointments.Upcoming, 1)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v", customerAppointments.Upcoming[0].ID), []byte{}, firstToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	entry, err = suite.api.storage.WaitlistEntries().GetByID(secondEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.OfferedEntry, entry.Status)
+
+	slot := internal.TimeSlot{
+		StartTime: time.Date(2030, 9, 24, 11, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2030, 9, 24, 13, 0, 0, 0, time.UTC),
+	}
+	holds, err := suite.api.storage.SlotHolds().DeleteActive(slot, mechanic.ID)
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	holds[0].ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = suite.api.storage.SlotHolds().Insert(holds[0])
+	require.NoError(t, err)
+
+	suite.api.sweepSlotHolds()
+
+	entry, err = suite.api.storage.WaitlistEntries().GetByID(secondEntry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.ExpiredEntry, entry.Status)
+
+	response = suite.CallAPI(http.MethodGet, slotsPath, []byte{}, nil)
+	suite.ParseResponse(t, response, &slots)
+	assert.Contains(t, slotStarts(slots), 11)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/waitlist/%v", secondEntry.ID), []byte{}, firstToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/waitlist/%v", secondEntry.ID), []byte{}, secondToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func slotStarts(slots []internal.TimeSlot) []int {
+	starts := make([]int, len(slots))
+	for i, slot := range slots {
+		starts[i] = slot.StartTime.Hour()
+	}
+	return starts
+}
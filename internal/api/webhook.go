@@ -0,0 +1,235 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/validate"
+	"github.com/KsaweryZietara/garage/internal/webhook"
+)
+
+const (
+	webhookAttempts = 5
+	webhookBackoff  = 30 * time.Second
+)
+
+type webhookPayload struct {
+	events.Event
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (a *API) ListWebhookEventTypes(writer http.ResponseWriter, _ *http.Request) {
+	a.sendResponse(writer, events.Types, 200)
+}
+
+func (a *API) ListWebhooks(writer http.ResponseWriter, request *http.Request) {
+	garage, ok := a.webhooksGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	webhooks, err := a.storage.Webhooks().ListByGarageID(garage.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWebhookDTOs(webhooks), 200)
+}
+
+func (a *API) CreateWebhook(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.CreateWebhookDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateWebhookDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	garage, ok := a.webhooksGarage(writer, request)
+	if !ok {
+		return
+	}
+
+	webhook, err := a.storage.Webhooks().Insert(internal.NewWebhook(dto, garage.ID))
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWebhookDTO(webhook), 201)
+}
+
+func (a *API) UpdateWebhook(writer http.ResponseWriter, request *http.Request) {
+	webhookIDStr := request.PathValue("id")
+	webhookID, err := strconv.Atoi(webhookIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	var dto internal.CreateWebhookDTO
+	err = json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.CreateWebhookDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	webhook, ok := a.ownedWebhook(writer, request, webhookID)
+	if !ok {
+		return
+	}
+
+	webhook.URL = dto.URL
+	webhook.Secret = dto.Secret
+	webhook.Active = dto.Active
+	webhook.EventTypes = dto.EventTypes
+
+	if err = a.storage.Webhooks().Update(webhook); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWebhookDTO(webhook), 200)
+}
+
+func (a *API) DeleteWebhook(writer http.ResponseWriter, request *http.Request) {
+	webhookIDStr := request.PathValue("id")
+	webhookID, err := strconv.Atoi(webhookIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	webhook, ok := a.ownedWebhook(writer, request, webhookID)
+	if !ok {
+		return
+	}
+
+	if err = a.storage.Webhooks().Delete(webhook.ID); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, nil, 200)
+}
+
+func (a *API) ListWebhookDeliveries(writer http.ResponseWriter, request *http.Request) {
+	webhookIDStr := request.PathValue("id")
+	webhookID, err := strconv.Atoi(webhookIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	webhook, ok := a.ownedWebhook(writer, request, webhookID)
+	if !ok {
+		return
+	}
+
+	deliveries, err := a.storage.Webhooks().ListDeliveries(webhook.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.NewWebhookDeliveryDTOs(deliveries), 200)
+}
+
+func (a *API) webhooksGarage(writer http.ResponseWriter, request *http.Request) (internal.Garage, bool) {
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return internal.Garage{}, false
+	}
+
+	owner, err := a.storage.Employees().GetByEmail(email)
+	if err != nil {
+		a.handleError(writer, err, 401)
+		return internal.Garage{}, false
+	}
+
+	garage, err := a.employeeGarage(request, owner)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Garage{}, false
+	}
+
+	return garage, true
+}
+
+func (a *API) ownedWebhook(writer http.ResponseWriter, request *http.Request, webhookID int) (internal.Webhook, bool) {
+	garage, ok := a.webhooksGarage(writer, request)
+	if !ok {
+		return internal.Webhook{}, false
+	}
+
+	webhook, err := a.storage.Webhooks().GetByID(webhookID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return internal.Webhook{}, false
+	}
+
+	if webhook.GarageID != garage.ID {
+		a.handleError(writer, errors.New("webhook not found"), 404)
+		return internal.Webhook{}, false
+	}
+
+	return webhook, true
+}
+
+// dispatchWebhooks sends the event to the webhooks of the garage subscribed
+// to it. Deliveries run in the background and every attempt is recorded in
+// the delivery history of the webhook.
+func (a *API) dispatchWebhooks(event events.Event) {
+	webhooks, err := a.storage.Webhooks().ListSubscribed(event.GarageID, string(event.Type))
+	if err != nil {
+		a.log.Error("unable to list webhooks", "error", err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{Event: event, CreatedAt: time.Now()})
+	if err != nil {
+		a.log.Error("unable to encode webhook payload", "error", err)
+		return
+	}
+
+	for _, hook := range webhooks {
+		go a.webhooks.Deliver(hook.URL, hook.Secret, string(event.Type), payload, func(attempt webhook.Attempt) {
+			delivery := internal.WebhookDelivery{
+				WebhookID:  hook.ID,
+				EventType:  string(event.Type),
+				Payload:    string(payload),
+				Attempt:    attempt.Number,
+				StatusCode: attempt.StatusCode,
+				CreatedAt:  time.Now(),
+			}
+			if attempt.Err != nil {
+				message := attempt.Err.Error()
+				delivery.Error = &message
+			}
+			if _, err := a.storage.Webhooks().InsertDelivery(delivery); err != nil {
+				a.log.Error("unable to record webhook delivery", "error", err)
+			}
+		})
+	}
+}
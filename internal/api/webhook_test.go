@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+	suite.api.webhooks = webhook.NewWithTransport(http.DefaultTransport, 3, 10*time.Millisecond)
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	appointment, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-3 * time.Hour),
+			EndTime:    time.Now().Add(-2 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	const secret = "0123456789abcdef"
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.Sign(secret, timestamp, body), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, string(events.ReviewCreated), r.Header.Get(webhook.EventHeader))
+
+		var payload events.Event
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, appointment.ID, payload.AppointmentID)
+		assert.Equal(t, garage.ID, payload.GarageID)
+
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dto := internal.CreateWebhookDTO{
+		URL:        receiver.URL,
+		Secret:     secret,
+		Active:     true,
+		EventTypes: []string{string(events.ReviewCreated)},
+	}
+	dtoJSON, err := json.Marshal(dto)
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, "/api/webhooks", dtoJSON, &customerToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodPost, "/api/webhooks", dtoJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var created internal.WebhookDTO
+	suite.ParseResponse(t, response, &created)
+	assert.Equal(t, receiver.URL, created.URL)
+	assert.Equal(t, []string{string(events.ReviewCreated)}, created.EventTypes)
+
+	response = suite.CallAPI(http.MethodGet, "/api/webhooks", []byte{}, &ownerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var webhooks []internal.WebhookDTO
+	suite.ParseResponse(t, response, &webhooks)
+	assert.Len(t, webhooks, 1)
+
+	reviewJSON, err := json.Marshal(internal.CreateReviewDTO{Rating: 5, Comment: "comment"})
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/appointments/%v/reviews", appointment.ID), reviewJSON, &customerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	var deliveries []internal.WebhookDeliveryDTO
+	require.Eventually(t, func() bool {
+		response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/webhooks/%v/deliveries", created.ID), []byte{}, &ownerToken)
+		suite.ParseResponse(t, response, &deliveries)
+		return len(deliveries) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, 2, deliveries[0].Attempt)
+	assert.Equal(t, http.StatusOK, *deliveries[0].StatusCode)
+	assert.Nil(t, deliveries[0].Error)
+	assert.Equal(t, 1, deliveries[1].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, *deliveries[1].StatusCode)
+	assert.NotNil(t, deliveries[1].Error)
+
+	dto.Active = false
+	dtoJSON, err = json.Marshal(dto)
+	require.NoError(t, err)
+	response = suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/webhooks/%v", created.ID), dtoJSON, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/webhooks/%v", created.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/webhooks/%v/deliveries", created.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
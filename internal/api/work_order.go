@@ -0,0 +1,242 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+const maxWorkOrderPhotos = 10
+
+// GetWorkOrder returns the work order of the appointment. Employees handling
+// the appointment can see it at any time, the customer once the appointment
+// is completed.
+func (a *API) GetWorkOrder(writer http.ResponseWriter, request *http.Request) {
+	appointmentIDStr := request.PathValue("id")
+	appointmentID, err := strconv.Atoi(appointmentIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	role, ok := a.roleFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	email, ok := a.emailFromContext(request.Context())
+	if !ok {
+		a.sendResponse(writer, nil, 401)
+		return
+	}
+
+	appointment, err := a.storage.Appointments().GetByID(appointmentID)
+	if err != nil {
+		a.handleError(writer, err, 404)
+		return
+	}
+
+	switch role {
+	case internal.CustomerRole:
+		customer, err := a.storage.Customers().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return
+		}
+		if customer.ID != appointment.CustomerID {
+			a.handleError(writer, errors.New("appointment not found for this customer"), 404)
+			return
+		}
+		if time.Now().Before(appointment.EndTime) {
+			a.handleError(writer, errors.New("appointment is not completed yet"), 404)
+			return
+		}
+
+	default:
+		employee, err := a.storage.Employees().GetByEmail(email)
+		if err != nil {
+			a.handleError(writer, err, 401)
+			return
+		}
+		canHandle, err := a.canHandleAppointment(request, employee, appointment)
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+		if !canHandle {
+			a.handleError(writer, errors.New("appointment not found for this employee"), 404)
+			return
+		}
+	}
+
+	workOrder, err := a.storage.WorkOrders().GetByAppointmentID(appointment.ID)
+	if err != nil {
+		workOrder = internal.WorkOrder{AppointmentID: appointment.ID}
+	}
+
+	a.sendResponse(writer, internal.NewWorkOrderDTO(appointment, workOrder), 200)
+}
+
+func (a *API) UpdateWorkOrder(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.UpdateWorkOrderDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	err = validate.UpdateWorkOrderDTO(dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, _, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	if time.Now().Before(appointment.StartTime) {
+		a.handleError(writer, errors.New("appointment has not started yet"), 400)
+		return
+	}
+
+	appointment.Mileage = dto.Mileage
+	appointment.Notes = &dto.Notes
+	if err = a.storage.Appointments().Update(appointment); err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	_, err = a.storage.WorkOrders().Save(internal.WorkOrder{
+		AppointmentID: appointment.ID,
+		Findings:      dto.Findings,
+	})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	workOrder, err := a.storage.WorkOrders().GetByAppointmentID(appointment.ID)
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.publishAppointmentEvent(events.AppointmentUpdated, appointment)
+
+	a.sendResponse(writer, internal.NewWorkOrderDTO(appointment, workOrder), 200)
+}
+
+func (a *API) CreateWorkOrderPhoto(writer http.ResponseWriter, request *http.Request) {
+	var dto internal.WorkOrderPhotoDTO
+	err := json.NewDecoder(request.Body).Decode(&dto)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	if len(dto.Photo) == 0 {
+		a.sendResponse(writer, nil, 400)
+		return
+	}
+
+	if strings.HasPrefix(dto.Photo, base64Prefix) {
+		separatorIndex := strings.Index(dto.Photo, ",")
+		if separatorIndex == -1 {
+			a.handleError(writer, fmt.Errorf("invalid base64 format"), 400)
+			return
+		}
+
+		dto.Photo = dto.Photo[separatorIndex+1:]
+	}
+
+	decodedPhoto, err := base64.StdEncoding.DecodeString(dto.Photo)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, _, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	if time.Now().Before(appointment.StartTime) {
+		a.handleError(writer, errors.New("appointment has not started yet"), 400)
+		return
+	}
+
+	workOrder, err := a.storage.WorkOrders().GetByAppointmentID(appointment.ID)
+	if err != nil {
+		workOrder, err = a.storage.WorkOrders().Save(internal.WorkOrder{AppointmentID: appointment.ID})
+		if err != nil {
+			a.handleError(writer, err, 500)
+			return
+		}
+	}
+
+	if len(workOrder.Photos) >= maxWorkOrderPhotos {
+		a.handleError(writer, fmt.Errorf("work order cannot have more than %d photos", maxWorkOrderPhotos), 400)
+		return
+	}
+
+	photo, err := a.storage.WorkOrders().InsertPhoto(internal.WorkOrderPhoto{
+		WorkOrderID: workOrder.ID,
+		Photo:       decodedPhoto,
+	})
+	if err != nil {
+		a.handleError(writer, err, 500)
+		return
+	}
+
+	a.sendResponse(writer, internal.WorkOrderPhotoDTO{
+		ID:        photo.ID,
+		Photo:     base64.StdEncoding.EncodeToString(photo.Photo),
+		CreatedAt: photo.CreatedAt,
+	}, 201)
+}
+
+func (a *API) DeleteWorkOrderPhoto(writer http.ResponseWriter, request *http.Request) {
+	photoIDStr := request.PathValue("photoId")
+	photoID, err := strconv.Atoi(photoIDStr)
+	if err != nil {
+		a.handleError(writer, err, 400)
+		return
+	}
+
+	appointment, _, ok := a.staffAppointment(writer, request)
+	if !ok {
+		return
+	}
+
+	workOrder, err := a.storage.WorkOrders().GetByAppointmentID(appointment.ID)
+	if err != nil {
+		a.handleError(writer, errors.New("photo not found"), 404)
+		return
+	}
+
+	for _, photo := range workOrder.Photos {
+		if photo.ID == photoID {
+			if err = a.storage.WorkOrders().DeletePhoto(photo.ID); err != nil {
+				a.handleError(writer, err, 500)
+				return
+			}
+
+			a.sendResponse(writer, nil, 200)
+			return
+		}
+	}
+
+	a.handleError(writer, errors.New("photo not found"), 404)
+}
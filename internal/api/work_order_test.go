@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkOrderEndpoints(t *testing.T) {
+	suite := NewSuite(t)
+	defer suite.Teardown()
+
+	customer, err := suite.api.storage.Customers().Insert(
+		internal.Customer{
+			Email:    "customer",
+			Password: "password",
+		})
+	require.NoError(t, err)
+
+	owner, err := suite.api.storage.Employees().Insert(
+		internal.Employee{
+			Name:      "name",
+			Surname:   "surname",
+			Email:     "email",
+			Password:  "password",
+			Role:      internal.OwnerRole,
+			GarageID:  nil,
+			Confirmed: true,
+		})
+	require.NoError(t, err)
+
+	garage, err := suite.api.storage.Garages().Insert(
+		internal.Garage{
+			Name:        "name",
+			City:        "city",
+			Street:      "street",
+			Number:      "number",
+			PostalCode:  "postalCode",
+			PhoneNumber: "phoneNumber",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    internal.DefaultCurrency,
+			TaxRate:     internal.DefaultTaxRate,
+		})
+	require.NoError(t, err)
+
+	service, err := suite.api.storage.Services().Insert(
+		internal.Service{
+			Name:     "name",
+			Time:     1,
+			Price:    12300,
+			GarageID: garage.ID,
+		})
+	require.NoError(t, err)
+
+	inProgress, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-time.Hour),
+			EndTime:    time.Now().Add(time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	completed, err := suite.api.storage.Appointments().Insert(
+		internal.Appointment{
+			StartTime:  time.Now().Add(-3 * time.Hour),
+			EndTime:    time.Now().Add(-2 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+	require.NoError(t, err)
+
+	ownerToken, err := suite.api.auth.CreateToken(owner.Email, internal.OwnerRole)
+	require.NoError(t, err)
+	customerToken, err := suite.api.auth.CreateToken(customer.Email, internal.CustomerRole)
+	require.NoError(t, err)
+
+	mileage := 120000
+	workOrderJSON, err := json.Marshal(internal.UpdateWorkOrderDTO{
+		Mileage:  &mileage,
+		Notes:    "Replaced brake pads",
+		Findings: "Brake discs close to the wear limit",
+	})
+	require.NoError(t, err)
+
+	for _, appointment := range []internal.Appointment{inProgress, completed} {
+		response := suite.CallAPI(http.MethodPut, fmt.Sprintf("/api/appointments/%v/work-order", appointment.ID), workOrderJSON, &ownerToken)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	photoJSON, err := json.Marshal(internal.WorkOrderPhotoDTO{
+		Photo: "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("photo")),
+	})
+	require.NoError(t, err)
+
+	response := suite.CallAPI(http.MethodPost, fmt.Sprintf("/api/appointments/%v/work-order/photos", completed.ID), photoJSON, &ownerToken)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	var photo internal.WorkOrderPhotoDTO
+	suite.ParseResponse(t, response, &photo)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/work-order", inProgress.ID), []byte{}, &customerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodGet, fmt.Sprintf("/api/appointments/%v/work-order", completed.ID), []byte{}, &customerToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var workOrder internal.WorkOrderDTO
+	suite.ParseResponse(t, response, &workOrder)
+	assert.Equal(t, "Brake discs close to the wear limit", workOrder.Findings)
+	assert.Equal(t, &mileage, workOrder.Mileage)
+	require.Len(t, workOrder.Photos, 1)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("photo")), workOrder.Photos[0].Photo)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v/work-order/photos/%v", inProgress.ID, photo.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = suite.CallAPI(http.MethodDelete, fmt.Sprintf("/api/appointments/%v/work-order/photos/%v", completed.ID, photo.ID), []byte{}, &ownerToken)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
@@ -0,0 +1,193 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/validate"
+)
+
+type Entry struct {
+	Make     string             `json:"make"`
+	Model    string             `json:"model"`
+	YearFrom *int               `json:"yearFrom,omitempty"`
+	YearTo   *int               `json:"yearTo,omitempty"`
+	BodyType *internal.BodyType `json:"bodyType,omitempty"`
+}
+
+type Store interface {
+	UpsertMake(name string) (int, error)
+	UpsertModel(model internal.Model) (int, error)
+}
+
+type Result struct {
+	Makes  int
+	Models int
+}
+
+// ParseFile reads a catalog file, choosing the format by its extension.
+func ParseFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(file)
+	case ".json":
+		return ParseJSON(file)
+	default:
+		return nil, fmt.Errorf("unsupported catalog format: %s", filepath.Ext(path))
+	}
+}
+
+// ParseCSV reads a catalog with a header row. The make and model columns are
+// required, year_from, year_to and body_type are optional.
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("while reading header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["make"]; !ok {
+		return nil, errors.New("missing make column")
+	}
+	if _, ok := columns["model"]; !ok {
+		return nil, errors.New("missing model column")
+	}
+
+	var entries []Entry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		entry := Entry{
+			Make:  value("make"),
+			Model: value("model"),
+		}
+		if entry.YearFrom, err = parseYear(value("year_from")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if entry.YearTo, err = parseYear(value("year_to")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if bodyType := value("body_type"); bodyType != "" {
+			normalized := internal.BodyType(strings.ToUpper(bodyType))
+			entry.BodyType = &normalized
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ParseJSON reads a catalog stored as an array of entries.
+func ParseJSON(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Make = strings.TrimSpace(entries[i].Make)
+		entries[i].Model = strings.TrimSpace(entries[i].Model)
+	}
+
+	return entries, nil
+}
+
+// Import validates all entries and loads them into the store. Entries that are
+// already present are updated in place, so the same file can be imported again.
+func Import(store Store, entries []Entry) (Result, error) {
+	for i, entry := range entries {
+		if err := validateEntry(entry); err != nil {
+			return Result{}, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+	}
+
+	makeIDs := make(map[string]int)
+	var result Result
+	for i, entry := range entries {
+		makeID, ok := makeIDs[entry.Make]
+		if !ok {
+			var err error
+			makeID, err = store.UpsertMake(entry.Make)
+			if err != nil {
+				return result, fmt.Errorf("entry %d: %w", i+1, err)
+			}
+			makeIDs[entry.Make] = makeID
+			result.Makes++
+		}
+
+		_, err := store.UpsertModel(internal.Model{
+			Name:     entry.Model,
+			MakeID:   makeID,
+			YearFrom: entry.YearFrom,
+			YearTo:   entry.YearTo,
+			BodyType: entry.BodyType,
+		})
+		if err != nil {
+			return result, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		result.Models++
+	}
+
+	return result, nil
+}
+
+func validateEntry(entry Entry) error {
+	if err := validate.CatalogMakeDTO(internal.CatalogMakeDTO{Name: entry.Make}); err != nil {
+		return err
+	}
+
+	return validate.CatalogModelDTO(internal.CatalogModelDTO{
+		Name:     entry.Model,
+		YearFrom: entry.YearFrom,
+		YearTo:   entry.YearTo,
+		BodyType: entry.BodyType,
+	})
+}
+
+func parseYear(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	year, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid year %q", s)
+	}
+
+	return &year, nil
+}
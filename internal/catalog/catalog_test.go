@@ -0,0 +1,159 @@
+package catalog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	makes  map[string]int
+	models map[string]internal.Model
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		makes:  make(map[string]int),
+		models: make(map[string]internal.Model),
+	}
+}
+
+func (f *fakeStore) UpsertMake(name string) (int, error) {
+	if name == "Broken" {
+		return 0, errors.New("database error")
+	}
+	if id, ok := f.makes[name]; ok {
+		return id, nil
+	}
+	f.makes[name] = len(f.makes) + 1
+	return f.makes[name], nil
+}
+
+func (f *fakeStore) UpsertModel(model internal.Model) (int, error) {
+	key := model.Name + "/" + string(rune('0'+model.MakeID))
+	existing, ok := f.models[key]
+	if !ok {
+		model.ID = len(f.models) + 1
+		f.models[key] = model
+		return model.ID, nil
+	}
+	if model.YearFrom != nil {
+		existing.YearFrom = model.YearFrom
+	}
+	if model.YearTo != nil {
+		existing.YearTo = model.YearTo
+	}
+	if model.BodyType != nil {
+		existing.BodyType = model.BodyType
+	}
+	f.models[key] = existing
+	return existing.ID, nil
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Run("should parse entries with optional columns", func(t *testing.T) {
+		input := "make,model,year_from,year_to,body_type\n" +
+			"Skoda,Octavia,1996,,estate\n" +
+			"Skoda,Fabia,,,\n"
+
+		entries, err := ParseCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "Skoda", entries[0].Make)
+		assert.Equal(t, "Octavia", entries[0].Model)
+		assert.Equal(t, 1996, *entries[0].YearFrom)
+		assert.Nil(t, entries[0].YearTo)
+		assert.Equal(t, internal.EstateBody, *entries[0].BodyType)
+		assert.Nil(t, entries[1].YearFrom)
+		assert.Nil(t, entries[1].BodyType)
+	})
+
+	t.Run("should parse entries without optional columns", func(t *testing.T) {
+		input := "model,make\nCorolla,Toyota\n"
+
+		entries, err := ParseCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Toyota", entries[0].Make)
+		assert.Equal(t, "Corolla", entries[0].Model)
+	})
+
+	t.Run("should return error when make column is missing", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("model\nCorolla\n"))
+		assert.EqualError(t, err, "missing make column")
+	})
+
+	t.Run("should return error for invalid year", func(t *testing.T) {
+		input := "make,model,year_from\nToyota,Corolla,abc\n"
+
+		_, err := ParseCSV(strings.NewReader(input))
+		assert.EqualError(t, err, `line 2: invalid year "abc"`)
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Run("should parse entries", func(t *testing.T) {
+		input := `[{"make": "Toyota", "model": " Corolla ", "yearFrom": 1966, "bodyType": "SEDAN"}]`
+
+		entries, err := ParseJSON(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Corolla", entries[0].Model)
+		assert.Equal(t, 1966, *entries[0].YearFrom)
+		assert.Equal(t, internal.SedanBody, *entries[0].BodyType)
+	})
+
+	t.Run("should return error for malformed input", func(t *testing.T) {
+		_, err := ParseJSON(strings.NewReader(`{"make": "Toyota"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Run("should import entries idempotently", func(t *testing.T) {
+		yearFrom := 1996
+		bodyType := internal.EstateBody
+		entries := []Entry{
+			{Make: "Skoda", Model: "Octavia"},
+			{Make: "Skoda", Model: "Fabia"},
+			{Make: "Toyota", Model: "Corolla"},
+		}
+		store := newFakeStore()
+
+		result, err := Import(store, entries)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Makes: 2, Models: 3}, result)
+
+		entries[0].YearFrom = &yearFrom
+		entries[0].BodyType = &bodyType
+		_, err = Import(store, entries)
+		require.NoError(t, err)
+		assert.Len(t, store.makes, 2)
+		assert.Len(t, store.models, 3)
+		assert.Equal(t, yearFrom, *store.models["Octavia/1"].YearFrom)
+		assert.Equal(t, bodyType, *store.models["Octavia/1"].BodyType)
+	})
+
+	t.Run("should reject invalid entries before importing", func(t *testing.T) {
+		bodyType := internal.BodyType("SPACESHIP")
+		entries := []Entry{
+			{Make: "Skoda", Model: "Octavia"},
+			{Make: "Skoda", Model: "Fabia", BodyType: &bodyType},
+		}
+		store := newFakeStore()
+
+		_, err := Import(store, entries)
+		assert.EqualError(t, err, "entry 2: unknown body type")
+		assert.Empty(t, store.makes)
+	})
+
+	t.Run("should return store errors", func(t *testing.T) {
+		_, err := Import(newFakeStore(), []Entry{{Make: "Broken", Model: "Model"}})
+		assert.EqualError(t, err, "entry 1: database error")
+	})
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"math"
 	"time"
 )
@@ -38,61 +39,237 @@ type CreateGarageDTO struct {
 	Longitude      float64      `json:"longitude"`
 	Services       []ServiceDTO `json:"services"`
 	EmployeeEmails []string     `json:"employeeEmails"`
+	Currency       string       `json:"currency"`
+	TaxRate        *int         `json:"taxRate,omitempty"`
+	WaitlistMode   WaitlistMode `json:"waitlistMode,omitempty"`
+}
+
+// MoneyDTO is an amount in minor units of its currency, split into the net
+// amount and the tax included in the gross price.
+type MoneyDTO struct {
+	Gross    int    `json:"gross"`
+	Net      int    `json:"net"`
+	Tax      int    `json:"tax"`
+	Currency string `json:"currency"`
+}
+
+func NewMoneyDTO(gross int, pricing Pricing) MoneyDTO {
+	net := pricing.Net(gross)
+	return MoneyDTO{
+		Gross:    gross,
+		Net:      net,
+		Tax:      gross - net,
+		Currency: pricing.Currency,
+	}
+}
+
+func newMoneyDTOPtr(gross *int, pricing Pricing) *MoneyDTO {
+	if gross == nil {
+		return nil
+	}
+	money := NewMoneyDTO(*gross, pricing)
+	return &money
+}
+
+// UnmarshalJSON accepts either a money object or a bare number of minor units,
+// which is read as the gross amount.
+func (m *MoneyDTO) UnmarshalJSON(data []byte) error {
+	var gross int
+	if err := json.Unmarshal(data, &gross); err == nil {
+		*m = MoneyDTO{Gross: gross}
+		return nil
+	}
+
+	type money MoneyDTO
+	var value money
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*m = MoneyDTO(value)
+	return nil
+}
+
+// GrossPtr returns the gross amount, or nil when no amount was given.
+func (m *MoneyDTO) GrossPtr() *int {
+	if m == nil {
+		return nil
+	}
+	gross := m.Gross
+	return &gross
 }
 
 type ServiceDTO struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Time  int    `json:"time"`
-	Price int    `json:"price"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Time        int       `json:"time"`
+	Price       MoneyDTO  `json:"price"`
+	MaxPrice    *MoneyDTO `json:"maxPrice,omitempty"`
+	CategoryID  *int      `json:"categoryId,omitempty"`
 }
 
-func NewServiceDTO(service Service) ServiceDTO {
+func NewServiceDTO(service Service, pricing Pricing) ServiceDTO {
 	return ServiceDTO{
-		ID:    service.ID,
-		Name:  service.Name,
-		Time:  service.Time,
-		Price: service.Price,
+		ID:          service.ID,
+		Name:        service.Name,
+		Description: service.Description,
+		Time:        service.Time,
+		Price:       NewMoneyDTO(service.Price, pricing),
+		MaxPrice:    newMoneyDTOPtr(service.MaxPrice, pricing),
+		CategoryID:  service.CategoryID,
+	}
+}
+
+func NewServiceDTOs(services []Service, pricing Pricing) []ServiceDTO {
+	serviceDTOs := make([]ServiceDTO, len(services))
+	for i, service := range services {
+		serviceDTOs[i] = NewServiceDTO(service, pricing)
 	}
+	return serviceDTOs
 }
 
-func NewServiceDTOs(services []Service) []ServiceDTO {
+func NewAppointmentServiceDTOs(services []AppointmentService) []ServiceDTO {
 	serviceDTOs := make([]ServiceDTO, len(services))
 	for i, service := range services {
-		serviceDTOs[i] = NewServiceDTO(service)
+		serviceDTOs[i] = ServiceDTO{
+			ID:    service.ServiceID,
+			Name:  service.Name,
+			Time:  service.Time,
+			Price: NewMoneyDTO(service.Price, service.Pricing()),
+		}
 	}
 	return serviceDTOs
 }
 
+// NewTotalPriceDTO sums the booked services. Net amounts and taxes are added
+// up per service so that they match the individual line items.
+func NewTotalPriceDTO(services []AppointmentService) MoneyDTO {
+	total := MoneyDTO{Currency: DefaultCurrency}
+	for i, service := range services {
+		price := NewMoneyDTO(service.Price, service.Pricing())
+		if i == 0 {
+			total.Currency = price.Currency
+		}
+		total.Gross += price.Gross
+		total.Net += price.Net
+		total.Tax += price.Tax
+	}
+	return total
+}
+
+// UpdateServiceDTO changes a service. Name, description and category apply
+// immediately, while the price and time take effect from EffectiveFrom,
+// defaulting to now.
+type UpdateServiceDTO struct {
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Time          int        `json:"time"`
+	Price         MoneyDTO   `json:"price"`
+	MaxPrice      *MoneyDTO  `json:"maxPrice,omitempty"`
+	CategoryID    *int       `json:"categoryId,omitempty"`
+	EffectiveFrom *time.Time `json:"effectiveFrom,omitempty"`
+}
+
+type ServiceVersionDTO struct {
+	ID            int       `json:"id"`
+	Price         MoneyDTO  `json:"price"`
+	MaxPrice      *MoneyDTO `json:"maxPrice,omitempty"`
+	Time          int       `json:"time"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+func NewServiceVersionDTOs(versions []ServiceVersion, pricing Pricing) []ServiceVersionDTO {
+	versionDTOs := make([]ServiceVersionDTO, len(versions))
+	for i, version := range versions {
+		versionDTOs[i] = ServiceVersionDTO{
+			ID:            version.ID,
+			Price:         NewMoneyDTO(version.Price, pricing),
+			MaxPrice:      newMoneyDTOPtr(version.MaxPrice, pricing),
+			Time:          version.Time,
+			EffectiveFrom: version.EffectiveFrom,
+		}
+	}
+	return versionDTOs
+}
+
+type ServiceSearchResultDTO struct {
+	Service ServiceDTO `json:"service"`
+	Garage  GarageDTO  `json:"garage"`
+}
+
+type ServiceCategoryDTO struct {
+	Name string `json:"name"`
+}
+
+type EmployeeServicesDTO struct {
+	ServiceIDs []int `json:"serviceIds"`
+}
+
+type ServiceRuleDTO struct {
+	ID      int             `json:"id"`
+	Type    ServiceRuleType `json:"type"`
+	MakeID  int             `json:"makeId"`
+	ModelID *int            `json:"modelId,omitempty"`
+	Price   *int            `json:"price,omitempty"`
+	Time    *int            `json:"time,omitempty"`
+}
+
+func NewServiceRuleDTO(rule ServiceRule) ServiceRuleDTO {
+	return ServiceRuleDTO{
+		ID:      rule.ID,
+		Type:    rule.Type,
+		MakeID:  rule.MakeID,
+		ModelID: rule.ModelID,
+		Price:   rule.Price,
+		Time:    rule.Time,
+	}
+}
+
+func NewServiceRuleDTOs(rules []ServiceRule) []ServiceRuleDTO {
+	ruleDTOs := make([]ServiceRuleDTO, len(rules))
+	for i, rule := range rules {
+		ruleDTOs[i] = NewServiceRuleDTO(rule)
+	}
+	return ruleDTOs
+}
+
 type GarageDTO struct {
-	ID          int     `json:"id"`
-	Name        string  `json:"name"`
-	City        string  `json:"city"`
-	Street      string  `json:"street"`
-	Number      string  `json:"number"`
-	PostalCode  string  `json:"postalCode"`
-	PhoneNumber string  `json:"phoneNumber"`
-	Latitude    float64 `json:"latitude"`
-	Longitude   float64 `json:"longitude"`
-	Rating      float64 `json:"rating"`
-	Distance    float64 `json:"distance"`
-	Logo        string  `json:"logo"`
+	ID             int          `json:"id"`
+	Name           string       `json:"name"`
+	City           string       `json:"city"`
+	Street         string       `json:"street"`
+	Number         string       `json:"number"`
+	PostalCode     string       `json:"postalCode"`
+	PhoneNumber    string       `json:"phoneNumber"`
+	Latitude       float64      `json:"latitude"`
+	Longitude      float64      `json:"longitude"`
+	Rating         float64      `json:"rating"`
+	Distance       float64      `json:"distance"`
+	Logo           string       `json:"logo"`
+	OrganizationID *int         `json:"organizationId,omitempty"`
+	Currency       string       `json:"currency"`
+	TaxRate        int          `json:"taxRate"`
+	WaitlistMode   WaitlistMode `json:"waitlistMode"`
 }
 
 func NewGarageDTO(garage Garage) GarageDTO {
 	return GarageDTO{
-		ID:          garage.ID,
-		Name:        garage.Name,
-		City:        garage.City,
-		Street:      garage.Street,
-		Number:      garage.Number,
-		PostalCode:  garage.PostalCode,
-		PhoneNumber: garage.PhoneNumber,
-		Latitude:    garage.Latitude,
-		Longitude:   garage.Longitude,
-		Rating:      math.Round(garage.Rating*10) / 10,
-		Distance:    math.Round(garage.Distance*10) / 10,
-		Logo:        base64.StdEncoding.EncodeToString(garage.Logo),
+		ID:             garage.ID,
+		Name:           garage.Name,
+		City:           garage.City,
+		Street:         garage.Street,
+		Number:         garage.Number,
+		PostalCode:     garage.PostalCode,
+		PhoneNumber:    garage.PhoneNumber,
+		Latitude:       garage.Latitude,
+		Longitude:      garage.Longitude,
+		Rating:         math.Round(garage.Rating*10) / 10,
+		Distance:       math.Round(garage.Distance*10) / 10,
+		Logo:           base64.StdEncoding.EncodeToString(garage.Logo),
+		OrganizationID: garage.OrganizationID,
+		Currency:       garage.Currency,
+		TaxRate:        garage.TaxRate,
+		WaitlistMode:   garage.WaitlistMode,
 	}
 }
 
@@ -111,6 +288,7 @@ type EmployeeDTO struct {
 	Confirmed      bool    `json:"confirmed"`
 	Email          *string `json:"email,omitempty"`
 	ProfilePicture string  `json:"profilePicture"`
+	RoleID         *int    `json:"roleId,omitempty"`
 }
 
 func NewEmployeeDTO(employee Employee, email bool) EmployeeDTO {
@@ -124,6 +302,7 @@ func NewEmployeeDTO(employee Employee, email bool) EmployeeDTO {
 
 	if email {
 		dto.Email = &employee.Email
+		dto.RoleID = employee.GarageRoleID
 	}
 
 	return dto
@@ -143,39 +322,172 @@ type CreateCustomerDTO struct {
 	ConfirmPassword string `json:"confirmPassword"`
 }
 
+type CustomerProfileDTO struct {
+	Name             string        `json:"name"`
+	Surname          string        `json:"surname"`
+	PhoneNumber      string        `json:"phoneNumber"`
+	PreferredContact ContactMethod `json:"preferredContact"`
+}
+
+type CustomerDTO struct {
+	ID               int           `json:"id"`
+	Email            string        `json:"email"`
+	Name             string        `json:"name"`
+	Surname          string        `json:"surname"`
+	PhoneNumber      string        `json:"phoneNumber"`
+	PreferredContact ContactMethod `json:"preferredContact"`
+	Guest            bool          `json:"guest"`
+}
+
+func NewCustomerDTO(customer Customer) CustomerDTO {
+	return CustomerDTO{
+		ID:               customer.ID,
+		Email:            customer.Email,
+		Name:             customer.Name,
+		Surname:          customer.Surname,
+		PhoneNumber:      customer.PhoneNumber,
+		PreferredContact: customer.PreferredContact,
+		Guest:            customer.Guest,
+	}
+}
+
+type CreateVehicleDTO struct {
+	ModelID      int    `json:"modelId"`
+	Year         *int   `json:"year,omitempty"`
+	PlateNumber  string `json:"plateNumber"`
+	VIN          string `json:"vin"`
+	ShareHistory bool   `json:"shareHistory"`
+	// Shared adds the vehicle to the fleet of the customer's company.
+	Shared bool `json:"shared"`
+}
+
+type VehicleDTO struct {
+	ID           int    `json:"id"`
+	ModelID      int    `json:"modelId"`
+	Car          Car    `json:"car"`
+	Year         *int   `json:"year,omitempty"`
+	PlateNumber  string `json:"plateNumber"`
+	VIN          string `json:"vin"`
+	ShareHistory bool   `json:"shareHistory"`
+	Shared       bool   `json:"shared"`
+}
+
+func NewVehicleDTO(vehicle Vehicle, car Car) VehicleDTO {
+	return VehicleDTO{
+		ID:           vehicle.ID,
+		ModelID:      vehicle.ModelID,
+		Car:          car,
+		Year:         vehicle.Year,
+		PlateNumber:  vehicle.PlateNumber,
+		VIN:          vehicle.VIN,
+		ShareHistory: vehicle.ShareHistory,
+		Shared:       vehicle.CompanyID != nil,
+	}
+}
+
+type AppointmentNotesDTO struct {
+	Mileage *int   `json:"mileage,omitempty"`
+	Notes   string `json:"notes"`
+}
+
+type ServiceHistoryEntryDTO struct {
+	AppointmentID int          `json:"appointmentId"`
+	StartTime     time.Time    `json:"startTime"`
+	EndTime       time.Time    `json:"endTime"`
+	Service       string       `json:"service"`
+	Services      []ServiceDTO `json:"services"`
+	TotalPrice    MoneyDTO     `json:"totalPrice"`
+	Garage        string       `json:"garage"`
+	Employee      EmployeeDTO  `json:"employee"`
+	Mileage       *int         `json:"mileage,omitempty"`
+	Notes         *string      `json:"notes,omitempty"`
+}
+
+func NewServiceHistoryEntryDTO(appointment Appointment, service Service, services []AppointmentService, garage Garage, employee Employee) ServiceHistoryEntryDTO {
+	return ServiceHistoryEntryDTO{
+		AppointmentID: appointment.ID,
+		StartTime:     appointment.StartTime,
+		EndTime:       appointment.EndTime,
+		Service:       service.Name,
+		Services:      NewAppointmentServiceDTOs(services),
+		TotalPrice:    NewTotalPriceDTO(services),
+		Garage:        garage.Name,
+		Employee:      NewEmployeeDTO(employee, false),
+		Mileage:       appointment.Mileage,
+		Notes:         appointment.Notes,
+	}
+}
+
+type VehicleHistoryDTO struct {
+	Vehicle VehicleDTO               `json:"vehicle"`
+	Entries []ServiceHistoryEntryDTO `json:"entries"`
+}
+
 type CreateAppointmentDTO struct {
 	StartTime  time.Time `json:"startTime"`
 	EndTime    time.Time `json:"endTime"`
 	ServiceID  int       `json:"serviceId"`
 	EmployeeID int       `json:"employeeId"`
 	ModelID    int       `json:"modelId"`
+	VehicleID  int       `json:"vehicleId"`
+	ServiceIDs []int     `json:"serviceIds"`
+	// Recurrence books the appointment as a series starting at StartTime.
+	Recurrence *RecurrenceDTO `json:"recurrence,omitempty"`
+}
+
+type RecurrenceDTO struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`
+	Count     int                 `json:"count"`
+}
+
+// BookedServiceIDs returns the services to book. ServiceIDs takes precedence
+// over the single ServiceID kept for older clients.
+func (dto CreateAppointmentDTO) BookedServiceIDs() []int {
+	if len(dto.ServiceIDs) > 0 {
+		return dto.ServiceIDs
+	}
+	return []int{dto.ServiceID}
 }
 
 type AppointmentDTO struct {
-	ID        int          `json:"id"`
-	StartTime time.Time    `json:"startTime"`
-	EndTime   time.Time    `json:"endTime"`
-	Service   ServiceDTO   `json:"service"`
-	Employee  *EmployeeDTO `json:"employee,omitempty"`
-	Garage    *GarageDTO   `json:"garage,omitempty"`
-	Rating    *int         `json:"rating,omitempty"`
-	Comment   *string      `json:"comment,omitempty"`
-	Car       Car          `json:"car"`
-}
-
-func NewAppointmentDTO(appointment Appointment, service Service, employee Employee, garage Garage, car Car) AppointmentDTO {
+	ID             int          `json:"id"`
+	StartTime      time.Time    `json:"startTime"`
+	EndTime        time.Time    `json:"endTime"`
+	Service        ServiceDTO   `json:"service"`
+	Services       []ServiceDTO `json:"services"`
+	TotalPrice     MoneyDTO     `json:"totalPrice"`
+	Employee       *EmployeeDTO `json:"employee,omitempty"`
+	Garage         *GarageDTO   `json:"garage,omitempty"`
+	Customer       *CustomerDTO `json:"customer,omitempty"`
+	Vehicle        *VehicleDTO  `json:"vehicle,omitempty"`
+	Rating         *int         `json:"rating,omitempty"`
+	Comment        *string      `json:"comment,omitempty"`
+	Mileage        *int         `json:"mileage,omitempty"`
+	Notes          *string      `json:"notes,omitempty"`
+	SeriesID       *int         `json:"seriesId,omitempty"`
+	CompanyID      *int         `json:"companyId,omitempty"`
+	Car            Car          `json:"car"`
+	UnreadMessages int          `json:"unreadMessages"`
+}
+
+func NewAppointmentDTO(appointment Appointment, service Service, services []AppointmentService, employee Employee, garage Garage, car Car) AppointmentDTO {
 	employeeDTO := NewEmployeeDTO(employee, false)
 	garageDTO := NewGarageDTO(garage)
 	return AppointmentDTO{
-		ID:        appointment.ID,
-		StartTime: appointment.StartTime,
-		EndTime:   appointment.EndTime,
-		Service:   NewServiceDTO(service),
-		Employee:  &employeeDTO,
-		Garage:    &garageDTO,
-		Rating:    appointment.Rating,
-		Comment:   appointment.Comment,
-		Car:       car,
+		ID:         appointment.ID,
+		StartTime:  appointment.StartTime,
+		EndTime:    appointment.EndTime,
+		Service:    NewServiceDTO(service, garage.Pricing()),
+		Services:   NewAppointmentServiceDTOs(services),
+		TotalPrice: NewTotalPriceDTO(services),
+		Employee:   &employeeDTO,
+		Garage:     &garageDTO,
+		Rating:     appointment.Rating,
+		Comment:    appointment.Comment,
+		SeriesID:   appointment.SeriesID,
+		CompanyID:  appointment.CompanyID,
+		Car:        car,
 	}
 }
 
@@ -214,16 +526,22 @@ type ReviewDTO struct {
 	ID       int         `json:"id"`
 	Time     time.Time   `json:"time"`
 	Service  string      `json:"service"`
+	Services []string    `json:"services"`
 	Employee EmployeeDTO `json:"employee"`
 	Rating   int         `json:"rating"`
 	Comment  *string     `json:"comment,omitempty"`
 }
 
-func NewReviewDTO(appointment Appointment, service Service, employee Employee) ReviewDTO {
+func NewReviewDTO(appointment Appointment, service Service, services []AppointmentService, employee Employee) ReviewDTO {
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = service.Name
+	}
 	return ReviewDTO{
 		ID:       appointment.ID,
 		Time:     appointment.EndTime,
 		Service:  service.Name,
+		Services: names,
 		Employee: NewEmployeeDTO(employee, false),
 		Rating:   *appointment.Rating,
 		Comment:  appointment.Comment,
@@ -241,3 +559,712 @@ type LogoDTO struct {
 type ProfilePictureDTO struct {
 	Base64Picture string `json:"profilePicture"`
 }
+
+type GarageRoleDTO struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func NewGarageRoleDTO(role GarageRole) GarageRoleDTO {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []Permission{}
+	}
+	return GarageRoleDTO{
+		ID:          role.ID,
+		Name:        role.Name,
+		Permissions: permissions,
+	}
+}
+
+func NewGarageRoleDTOs(roles []GarageRole) []GarageRoleDTO {
+	roleDTOs := make([]GarageRoleDTO, len(roles))
+	for i, role := range roles {
+		roleDTOs[i] = NewGarageRoleDTO(role)
+	}
+	return roleDTOs
+}
+
+type EmployeeRoleDTO struct {
+	RoleID *int `json:"roleId"`
+}
+
+type EmployeeIDDTO struct {
+	EmployeeID int `json:"employeeId"`
+}
+
+type OwnershipTransferDTO struct {
+	ID            int         `json:"id"`
+	Garage        GarageDTO   `json:"garage"`
+	From          EmployeeDTO `json:"from"`
+	To            EmployeeDTO `json:"to"`
+	FromConfirmed bool        `json:"fromConfirmed"`
+	ToConfirmed   bool        `json:"toConfirmed"`
+	Completed     bool        `json:"completed"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
+func NewOwnershipTransferDTO(transfer OwnershipTransfer, garage Garage, from, to Employee) OwnershipTransferDTO {
+	return OwnershipTransferDTO{
+		ID:            transfer.ID,
+		Garage:        NewGarageDTO(garage),
+		From:          NewEmployeeDTO(from, true),
+		To:            NewEmployeeDTO(to, true),
+		FromConfirmed: transfer.FromConfirmed,
+		ToConfirmed:   transfer.ToConfirmed,
+		Completed:     transfer.Completed,
+		CreatedAt:     transfer.CreatedAt,
+	}
+}
+
+type CreateOrganizationDTO struct {
+	Name string `json:"name"`
+}
+
+type OrganizationDTO struct {
+	ID      int         `json:"id"`
+	Name    string      `json:"name"`
+	Garages []GarageDTO `json:"garages"`
+}
+
+func NewOrganizationDTO(organization Organization, garages []Garage) OrganizationDTO {
+	return OrganizationDTO{
+		ID:      organization.ID,
+		Name:    organization.Name,
+		Garages: NewGarageDTOs(garages),
+	}
+}
+
+type LocationDTO struct {
+	GarageID int `json:"garageId"`
+}
+
+type GarageReportDTO struct {
+	GarageID     int      `json:"garageId"`
+	GarageName   string   `json:"garageName"`
+	Appointments int      `json:"appointments"`
+	Revenue      MoneyDTO `json:"revenue"`
+	Reviews      int      `json:"reviews"`
+	Rating       float64  `json:"rating"`
+}
+
+// OrganizationTotalDTO sums up the garages of an organization. Garages may use
+// different currencies, so the revenue is given once per currency.
+type OrganizationTotalDTO struct {
+	Appointments int        `json:"appointments"`
+	Revenue      []MoneyDTO `json:"revenue"`
+	Reviews      int        `json:"reviews"`
+	Rating       float64    `json:"rating"`
+}
+
+type OrganizationReportDTO struct {
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	Garages []GarageReportDTO    `json:"garages"`
+	Total   OrganizationTotalDTO `json:"total"`
+}
+
+func NewOrganizationReportDTO(from, to time.Time, reports []GarageReport) OrganizationReportDTO {
+	dto := OrganizationReportDTO{
+		From:    from,
+		To:      to,
+		Garages: make([]GarageReportDTO, len(reports)),
+		Total:   OrganizationTotalDTO{Revenue: []MoneyDTO{}},
+	}
+
+	var ratingSum float64
+	for i, report := range reports {
+		revenue := MoneyDTO{
+			Gross:    report.Revenue,
+			Net:      report.RevenueNet,
+			Tax:      report.Revenue - report.RevenueNet,
+			Currency: report.Currency,
+		}
+		dto.Garages[i] = GarageReportDTO{
+			GarageID:     report.GarageID,
+			GarageName:   report.GarageName,
+			Appointments: report.Appointments,
+			Revenue:      revenue,
+			Reviews:      report.Reviews,
+			Rating:       math.Round(report.Rating*10) / 10,
+		}
+		dto.Total.Appointments += report.Appointments
+		dto.Total.Revenue = addMoney(dto.Total.Revenue, revenue)
+		dto.Total.Reviews += report.Reviews
+		ratingSum += report.Rating * float64(report.Reviews)
+	}
+
+	if dto.Total.Reviews > 0 {
+		dto.Total.Rating = math.Round(ratingSum/float64(dto.Total.Reviews)*10) / 10
+	}
+
+	return dto
+}
+
+// addMoney adds the amount to the total of its currency.
+func addMoney(totals []MoneyDTO, money MoneyDTO) []MoneyDTO {
+	for i := range totals {
+		if totals[i].Currency == money.Currency {
+			totals[i].Gross += money.Gross
+			totals[i].Net += money.Net
+			totals[i].Tax += money.Tax
+			return totals
+		}
+	}
+	return append(totals, money)
+}
+
+type CatalogMakeDTO struct {
+	Name string `json:"name"`
+}
+
+type CatalogModelDTO struct {
+	Name     string    `json:"name"`
+	YearFrom *int      `json:"yearFrom,omitempty"`
+	YearTo   *int      `json:"yearTo,omitempty"`
+	BodyType *BodyType `json:"bodyType,omitempty"`
+}
+
+type MergeDTO struct {
+	TargetID int `json:"targetId"`
+}
+
+type InvoiceItemDTO struct {
+	Kind      InvoiceItemKind `json:"kind"`
+	Name      string          `json:"name"`
+	Quantity  int             `json:"quantity"`
+	UnitPrice MoneyDTO        `json:"unitPrice"`
+	Total     MoneyDTO        `json:"total"`
+}
+
+// CreateInvoiceDTO lists parts and labour charged on top of the booked
+// services, which are always added to the invoice.
+type CreateInvoiceDTO struct {
+	Items []InvoiceItemDTO `json:"items"`
+}
+
+type InvoiceDTO struct {
+	ID             int              `json:"id"`
+	Number         string           `json:"number"`
+	AppointmentID  *int             `json:"appointmentId,omitempty"`
+	AppointmentIDs []int            `json:"appointmentIds"`
+	CompanyID      *int             `json:"companyId,omitempty"`
+	Period         string           `json:"period,omitempty"`
+	IssuedAt       time.Time        `json:"issuedAt"`
+	SellerName     string           `json:"sellerName"`
+	SellerAddress  string           `json:"sellerAddress"`
+	BuyerName      string           `json:"buyerName"`
+	BuyerEmail     string           `json:"buyerEmail"`
+	Items          []InvoiceItemDTO `json:"items"`
+	Total          MoneyDTO         `json:"total"`
+}
+
+func NewInvoiceDTO(invoice Invoice) InvoiceDTO {
+	dto := InvoiceDTO{
+		ID:             invoice.ID,
+		Number:         invoice.DisplayNumber(),
+		AppointmentID:  invoice.AppointmentID,
+		AppointmentIDs: invoice.AppointmentIDs,
+		CompanyID:      invoice.CompanyID,
+		IssuedAt:       invoice.IssuedAt,
+		SellerName:     invoice.SellerName,
+		SellerAddress:  invoice.SellerAddress,
+		BuyerName:      invoice.BuyerName,
+		BuyerEmail:     invoice.BuyerEmail,
+		Items:          make([]InvoiceItemDTO, len(invoice.Items)),
+		Total:          MoneyDTO{Currency: invoice.Currency},
+	}
+	if invoice.Period != nil {
+		dto.Period = invoice.Period.Format("2006-01")
+	}
+
+	for i, item := range invoice.Items {
+		pricing := Pricing{Currency: invoice.Currency, TaxRate: item.TaxRate}
+		total := NewMoneyDTO(item.Total(), pricing)
+		dto.Items[i] = InvoiceItemDTO{
+			Kind:      item.Kind,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: NewMoneyDTO(item.UnitPrice, pricing),
+			Total:     total,
+		}
+		dto.Total.Gross += total.Gross
+		dto.Total.Net += total.Net
+		dto.Total.Tax += total.Tax
+	}
+
+	return dto
+}
+
+type PartDTO struct {
+	ID               int      `json:"id"`
+	SKU              string   `json:"sku"`
+	Name             string   `json:"name"`
+	Quantity         int      `json:"quantity"`
+	Cost             MoneyDTO `json:"cost"`
+	ReorderThreshold int      `json:"reorderThreshold"`
+	LowStock         bool     `json:"lowStock"`
+}
+
+func NewPartDTO(part Part, pricing Pricing) PartDTO {
+	return PartDTO{
+		ID:               part.ID,
+		SKU:              part.SKU,
+		Name:             part.Name,
+		Quantity:         part.Quantity,
+		Cost:             NewMoneyDTO(part.Cost, pricing),
+		ReorderThreshold: part.ReorderThreshold,
+		LowStock:         part.IsLowStock(),
+	}
+}
+
+func NewPartDTOs(parts []Part, pricing Pricing) []PartDTO {
+	partDTOs := make([]PartDTO, len(parts))
+	for i, part := range parts {
+		partDTOs[i] = NewPartDTO(part, pricing)
+	}
+	return partDTOs
+}
+
+type CreateAppointmentPartDTO struct {
+	PartID   int `json:"partId"`
+	Quantity int `json:"quantity"`
+}
+
+type AppointmentPartDTO struct {
+	ID       int      `json:"id"`
+	PartID   int      `json:"partId"`
+	SKU      string   `json:"sku"`
+	Name     string   `json:"name"`
+	Quantity int      `json:"quantity"`
+	Cost     MoneyDTO `json:"cost"`
+}
+
+func NewAppointmentPartDTO(appointmentPart AppointmentPart, part Part, pricing Pricing) AppointmentPartDTO {
+	return AppointmentPartDTO{
+		ID:       appointmentPart.ID,
+		PartID:   part.ID,
+		SKU:      part.SKU,
+		Name:     part.Name,
+		Quantity: appointmentPart.Quantity,
+		Cost:     NewMoneyDTO(appointmentPart.Cost*appointmentPart.Quantity, pricing),
+	}
+}
+
+type PartReportDTO struct {
+	Part      PartDTO  `json:"part"`
+	Used      int      `json:"used"`
+	UsedCost  MoneyDTO `json:"usedCost"`
+	StockCost MoneyDTO `json:"stockCost"`
+}
+
+type InventoryReportDTO struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Parts     []PartReportDTO `json:"parts"`
+	LowStock  int             `json:"lowStock"`
+	UsedCost  MoneyDTO        `json:"usedCost"`
+	StockCost MoneyDTO        `json:"stockCost"`
+}
+
+func NewInventoryReportDTO(from, to time.Time, parts []Part, usages []PartUsage, pricing Pricing) InventoryReportDTO {
+	used := make(map[int]PartUsage, len(usages))
+	for _, usage := range usages {
+		used[usage.PartID] = usage
+	}
+
+	var usedCost, stockCost int
+	dto := InventoryReportDTO{
+		From:  from,
+		To:    to,
+		Parts: make([]PartReportDTO, len(parts)),
+	}
+	for i, part := range parts {
+		usage := used[part.ID]
+		dto.Parts[i] = PartReportDTO{
+			Part:      NewPartDTO(part, pricing),
+			Used:      usage.Quantity,
+			UsedCost:  NewMoneyDTO(usage.Cost, pricing),
+			StockCost: NewMoneyDTO(part.Cost*part.Quantity, pricing),
+		}
+		if part.IsLowStock() {
+			dto.LowStock++
+		}
+		usedCost += usage.Cost
+		stockCost += part.Cost * part.Quantity
+	}
+	dto.UsedCost = NewMoneyDTO(usedCost, pricing)
+	dto.StockCost = NewMoneyDTO(stockCost, pricing)
+
+	return dto
+}
+
+type UpdateWorkOrderDTO struct {
+	Mileage  *int   `json:"mileage,omitempty"`
+	Notes    string `json:"notes"`
+	Findings string `json:"findings"`
+}
+
+type WorkOrderPhotoDTO struct {
+	ID        int       `json:"id"`
+	Photo     string    `json:"photo"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WorkOrderDTO struct {
+	AppointmentID int                 `json:"appointmentId"`
+	Mileage       *int                `json:"mileage,omitempty"`
+	Notes         *string             `json:"notes,omitempty"`
+	Findings      string              `json:"findings"`
+	UpdatedAt     *time.Time          `json:"updatedAt,omitempty"`
+	Photos        []WorkOrderPhotoDTO `json:"photos"`
+}
+
+func NewWorkOrderDTO(appointment Appointment, workOrder WorkOrder) WorkOrderDTO {
+	dto := WorkOrderDTO{
+		AppointmentID: appointment.ID,
+		Mileage:       appointment.Mileage,
+		Notes:         appointment.Notes,
+		Findings:      workOrder.Findings,
+		Photos:        make([]WorkOrderPhotoDTO, len(workOrder.Photos)),
+	}
+	if workOrder.ID != 0 {
+		dto.UpdatedAt = &workOrder.UpdatedAt
+	}
+	for i, photo := range workOrder.Photos {
+		dto.Photos[i] = WorkOrderPhotoDTO{
+			ID:        photo.ID,
+			Photo:     base64.StdEncoding.EncodeToString(photo.Photo),
+			CreatedAt: photo.CreatedAt,
+		}
+	}
+
+	return dto
+}
+
+type CreateAdditionalItemDTO struct {
+	Description string   `json:"description"`
+	Price       MoneyDTO `json:"price"`
+	Time        int      `json:"time"`
+}
+
+type AdditionalItemDTO struct {
+	ID          int                  `json:"id"`
+	Description string               `json:"description"`
+	Price       MoneyDTO             `json:"price"`
+	Time        int                  `json:"time"`
+	Status      AdditionalItemStatus `json:"status"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	DecidedAt   *time.Time           `json:"decidedAt,omitempty"`
+}
+
+func NewAdditionalItemDTO(item AdditionalItem, pricing Pricing) AdditionalItemDTO {
+	return AdditionalItemDTO{
+		ID:          item.ID,
+		Description: item.Description,
+		Price:       NewMoneyDTO(item.Price, pricing),
+		Time:        item.Time,
+		Status:      item.Status,
+		CreatedAt:   item.CreatedAt,
+		DecidedAt:   item.DecidedAt,
+	}
+}
+
+func NewAdditionalItemDTOs(items []AdditionalItem, pricing Pricing) []AdditionalItemDTO {
+	itemDTOs := make([]AdditionalItemDTO, len(items))
+	for i, item := range items {
+		itemDTOs[i] = NewAdditionalItemDTO(item, pricing)
+	}
+	return itemDTOs
+}
+
+type CreateMessageDTO struct {
+	Content string `json:"content"`
+}
+
+type MessageDTO struct {
+	ID         int        `json:"id"`
+	SenderRole Role       `json:"senderRole"`
+	EmployeeID *int       `json:"employeeId,omitempty"`
+	Content    string     `json:"content"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReadAt     *time.Time `json:"readAt,omitempty"`
+}
+
+func NewMessageDTO(message Message) MessageDTO {
+	return MessageDTO{
+		ID:         message.ID,
+		SenderRole: message.SenderRole,
+		EmployeeID: message.EmployeeID,
+		Content:    message.Content,
+		CreatedAt:  message.CreatedAt,
+		ReadAt:     message.ReadAt,
+	}
+}
+
+func NewMessageDTOs(messages []Message) []MessageDTO {
+	messageDTOs := make([]MessageDTO, len(messages))
+	for i, message := range messages {
+		messageDTOs[i] = NewMessageDTO(message)
+	}
+	return messageDTOs
+}
+
+type CreateWebhookDTO struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	Active     bool     `json:"active"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+type WebhookDTO struct {
+	ID         int       `json:"id"`
+	URL        string    `json:"url"`
+	Active     bool      `json:"active"`
+	EventTypes []string  `json:"eventTypes"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewWebhookDTO(webhook Webhook) WebhookDTO {
+	eventTypes := webhook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return WebhookDTO{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		Active:     webhook.Active,
+		EventTypes: eventTypes,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func NewWebhookDTOs(webhooks []Webhook) []WebhookDTO {
+	webhookDTOs := make([]WebhookDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		webhookDTOs[i] = NewWebhookDTO(webhook)
+	}
+	return webhookDTOs
+}
+
+type WebhookDeliveryDTO struct {
+	ID         int       `json:"id"`
+	EventType  string    `json:"eventType"`
+	Payload    string    `json:"payload"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"statusCode"`
+	Error      *string   `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewWebhookDeliveryDTOs(deliveries []WebhookDelivery) []WebhookDeliveryDTO {
+	deliveryDTOs := make([]WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryDTOs[i] = WebhookDeliveryDTO{
+			ID:         delivery.ID,
+			EventType:  delivery.EventType,
+			Payload:    delivery.Payload,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			CreatedAt:  delivery.CreatedAt,
+		}
+	}
+	return deliveryDTOs
+}
+
+type CalendarTokenDTO struct {
+	Token     string    `json:"token"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewCalendarTokenDTO(token CalendarToken) CalendarTokenDTO {
+	return CalendarTokenDTO{
+		Token:     token.ID,
+		Path:      "/api/calendars/" + token.ID,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+type CreateWaitlistEntryDTO struct {
+	ServiceID  int    `json:"serviceId"`
+	EmployeeID int    `json:"employeeId,omitempty"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+type WaitlistEntryDTO struct {
+	ID         int            `json:"id"`
+	GarageID   int            `json:"garageId"`
+	ServiceID  int            `json:"serviceId"`
+	EmployeeID *int           `json:"employeeId,omitempty"`
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	Status     WaitlistStatus `json:"status"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+func NewWaitlistEntryDTO(entry WaitlistEntry) WaitlistEntryDTO {
+	return WaitlistEntryDTO{
+		ID:         entry.ID,
+		GarageID:   entry.GarageID,
+		ServiceID:  entry.ServiceID,
+		EmployeeID: entry.EmployeeID,
+		From:       entry.FromTime.Format("2006-01-02"),
+		To:         entry.ToTime.AddDate(0, 0, -1).Format("2006-01-02"),
+		Status:     entry.Status,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func NewWaitlistEntryDTOs(entries []WaitlistEntry) []WaitlistEntryDTO {
+	entryDTOs := make([]WaitlistEntryDTO, len(entries))
+	for i, entry := range entries {
+		entryDTOs[i] = NewWaitlistEntryDTO(entry)
+	}
+	return entryDTOs
+}
+
+type SlotHoldDTO struct {
+	ID         int       `json:"id"`
+	EmployeeID int       `json:"employeeId"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func NewSlotHoldDTO(hold SlotHold) SlotHoldDTO {
+	return SlotHoldDTO{
+		ID:         hold.ID,
+		EmployeeID: hold.EmployeeID,
+		StartTime:  hold.StartTime,
+		EndTime:    hold.EndTime,
+		ExpiresAt:  hold.ExpiresAt,
+	}
+}
+
+type AppointmentSeriesDTO struct {
+	ID           int                    `json:"id"`
+	Frequency    RecurrenceFrequency    `json:"frequency"`
+	Interval     int                    `json:"interval"`
+	Count        int                    `json:"count"`
+	Appointments []SeriesAppointmentDTO `json:"appointments"`
+	Conflicts    []TimeSlot             `json:"conflicts"`
+}
+
+type SeriesAppointmentDTO struct {
+	ID        int       `json:"id"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// NewAppointmentSeriesDTO lists the appointments of the series together with
+// the slots that could not be booked or moved.
+func NewAppointmentSeriesDTO(series AppointmentSeries, appointments []Appointment, conflicts []TimeSlot) AppointmentSeriesDTO {
+	appointmentDTOs := make([]SeriesAppointmentDTO, len(appointments))
+	for i, appointment := range appointments {
+		appointmentDTOs[i] = SeriesAppointmentDTO{
+			ID:        appointment.ID,
+			StartTime: appointment.StartTime,
+			EndTime:   appointment.EndTime,
+		}
+	}
+	if conflicts == nil {
+		conflicts = []TimeSlot{}
+	}
+	return AppointmentSeriesDTO{
+		ID:           series.ID,
+		Frequency:    series.Frequency,
+		Interval:     series.Interval,
+		Count:        series.Count,
+		Appointments: appointmentDTOs,
+		Conflicts:    conflicts,
+	}
+}
+
+// CreateStaffAppointmentDTO is a booking entered by the staff, for example
+// one taken by phone, either for an existing customer or for a guest.
+type CreateStaffAppointmentDTO struct {
+	Appointment   CreateAppointmentDTO `json:"appointment"`
+	CustomerEmail string               `json:"customerEmail"`
+	Guest         *GuestDTO            `json:"guest,omitempty"`
+}
+
+type GuestDTO struct {
+	Name        string `json:"name"`
+	Surname     string `json:"surname"`
+	PhoneNumber string `json:"phoneNumber"`
+	Email       string `json:"email"`
+	// PlateNumber registers the booked car as a vehicle of the guest.
+	PlateNumber string `json:"plateNumber"`
+	// Invite emails the guest an invitation to claim the booking into
+	// a full account.
+	Invite bool `json:"invite"`
+}
+
+type RescheduleAppointmentDTO struct {
+	StartTime time.Time `json:"startTime"`
+}
+
+type CreateCompanyDTO struct {
+	Name         string `json:"name"`
+	TaxID        string `json:"taxId"`
+	BillingEmail string `json:"billingEmail"`
+}
+
+type CompanyDTO struct {
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	TaxID        string             `json:"taxId"`
+	BillingEmail string             `json:"billingEmail"`
+	Role         CompanyRole        `json:"role"`
+	Members      []CompanyMemberDTO `json:"members"`
+}
+
+// NewCompanyDTO describes the company to one of its members, role being the
+// role of that member.
+func NewCompanyDTO(company Company, role CompanyRole, members []CompanyMemberDTO) CompanyDTO {
+	return CompanyDTO{
+		ID:           company.ID,
+		Name:         company.Name,
+		TaxID:        company.TaxID,
+		BillingEmail: company.BillingEmail,
+		Role:         role,
+		Members:      members,
+	}
+}
+
+type CreateCompanyMemberDTO struct {
+	Email string      `json:"email"`
+	Role  CompanyRole `json:"role"`
+}
+
+type UpdateCompanyMemberDTO struct {
+	Role CompanyRole `json:"role"`
+}
+
+type CompanyMemberDTO struct {
+	CustomerID int         `json:"customerId"`
+	Email      string      `json:"email"`
+	Name       string      `json:"name"`
+	Surname    string      `json:"surname"`
+	Role       CompanyRole `json:"role"`
+}
+
+func NewCompanyMemberDTO(member CompanyMember, customer Customer) CompanyMemberDTO {
+	return CompanyMemberDTO{
+		CustomerID: member.CustomerID,
+		Email:      customer.Email,
+		Name:       customer.Name,
+		Surname:    customer.Surname,
+		Role:       member.Role,
+	}
+}
+
+type CreateCompanyInvoiceDTO struct {
+	CompanyID int `json:"companyId"`
+	// Month is given as 2006-01.
+	Month string `json:"month"`
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoneyDTO(t *testing.T) {
+	money := NewMoneyDTO(14999, Pricing{Currency: "PLN", TaxRate: 2300})
+	assert.Equal(t, 14999, money.Gross)
+	assert.Equal(t, 12194, money.Net)
+	assert.Equal(t, 2805, money.Tax)
+	assert.Equal(t, "PLN", money.Currency)
+
+	money = NewMoneyDTO(1000, Pricing{Currency: "EUR", TaxRate: 0})
+	assert.Equal(t, 1000, money.Net)
+	assert.Equal(t, 0, money.Tax)
+}
+
+func TestMoneyDTOUnmarshalJSON(t *testing.T) {
+	var dto ServiceDTO
+	err := json.Unmarshal([]byte(`{"name": "Oil change", "price": 14999, "maxPrice": {"gross": 19999}}`), &dto)
+	require.NoError(t, err)
+	assert.Equal(t, 14999, dto.Price.Gross)
+	require.NotNil(t, dto.MaxPrice)
+	assert.Equal(t, 19999, dto.MaxPrice.Gross)
+
+	err = json.Unmarshal([]byte(`{"price": "149.99"}`), &dto)
+	assert.Error(t, err)
+}
+
+func TestNewTotalPriceDTO(t *testing.T) {
+	services := []AppointmentService{
+		{Price: 12300, Currency: "PLN", TaxRate: 2300},
+		{Price: 999, Currency: "PLN", TaxRate: 2300},
+	}
+
+	total := NewTotalPriceDTO(services)
+	assert.Equal(t, 13299, total.Gross)
+	assert.Equal(t, 10000+812, total.Net)
+	assert.Equal(t, total.Gross-total.Net, total.Tax)
+	assert.Equal(t, "PLN", total.Currency)
+}
+
+func TestNewOrganizationReportDTO(t *testing.T) {
+	reports := []GarageReport{
+		{GarageID: 1, Currency: "PLN", Appointments: 2, Revenue: 12300, RevenueNet: 10000},
+		{GarageID: 2, Currency: "EUR", Appointments: 1, Revenue: 500, RevenueNet: 500},
+		{GarageID: 3, Currency: "PLN", Appointments: 1, Revenue: 1230, RevenueNet: 1000},
+	}
+
+	report := NewOrganizationReportDTO(time.Time{}, time.Time{}, reports)
+	assert.Equal(t, MoneyDTO{Gross: 12300, Net: 10000, Tax: 2300, Currency: "PLN"}, report.Garages[0].Revenue)
+	assert.Equal(t, 4, report.Total.Appointments)
+	assert.Equal(t, []MoneyDTO{
+		{Gross: 13530, Net: 11000, Tax: 2530, Currency: "PLN"},
+		{Gross: 500, Net: 500, Tax: 0, Currency: "EUR"},
+	}, report.Total.Revenue)
+}
+
+func TestFormatMoney(t *testing.T) {
+	assert.Equal(t, "149,99 PLN", FormatMoney(14999, "PLN"))
+	assert.Equal(t, "0,05 EUR", FormatMoney(5, "EUR"))
+	assert.Equal(t, "-1,50 PLN", FormatMoney(-150, "PLN"))
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	channel        = "garage_events"
+	subscriberSize = 16
+	minReconnect   = 10 * time.Second
+	maxReconnect   = time.Minute
+)
+
+type Type string
+
+const (
+	AppointmentCreated   Type = "appointment.created"
+	AppointmentCancelled Type = "appointment.cancelled"
+	AppointmentUpdated   Type = "appointment.updated"
+	MessageCreated       Type = "message.created"
+	ReviewCreated        Type = "review.created"
+)
+
+var Types = []Type{
+	AppointmentCreated,
+	AppointmentCancelled,
+	AppointmentUpdated,
+	MessageCreated,
+	ReviewCreated,
+}
+
+// Event describes a change of an appointment. It carries only identifiers,
+// clients fetch the details through the API.
+type Event struct {
+	Type          Type `json:"type"`
+	AppointmentID int  `json:"appointmentId"`
+	GarageID      int  `json:"garageId"`
+	EmployeeID    int  `json:"employeeId"`
+	CustomerID    int  `json:"customerId"`
+}
+
+// Bus delivers events to the subscribers of this instance. Once Listen is
+// called, events are published with Postgres NOTIFY and delivered when the
+// notification comes back, so every instance sees events of all of them.
+type Bus struct {
+	log         *slog.Logger
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+	db          *sql.DB
+}
+
+func New(log *slog.Logger) *Bus {
+	return &Bus{
+		log:         log,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Listen starts receiving events published by all instances connected to the
+// same database.
+func (b *Bus) Listen(connectionURL string) error {
+	db, err := sql.Open("postgres", connectionURL)
+	if err != nil {
+		return err
+	}
+	if err = db.Ping(); err != nil {
+		return err
+	}
+
+	listener := pq.NewListener(connectionURL, minReconnect, maxReconnect, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			b.log.Error("event listener failure", "error", err)
+		}
+	})
+	if err = listener.Listen(channel); err != nil {
+		return err
+	}
+
+	go func() {
+		for notification := range listener.Notify {
+			// A nil notification is sent after the connection is re-established.
+			if notification == nil {
+				continue
+			}
+
+			var event Event
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				b.log.Error("invalid event", "error", err)
+				continue
+			}
+			b.dispatch(event)
+		}
+	}()
+
+	b.db = db
+	return nil
+}
+
+func (b *Bus) Publish(event Event) {
+	if b.db == nil {
+		b.dispatch(event)
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		b.log.Error("unable to encode event", "error", err)
+		return
+	}
+
+	if _, err = b.db.Exec("SELECT pg_notify($1, $2)", channel, string(payload)); err != nil {
+		b.log.Error("unable to publish event", "error", err)
+		b.dispatch(event)
+	}
+}
+
+// Subscribe returns a channel receiving all events and a function closing it.
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	events := make(chan Event, subscriberSize)
+
+	b.mu.Lock()
+	b.subscribers[events] = struct{}{}
+	b.mu.Unlock()
+
+	return events, func() {
+		b.mu.Lock()
+		if _, ok := b.subscribers[events]; ok {
+			delete(b.subscribers, events)
+			close(events)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// dispatch never blocks, events are dropped for subscribers that do not keep
+// up with them.
+func (b *Bus) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			b.log.Warn("event dropped for slow subscriber", "type", event.Type)
+		}
+	}
+}
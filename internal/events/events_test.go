@@ -0,0 +1,50 @@
+package events
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	t.Run("should deliver events to all subscribers", func(t *testing.T) {
+		bus := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		first, unsubscribeFirst := bus.Subscribe()
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe()
+		defer unsubscribeSecond()
+
+		event := Event{Type: AppointmentCreated, AppointmentID: 1, GarageID: 2, EmployeeID: 3, CustomerID: 4}
+		bus.Publish(event)
+
+		assert.Equal(t, event, <-first)
+		assert.Equal(t, event, <-second)
+	})
+
+	t.Run("should close the channel on unsubscribe", func(t *testing.T) {
+		bus := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		events, unsubscribe := bus.Subscribe()
+		unsubscribe()
+		unsubscribe()
+
+		bus.Publish(Event{Type: MessageCreated})
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("should drop events for slow subscribers", func(t *testing.T) {
+		bus := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		events, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+
+		for i := 0; i < subscriberSize+5; i++ {
+			bus.Publish(Event{Type: AppointmentUpdated, AppointmentID: i})
+		}
+
+		require.Len(t, events, subscriberSize)
+		assert.Equal(t, 0, (<-events).AppointmentID)
+	})
+}
@@ -0,0 +1,101 @@
+package ical
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+	productID   = "-//garage//appointments//PL"
+	timeFormat  = "20060102T150405Z"
+	// localTimeFormat writes floating times, shown at the same wall-clock
+	// time in every time zone.
+	localTimeFormat = "20060102T150405"
+	lineLength      = 75
+)
+
+// Event is a single VEVENT. UID must stay the same for the same appointment,
+// so calendar clients update the event instead of duplicating it. Start and
+// End are wall-clock times of the garage, the way appointments are stored,
+// whatever their location.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+}
+
+// Encode renders the events as an iCalendar (RFC 5545) document. The stamp is
+// written as DTSTAMP of every event.
+func Encode(name string, events []Event, stamp time.Time) []byte {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+productID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escape(name))
+	}
+
+	for _, event := range events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escape(event.UID))
+		writeLine(&b, "DTSTAMP:"+formatTime(stamp))
+		writeLine(&b, "DTSTART:"+formatLocalTime(event.Start))
+		writeLine(&b, "DTEND:"+formatLocalTime(event.End))
+		writeLine(&b, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escape(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&b, "LOCATION:"+escape(event.Location))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+func formatLocalTime(t time.Time) string {
+	return t.Format(localTimeFormat)
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeLine folds lines longer than 75 octets, never splitting a multi-byte
+// character, and terminates them with CRLF.
+func writeLine(b *strings.Builder, line string) {
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = lineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
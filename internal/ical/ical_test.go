@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	stamp := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("should encode events", func(t *testing.T) {
+		calendar := string(Encode("Garage", []Event{{
+			UID:         "appointment-1@garage",
+			Start:       time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC),
+			End:         time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC),
+			Summary:     "Oil change",
+			Description: "Toyota Corolla",
+			Location:    "Main 1, 00-001 Warsaw",
+		}}, stamp))
+
+		assert.Equal(t, strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:" + productID,
+			"CALSCALE:GREGORIAN",
+			"METHOD:PUBLISH",
+			"X-WR-CALNAME:Garage",
+			"BEGIN:VEVENT",
+			"UID:appointment-1@garage",
+			"DTSTAMP:20261001T080000Z",
+			"DTSTART:20261002T100000",
+			"DTEND:20261002T120000",
+			"SUMMARY:Oil change",
+			"DESCRIPTION:Toyota Corolla",
+			`LOCATION:Main 1\, 00-001 Warsaw`,
+			"END:VEVENT",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), calendar)
+	})
+
+	t.Run("should write appointments at their wall-clock time", func(t *testing.T) {
+		// Appointments are stored as UTC values holding the wall-clock time
+		// of the garage, so a 9:00 winter appointment must stay at 9:00.
+		calendar := string(Encode("", []Event{{
+			UID:   "appointment-4@garage",
+			Start: time.Date(2026, 12, 14, 9, 0, 0, 0, time.UTC),
+			End:   time.Date(2026, 12, 14, 11, 0, 0, 0, time.UTC),
+		}}, stamp))
+
+		assert.Contains(t, calendar, "DTSTART:20261214T090000\r\n")
+		assert.Contains(t, calendar, "DTEND:20261214T110000\r\n")
+	})
+
+	t.Run("should escape text values", func(t *testing.T) {
+		calendar := string(Encode("", []Event{{
+			UID:     "appointment-2@garage",
+			Summary: "Brakes; pads, discs\\rotors\nfront",
+		}}, stamp))
+
+		assert.Contains(t, calendar, `SUMMARY:Brakes\; pads\, discs\\rotors\nfront`+"\r\n")
+		assert.NotContains(t, calendar, "X-WR-CALNAME")
+		assert.NotContains(t, calendar, "DESCRIPTION")
+	})
+
+	t.Run("should fold long lines without splitting characters", func(t *testing.T) {
+		summary := strings.Repeat("ż", 100)
+		calendar := string(Encode("", []Event{{UID: "appointment-3@garage", Summary: summary}}, stamp))
+
+		for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), lineLength)
+			assert.True(t, utf8.ValidString(line))
+		}
+		unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+		assert.Contains(t, unfolded, "SUMMARY:"+summary+"\r\n")
+	})
+}
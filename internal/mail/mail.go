@@ -2,6 +2,7 @@ package mail
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"net/smtp"
@@ -10,8 +11,17 @@ import (
 )
 
 const (
-	headers             = "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";"
-	NewEmployeeTemplate = "newEmployee.html"
+	headers                         = "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";"
+	NewEmployeeTemplate             = "newEmployee.html"
+	InvoiceTemplate                 = "invoice.html"
+	LowStockTemplate                = "lowStock.html"
+	AdditionalItemTemplate          = "additionalItem.html"
+	AdditionalItemDecisionTemplate  = "additionalItemDecision.html"
+	NewMessageTemplate              = "newMessage.html"
+	AppointmentConfirmationTemplate = "appointmentConfirmation.html"
+	WaitlistOfferTemplate           = "waitlistOffer.html"
+	CustomerInvitationTemplate      = "customerInvitation.html"
+	boundary                        = "garage-mail-boundary"
 )
 
 type NewEmployee struct {
@@ -19,6 +29,65 @@ type NewEmployee struct {
 	Code       string
 }
 
+type Invoice struct {
+	GarageName string
+	Number     string
+	Total      string
+}
+
+type LowStock struct {
+	GarageName string
+	PartName   string
+	SKU        string
+	Quantity   int
+}
+
+type AdditionalItem struct {
+	GarageName  string
+	Description string
+	Price       string
+	Time        int
+}
+
+type AdditionalItemDecision struct {
+	Description string
+	Approved    bool
+	Extended    bool
+	Conflict    bool
+	EndTime     string
+}
+
+type NewMessage struct {
+	GarageName string
+	StartTime  string
+	Content    string
+}
+
+type AppointmentConfirmation struct {
+	GarageName string
+	Services   string
+	StartTime  string
+	Address    string
+}
+
+type WaitlistOffer struct {
+	GarageName string
+	Service    string
+	StartTime  string
+	ExpiresAt  string
+}
+
+type CustomerInvitation struct {
+	GarageName string
+	Code       string
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
 type Config struct {
 	Username string `env:"USERNAME"`
 	Password string `env:"PASSWORD"`
@@ -36,7 +105,7 @@ func New(cfg Config) *Mail {
 	}
 }
 
-func (m *Mail) Send(to string, subject string, templateName string, templateData interface{}) error {
+func (m *Mail) Send(to string, subject string, templateName string, templateData interface{}, attachments ...Attachment) error {
 	_, currentPath, _, _ := runtime.Caller(0)
 	templatePath := fmt.Sprintf("%s/resources/templates/%s", path.Join(path.Dir(currentPath), "../../../../"), templateName)
 
@@ -53,6 +122,9 @@ func (m *Mail) Send(to string, subject string, templateName string, templateData
 	auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.SmtpHost)
 
 	msg := "Subject: " + subject + "\n" + headers + "\n\n" + body.String()
+	if len(attachments) > 0 {
+		msg = "Subject: " + subject + "\n" + multipartMessage(body.String(), attachments)
+	}
 
 	if err = smtp.SendMail(m.cfg.SmtpHost+":"+m.cfg.SmtpPort, auth, m.cfg.Username, []string{to}, []byte(msg)); err != nil {
 		return err
@@ -60,3 +132,28 @@ func (m *Mail) Send(to string, subject string, templateName string, templateData
 
 	return nil
 }
+
+// multipartMessage wraps the HTML body and the attachments in a
+// multipart/mixed message.
+func multipartMessage(body string, attachments []Attachment) string {
+	var msg bytes.Buffer
+	msg.WriteString("MIME-version: 1.0;\nContent-Type: multipart/mixed; boundary=\"" + boundary + "\"\n\n")
+	msg.WriteString("--" + boundary + "\n" + headers + "\n\n" + body + "\n")
+
+	for _, attachment := range attachments {
+		msg.WriteString("--" + boundary + "\n")
+		msg.WriteString("Content-Type: " + attachment.ContentType + "; name=\"" + attachment.Name + "\"\n")
+		msg.WriteString("Content-Transfer-Encoding: base64\n")
+		msg.WriteString("Content-Disposition: attachment; filename=\"" + attachment.Name + "\"\n\n")
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			msg.WriteString(encoded[:76] + "\n")
+			encoded = encoded[76:]
+		}
+		msg.WriteString(encoded + "\n")
+	}
+
+	msg.WriteString("--" + boundary + "--\n")
+	return msg.String()
+}
@@ -1,6 +1,11 @@
 package internal
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Role string
 
@@ -8,8 +13,43 @@ const (
 	OwnerRole    Role = "OWNER"
 	MechanicRole Role = "MECHANIC"
 	CustomerRole Role = "CUSTOMER"
+	AdminRole    Role = "ADMIN"
+)
+
+type Permission string
+
+const (
+	AppointmentsManagePermission Permission = "appointments:manage"
+	ServicesWritePermission      Permission = "services:write"
+	StaffManagePermission        Permission = "staff:manage"
+	GarageWritePermission        Permission = "garage:write"
+	InvoicesWritePermission      Permission = "invoices:write"
+	InventoryManagePermission    Permission = "inventory:manage"
+)
+
+type ContactMethod string
+
+const (
+	EmailContact ContactMethod = "EMAIL"
+	PhoneContact ContactMethod = "PHONE"
+	SMSContact   ContactMethod = "SMS"
 )
 
+var ContactMethods = []ContactMethod{
+	EmailContact,
+	PhoneContact,
+	SMSContact,
+}
+
+var Permissions = []Permission{
+	AppointmentsManagePermission,
+	ServicesWritePermission,
+	StaffManagePermission,
+	GarageWritePermission,
+	InvoicesWritePermission,
+	InventoryManagePermission,
+}
+
 type Employee struct {
 	ID             int
 	Name           string
@@ -19,6 +59,8 @@ type Employee struct {
 	Role           Role
 	ProfilePicture []byte
 	GarageID       *int
+	GarageRoleID   *int
+	ActiveGarageID *int
 	Confirmed      bool
 	IsDeleted      bool
 }
@@ -32,63 +74,286 @@ func NewEmployee(dto CreateEmployeeDTO, role Role) Employee {
 	}
 }
 
+// Garage is a workshop. OwnerID is the employee who created it and is not
+// updated when ownership changes, the current owners are kept in garage_owners.
 type Garage struct {
+	ID             int
+	Name           string
+	City           string
+	Street         string
+	Number         string
+	PostalCode     string
+	PhoneNumber    string
+	Latitude       float64
+	Longitude      float64
+	OwnerID        int
+	OrganizationID *int
+	Rating         float64
+	Distance       float64
+	Logo           []byte
+	Currency       string
+	TaxRate        int
+	WaitlistMode   WaitlistMode
+}
+
+func (g Garage) Pricing() Pricing {
+	return Pricing{Currency: g.Currency, TaxRate: g.TaxRate}
+}
+
+func NewGarage(dto CreateGarageDTO, ownerID int, organizationID *int) Garage {
+	garage := Garage{
+		Name:           dto.Name,
+		City:           dto.City,
+		Street:         dto.Street,
+		Number:         dto.Number,
+		PostalCode:     dto.PostalCode,
+		PhoneNumber:    dto.PhoneNumber,
+		OwnerID:        ownerID,
+		OrganizationID: organizationID,
+		Latitude:       dto.Latitude,
+		Longitude:      dto.Longitude,
+		Currency:       DefaultCurrency,
+		TaxRate:        DefaultTaxRate,
+		WaitlistMode:   FirstComeWaitlist,
+	}
+	if dto.Currency != "" {
+		garage.Currency = dto.Currency
+	}
+	if dto.TaxRate != nil {
+		garage.TaxRate = *dto.TaxRate
+	}
+	if dto.WaitlistMode != "" {
+		garage.WaitlistMode = dto.WaitlistMode
+	}
+	return garage
+}
+
+const (
+	DefaultCurrency = "PLN"
+	DefaultTaxRate  = 2300
+)
+
+// Pricing describes how a garage charges for its services. Prices are stored
+// as gross amounts in minor units of Currency, e.g. 14999 for 149.99 PLN, and
+// TaxRate is given in basis points, e.g. 2300 for 23% VAT.
+type Pricing struct {
+	Currency string
+	TaxRate  int
+}
+
+// Net returns the amount before tax of a gross price, rounded to the nearest
+// minor unit.
+func (p Pricing) Net(gross int) int {
+	divisor := 10000 + p.TaxRate
+	return (gross*10000 + divisor/2) / divisor
+}
+
+// FormatAmount formats an amount in minor units with two decimal places.
+func FormatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d,%02d", sign, amount/100, amount%100)
+}
+
+func FormatMoney(amount int, currency string) string {
+	return FormatAmount(amount) + " " + currency
+}
+
+type Organization struct {
+	ID   int
+	Name string
+}
+
+// GarageReport sums up a garage over a period. Revenue is the gross amount of
+// the booked services in the garage currency, RevenueNet the amount before tax.
+type GarageReport struct {
+	GarageID     int
+	GarageName   string
+	Currency     string
+	Appointments int
+	Revenue      int
+	RevenueNet   int
+	Reviews      int
+	Rating       float64
+}
+
+type OwnershipTransfer struct {
+	ID             int
+	GarageID       int
+	FromEmployeeID int
+	ToEmployeeID   int
+	FromConfirmed  bool
+	ToConfirmed    bool
+	Completed      bool
+	CreatedAt      time.Time
+}
+
+type GarageRole struct {
 	ID          int
 	Name        string
-	City        string
-	Street      string
-	Number      string
-	PostalCode  string
-	PhoneNumber string
-	Latitude    float64
-	Longitude   float64
-	OwnerID     int
-	Rating      float64
-	Distance    float64
-	Logo        []byte
-}
-
-func NewGarage(dto CreateGarageDTO, ownerID int) Garage {
-	return Garage{
+	GarageID    int
+	Permissions []Permission
+}
+
+func NewGarageRole(dto GarageRoleDTO, garageID int) GarageRole {
+	return GarageRole{
 		Name:        dto.Name,
-		City:        dto.City,
-		Street:      dto.Street,
-		Number:      dto.Number,
-		PostalCode:  dto.PostalCode,
-		PhoneNumber: dto.PhoneNumber,
-		OwnerID:     ownerID,
-		Latitude:    dto.Latitude,
-		Longitude:   dto.Longitude,
+		GarageID:    garageID,
+		Permissions: dto.Permissions,
+	}
+}
+
+func (r GarageRole) HasPermission(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
 	}
+	return false
 }
 
 type Service struct {
-	ID        int
-	Name      string
-	Time      int
-	Price     int
-	IsDeleted bool
-	GarageID  int
+	ID          int
+	Name        string
+	Description string
+	Time        int
+	// Price is the starting price of the service. When MaxPrice is set the
+	// final price depends on the vehicle and lies within the range.
+	Price      int
+	MaxPrice   *int
+	CategoryID *int
+	IsDeleted  bool
+	GarageID   int
 }
 
 func NewService(dto ServiceDTO, garageID int) Service {
 	return Service{
-		Name:     dto.Name,
-		Time:     dto.Time,
-		Price:    dto.Price,
-		GarageID: garageID,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Time:        dto.Time,
+		Price:       dto.Price.Gross,
+		MaxPrice:    dto.MaxPrice.GrossPtr(),
+		CategoryID:  dto.CategoryID,
+		GarageID:    garageID,
 	}
 }
 
+// ServiceCategory groups services. Categories without a garage are shared by
+// every garage, the others belong to the garage that created them.
+type ServiceCategory struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	GarageID *int   `json:"garageId,omitempty"`
+}
+
+type ServiceRuleType string
+
+const (
+	AllowRule    ServiceRuleType = "ALLOW"
+	ExcludeRule  ServiceRuleType = "EXCLUDE"
+	OverrideRule ServiceRuleType = "OVERRIDE"
+)
+
+var ServiceRuleTypes = []ServiceRuleType{
+	AllowRule,
+	ExcludeRule,
+	OverrideRule,
+}
+
+// ServiceRule narrows a service down to a make, or to a single model when
+// ModelID is set. Allow rules restrict the service to the listed vehicles,
+// exclude rules reject them and override rules only change the price or time.
+type ServiceRule struct {
+	ID        int
+	ServiceID int
+	Type      ServiceRuleType
+	MakeID    int
+	ModelID   *int
+	Price     *int
+	Time      *int
+}
+
+func NewServiceRule(dto ServiceRuleDTO, serviceID int) ServiceRule {
+	return ServiceRule{
+		ServiceID: serviceID,
+		Type:      dto.Type,
+		MakeID:    dto.MakeID,
+		ModelID:   dto.ModelID,
+		Price:     dto.Price,
+		Time:      dto.Time,
+	}
+}
+
+func (r ServiceRule) Matches(model Model) bool {
+	if r.ModelID != nil {
+		return *r.ModelID == model.ID
+	}
+	return r.MakeID == model.MakeID
+}
+
+// ForModel applies the rules of the service to the given model. It returns
+// false when the service is not available for the model. Price and time are
+// taken from the most specific matching rule, a model rule winning over a make
+// rule.
+func (s Service) ForModel(rules []ServiceRule, model Model) (Service, bool) {
+	allowRules := 0
+	allowed := false
+	var priceRule, timeRule *ServiceRule
+	for i, rule := range rules {
+		if rule.Type == AllowRule {
+			allowRules++
+		}
+		if !rule.Matches(model) {
+			continue
+		}
+		switch rule.Type {
+		case ExcludeRule:
+			return Service{}, false
+		case AllowRule:
+			allowed = true
+		}
+		if rule.Price != nil && (priceRule == nil || priceRule.ModelID == nil) {
+			priceRule = &rules[i]
+		}
+		if rule.Time != nil && (timeRule == nil || timeRule.ModelID == nil) {
+			timeRule = &rules[i]
+		}
+	}
+
+	if allowRules > 0 && !allowed {
+		return Service{}, false
+	}
+
+	if priceRule != nil {
+		s.Price = *priceRule.Price
+		s.MaxPrice = nil
+	}
+	if timeRule != nil {
+		s.Time = *timeRule.Time
+	}
+
+	return s, true
+}
+
 type ConfirmationCode struct {
 	ID         string
 	EmployeeID int
 }
 
 type Customer struct {
-	ID       int
-	Email    string
-	Password string
+	ID               int
+	Email            string
+	Password         string
+	Name             string
+	Surname          string
+	PhoneNumber      string
+	PreferredContact ContactMethod
+	// Guest is set for customers booked in by the staff who did not create
+	// an account. Guests have no password and may have no email.
+	Guest bool
 }
 
 func NewCustomer(dto CreateCustomerDTO) Customer {
@@ -98,6 +363,46 @@ func NewCustomer(dto CreateCustomerDTO) Customer {
 	}
 }
 
+func NewGuest(dto GuestDTO) Customer {
+	return Customer{
+		Email:       dto.Email,
+		Name:        dto.Name,
+		Surname:     dto.Surname,
+		PhoneNumber: dto.PhoneNumber,
+		Guest:       true,
+	}
+}
+
+// CustomerInvitation lets a guest claim their bookings into a full account.
+type CustomerInvitation struct {
+	ID         string
+	CustomerID int
+}
+
+type Vehicle struct {
+	ID           int
+	CustomerID   int
+	ModelID      int
+	Year         *int
+	PlateNumber  string
+	VIN          string
+	ShareHistory bool
+	IsDeleted    bool
+	// CompanyID is set for vehicles shared by the members of a company.
+	CompanyID *int
+}
+
+func NewVehicle(dto CreateVehicleDTO, customerID int) Vehicle {
+	return Vehicle{
+		CustomerID:   customerID,
+		ModelID:      dto.ModelID,
+		Year:         dto.Year,
+		PlateNumber:  dto.PlateNumber,
+		VIN:          dto.VIN,
+		ShareHistory: dto.ShareHistory,
+	}
+}
+
 type Appointment struct {
 	ID         int
 	StartTime  time.Time
@@ -108,17 +413,79 @@ type Appointment struct {
 	EmployeeID int
 	CustomerID int
 	ModelID    int
+	VehicleID  *int
+	SeriesID   *int
+	CompanyID  *int
+	Mileage    *int
+	Notes      *string
+	// Services holds the line items of a new appointment. It is not filled when
+	// appointments are loaded, see Appointments.ListServices.
+	Services []AppointmentService
 }
 
 func NewAppointment(dto CreateAppointmentDTO, customerID int) Appointment {
-	return Appointment{
+	appointment := Appointment{
 		StartTime:  dto.StartTime,
 		EndTime:    dto.EndTime,
-		ServiceID:  dto.ServiceID,
+		ServiceID:  dto.BookedServiceIDs()[0],
 		EmployeeID: dto.EmployeeID,
 		CustomerID: customerID,
 		ModelID:    dto.ModelID,
 	}
+	if dto.VehicleID != 0 {
+		appointment.VehicleID = &dto.VehicleID
+	}
+	return appointment
+}
+
+// ServiceVersion is the price and duration of a service starting from
+// EffectiveFrom. Changes may be scheduled ahead, so the service reads the
+// latest version that is already in effect.
+type ServiceVersion struct {
+	ID            int
+	ServiceID     int
+	Price         int
+	MaxPrice      *int
+	Time          int
+	EffectiveFrom time.Time
+}
+
+func NewServiceVersion(dto UpdateServiceDTO, serviceID int, effectiveFrom time.Time) ServiceVersion {
+	return ServiceVersion{
+		ServiceID:     serviceID,
+		Price:         dto.Price.Gross,
+		MaxPrice:      dto.MaxPrice.GrossPtr(),
+		Time:          dto.Time,
+		EffectiveFrom: effectiveFrom,
+	}
+}
+
+// AppointmentService is a single service booked within an appointment. Name,
+// price, time and the garage pricing are copied at booking time.
+type AppointmentService struct {
+	ID            int
+	AppointmentID int
+	ServiceID     int
+	Name          string
+	Price         int
+	Time          int
+	Currency      string
+	TaxRate       int
+}
+
+func NewAppointmentService(service Service, pricing Pricing) AppointmentService {
+	return AppointmentService{
+		ServiceID: service.ID,
+		Name:      service.Name,
+		Price:     service.Price,
+		Time:      service.Time,
+		Currency:  pricing.Currency,
+		TaxRate:   pricing.TaxRate,
+	}
+}
+
+func (s AppointmentService) Pricing() Pricing {
+	return Pricing{Currency: s.Currency, TaxRate: s.TaxRate}
 }
 
 type TimeSlot struct {
@@ -131,13 +498,524 @@ type Make struct {
 	Name string `json:"name"`
 }
 
+type BodyType string
+
+const (
+	SedanBody       BodyType = "SEDAN"
+	HatchbackBody   BodyType = "HATCHBACK"
+	EstateBody      BodyType = "ESTATE"
+	SUVBody         BodyType = "SUV"
+	CoupeBody       BodyType = "COUPE"
+	ConvertibleBody BodyType = "CONVERTIBLE"
+	MinivanBody     BodyType = "MINIVAN"
+	VanBody         BodyType = "VAN"
+	PickupBody      BodyType = "PICKUP"
+)
+
+var BodyTypes = []BodyType{
+	SedanBody,
+	HatchbackBody,
+	EstateBody,
+	SUVBody,
+	CoupeBody,
+	ConvertibleBody,
+	MinivanBody,
+	VanBody,
+	PickupBody,
+}
+
 type Model struct {
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	MakeID int    `json:"makeId"`
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	MakeID   int       `json:"makeId"`
+	YearFrom *int      `json:"yearFrom,omitempty"`
+	YearTo   *int      `json:"yearTo,omitempty"`
+	BodyType *BodyType `json:"bodyType,omitempty"`
+}
+
+func NewModel(dto CatalogModelDTO, makeID int) Model {
+	return Model{
+		Name:     dto.Name,
+		MakeID:   makeID,
+		YearFrom: dto.YearFrom,
+		YearTo:   dto.YearTo,
+		BodyType: dto.BodyType,
+	}
 }
 
 type Car struct {
 	Make  string `json:"make"`
 	Model string `json:"model"`
 }
+
+type InvoiceItemKind string
+
+const (
+	ServiceItem InvoiceItemKind = "SERVICE"
+	PartItem    InvoiceItemKind = "PART"
+	LabourItem  InvoiceItemKind = "LABOUR"
+)
+
+// Invoice is issued for a completed appointment. Seller and buyer details are
+// copied when the invoice is issued, so later profile changes do not alter it.
+// Invoice is issued either for a single appointment of a customer or, for
+// companies, for all their appointments at the garage within the month
+// starting at Period.
+type Invoice struct {
+	ID             int
+	GarageID       int
+	AppointmentID  *int
+	CustomerID     *int
+	CompanyID      *int
+	Period         *time.Time
+	Number         int
+	IssuedAt       time.Time
+	Currency       string
+	SellerName     string
+	SellerAddress  string
+	BuyerName      string
+	BuyerEmail     string
+	AppointmentIDs []int
+	Items          []InvoiceItem
+}
+
+func NewInvoice(appointment Appointment, garage Garage, customer Customer, items []InvoiceItem) Invoice {
+	buyerName := strings.TrimSpace(customer.Name + " " + customer.Surname)
+	if buyerName == "" {
+		buyerName = customer.Email
+	}
+
+	return Invoice{
+		GarageID:       garage.ID,
+		AppointmentID:  &appointment.ID,
+		CustomerID:     &customer.ID,
+		IssuedAt:       time.Now(),
+		Currency:       garage.Currency,
+		SellerName:     garage.Name,
+		SellerAddress:  sellerAddress(garage),
+		BuyerName:      buyerName,
+		BuyerEmail:     customer.Email,
+		AppointmentIDs: []int{appointment.ID},
+		Items:          items,
+	}
+}
+
+func NewCompanyInvoice(company Company, garage Garage, period time.Time, appointments []Appointment, items []InvoiceItem) Invoice {
+	appointmentIDs := make([]int, len(appointments))
+	for i, appointment := range appointments {
+		appointmentIDs[i] = appointment.ID
+	}
+
+	return Invoice{
+		GarageID:       garage.ID,
+		CompanyID:      &company.ID,
+		Period:         &period,
+		IssuedAt:       time.Now(),
+		Currency:       garage.Currency,
+		SellerName:     garage.Name,
+		SellerAddress:  sellerAddress(garage),
+		BuyerName:      company.Name,
+		BuyerEmail:     company.BillingEmail,
+		AppointmentIDs: appointmentIDs,
+		Items:          items,
+	}
+}
+
+func sellerAddress(garage Garage) string {
+	return fmt.Sprintf("%s %s, %s %s", garage.Street, garage.Number, garage.PostalCode, garage.City)
+}
+
+// DisplayNumber formats the number of the invoice within its garage.
+func (i Invoice) DisplayNumber() string {
+	return fmt.Sprintf("FV/%06d/%d", i.Number, i.IssuedAt.Year())
+}
+
+type InvoiceItem struct {
+	ID        int
+	InvoiceID int
+	Kind      InvoiceItemKind
+	Name      string
+	Quantity  int
+	UnitPrice int
+	TaxRate   int
+}
+
+func NewInvoiceItem(dto InvoiceItemDTO, pricing Pricing) InvoiceItem {
+	return InvoiceItem{
+		Kind:      dto.Kind,
+		Name:      dto.Name,
+		Quantity:  dto.Quantity,
+		UnitPrice: dto.UnitPrice.Gross,
+		TaxRate:   pricing.TaxRate,
+	}
+}
+
+func (i InvoiceItem) Total() int {
+	return i.UnitPrice * i.Quantity
+}
+
+// ErrInsufficientStock is returned when more parts are requested than the
+// garage has in stock.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrNotFound is returned when the record to change no longer exists.
+var ErrNotFound = errors.New("not found")
+
+// Part is a spare part kept in stock by a garage. Cost is the purchase price
+// of a single unit in minor units of the garage currency.
+type Part struct {
+	ID               int
+	GarageID         int
+	SKU              string
+	Name             string
+	Quantity         int
+	Cost             int
+	ReorderThreshold int
+	IsDeleted        bool
+}
+
+func NewPart(dto PartDTO, garageID int) Part {
+	return Part{
+		GarageID:         garageID,
+		SKU:              dto.SKU,
+		Name:             dto.Name,
+		Quantity:         dto.Quantity,
+		Cost:             dto.Cost.Gross,
+		ReorderThreshold: dto.ReorderThreshold,
+	}
+}
+
+// IsLowStock reports whether the part should be reordered.
+func (p Part) IsLowStock() bool {
+	return p.Quantity <= p.ReorderThreshold
+}
+
+// AppointmentPart is a part used during an appointment. The unit cost is
+// copied from the part when it is taken from stock.
+type AppointmentPart struct {
+	ID            int
+	AppointmentID int
+	PartID        int
+	Quantity      int
+	Cost          int
+	CreatedAt     time.Time
+}
+
+// PartUsage sums up how many units of a part were used within a period.
+type PartUsage struct {
+	PartID   int
+	Quantity int
+	Cost     int
+}
+
+// WorkOrder records the work done during an appointment. Mileage and notes
+// are kept on the appointment itself, the work order adds the mechanic's
+// findings and photos.
+type WorkOrder struct {
+	ID            int
+	AppointmentID int
+	Findings      string
+	UpdatedAt     time.Time
+	Photos        []WorkOrderPhoto
+}
+
+type WorkOrderPhoto struct {
+	ID          int
+	WorkOrderID int
+	Photo       []byte
+	CreatedAt   time.Time
+}
+
+type AdditionalItemStatus string
+
+const (
+	PendingItem  AdditionalItemStatus = "PENDING"
+	ApprovedItem AdditionalItemStatus = "APPROVED"
+	RejectedItem AdditionalItemStatus = "REJECTED"
+)
+
+// AdditionalItem is extra work proposed by the mechanic during an appointment.
+// It is carried out only after the customer approves it. Time is the number
+// of working hours the work adds to the appointment.
+type AdditionalItem struct {
+	ID            int
+	AppointmentID int
+	Description   string
+	Price         int
+	Time          int
+	Status        AdditionalItemStatus
+	CreatedAt     time.Time
+	DecidedAt     *time.Time
+}
+
+func NewAdditionalItem(dto CreateAdditionalItemDTO, appointmentID int) AdditionalItem {
+	return AdditionalItem{
+		AppointmentID: appointmentID,
+		Description:   dto.Description,
+		Price:         dto.Price.Gross,
+		Time:          dto.Time,
+		Status:        PendingItem,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// Message is a message in the thread of an appointment. It is sent either by
+// the customer or by a garage employee, ReadAt is set once the other side
+// opens the thread.
+type Message struct {
+	ID            int
+	AppointmentID int
+	SenderRole    Role
+	CustomerID    *int
+	EmployeeID    *int
+	Content       string
+	CreatedAt     time.Time
+	ReadAt        *time.Time
+}
+
+func NewCustomerMessage(dto CreateMessageDTO, appointmentID, customerID int) Message {
+	return Message{
+		AppointmentID: appointmentID,
+		SenderRole:    CustomerRole,
+		CustomerID:    &customerID,
+		Content:       dto.Content,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func NewEmployeeMessage(dto CreateMessageDTO, appointmentID int, employee Employee) Message {
+	return Message{
+		AppointmentID: appointmentID,
+		SenderRole:    employee.Role,
+		EmployeeID:    &employee.ID,
+		Content:       dto.Content,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// FromCustomer reports whether the message was sent by the customer.
+func (m Message) FromCustomer() bool {
+	return m.SenderRole == CustomerRole
+}
+
+type Webhook struct {
+	ID         int
+	GarageID   int
+	URL        string
+	Secret     string
+	Active     bool
+	CreatedAt  time.Time
+	EventTypes []string
+}
+
+func NewWebhook(dto CreateWebhookDTO, garageID int) Webhook {
+	return Webhook{
+		GarageID:   garageID,
+		URL:        dto.URL,
+		Secret:     dto.Secret,
+		Active:     dto.Active,
+		CreatedAt:  time.Now(),
+		EventTypes: dto.EventTypes,
+	}
+}
+
+type WebhookDelivery struct {
+	ID         int
+	WebhookID  int
+	EventType  string
+	Payload    string
+	Attempt    int
+	StatusCode *int
+	Error      *string
+	CreatedAt  time.Time
+}
+
+// CalendarToken grants access to the iCalendar feed of either an employee or
+// a garage, exactly one of EmployeeID and GarageID is set.
+type CalendarToken struct {
+	ID         string
+	EmployeeID *int
+	GarageID   *int
+	CreatedAt  time.Time
+}
+
+// WaitlistMode decides who is offered a slot freed by a cancellation: the
+// customer waiting the longest, or everyone waiting for it at once.
+type WaitlistMode string
+
+const (
+	FirstComeWaitlist WaitlistMode = "FIRST_COME"
+	BroadcastWaitlist WaitlistMode = "BROADCAST"
+)
+
+var WaitlistModes = []WaitlistMode{
+	FirstComeWaitlist,
+	BroadcastWaitlist,
+}
+
+type WaitlistStatus string
+
+const (
+	WaitingEntry WaitlistStatus = "WAITING"
+	OfferedEntry WaitlistStatus = "OFFERED"
+	BookedEntry  WaitlistStatus = "BOOKED"
+	ExpiredEntry WaitlistStatus = "EXPIRED"
+)
+
+// WaitlistEntry is a customer waiting for a slot starting between FromTime
+// and ToTime, the end being exclusive. Without EmployeeID any mechanic qualified for the service
+// will do.
+type WaitlistEntry struct {
+	ID         int
+	CustomerID int
+	GarageID   int
+	ServiceID  int
+	EmployeeID *int
+	FromTime   time.Time
+	ToTime     time.Time
+	Status     WaitlistStatus
+	CreatedAt  time.Time
+}
+
+// ErrSlotHeld is returned when the slot is already held for another customer.
+var ErrSlotHeld = errors.New("slot is held for another customer")
+
+// SlotHold reserves the time of an employee for a customer until ExpiresAt,
+// other customers cannot book it in the meantime.
+type SlotHold struct {
+	ID              int
+	EmployeeID      int
+	CustomerID      int
+	WaitlistEntryID *int
+	StartTime       time.Time
+	EndTime         time.Time
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+}
+
+func NewWaitlistEntry(dto CreateWaitlistEntryDTO, customerID, garageID int) WaitlistEntry {
+	// Dates are checked by validate.CreateWaitlistEntryDTO.
+	from, _ := time.Parse("2006-01-02", dto.From)
+	to, _ := time.Parse("2006-01-02", dto.To)
+
+	entry := WaitlistEntry{
+		CustomerID: customerID,
+		GarageID:   garageID,
+		ServiceID:  dto.ServiceID,
+		FromTime:   from,
+		ToTime:     to.AddDate(0, 0, 1),
+		Status:     WaitingEntry,
+		CreatedAt:  time.Now(),
+	}
+	if dto.EmployeeID != 0 {
+		entry.EmployeeID = &dto.EmployeeID
+	}
+	return entry
+}
+
+type RecurrenceFrequency string
+
+const (
+	WeeklyRecurrence  RecurrenceFrequency = "WEEKLY"
+	MonthlyRecurrence RecurrenceFrequency = "MONTHLY"
+)
+
+var RecurrenceFrequencies = []RecurrenceFrequency{
+	WeeklyRecurrence,
+	MonthlyRecurrence,
+}
+
+// AppointmentSeries is a booking repeated Count times. Weekly series repeat
+// every Interval weeks, monthly ones every Interval months on the same
+// weekday of the month as the first appointment, e.g. the second Tuesday or,
+// when the first one falls in the last week, the last Tuesday.
+type AppointmentSeries struct {
+	ID         int
+	CustomerID int
+	Frequency  RecurrenceFrequency
+	Interval   int
+	Count      int
+	CreatedAt  time.Time
+}
+
+func NewAppointmentSeries(dto RecurrenceDTO, customerID int) AppointmentSeries {
+	return AppointmentSeries{
+		CustomerID: customerID,
+		Frequency:  dto.Frequency,
+		Interval:   dto.Interval,
+		Count:      dto.Count,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Occurrences returns the start times of count appointments of the series
+// beginning at start.
+func (s AppointmentSeries) Occurrences(start time.Time, count int) []time.Time {
+	occurrences := make([]time.Time, count)
+	for i := range occurrences {
+		switch s.Frequency {
+		case MonthlyRecurrence:
+			occurrences[i] = sameWeekdayOfMonth(start, i*s.Interval)
+		default:
+			occurrences[i] = start.AddDate(0, 0, 7*i*s.Interval)
+		}
+	}
+	return occurrences
+}
+
+// sameWeekdayOfMonth returns the day the given number of months after start
+// that is the same weekday and week of the month as start.
+func sameWeekdayOfMonth(start time.Time, months int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(months), 1, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	day := first.AddDate(0, 0, (int(start.Weekday())-int(first.Weekday())+7)%7)
+
+	if start.AddDate(0, 0, 7).Month() != start.Month() {
+		for day.AddDate(0, 0, 7).Month() == day.Month() {
+			day = day.AddDate(0, 0, 7)
+		}
+		return day
+	}
+
+	return day.AddDate(0, 0, 7*((start.Day()-1)/7))
+}
+
+// CompanyRole is the role of a customer within a company. Admins manage the
+// company, its members and invoices, dispatchers book for the shared fleet.
+type CompanyRole string
+
+const (
+	CompanyAdmin      CompanyRole = "ADMIN"
+	CompanyDispatcher CompanyRole = "DISPATCHER"
+)
+
+var CompanyRoles = []CompanyRole{
+	CompanyAdmin,
+	CompanyDispatcher,
+}
+
+// Company is a business customer. Its members are customer accounts sharing
+// the company vehicles and appointment history.
+type Company struct {
+	ID           int
+	Name         string
+	TaxID        string
+	BillingEmail string
+	CreatedAt    time.Time
+}
+
+func NewCompany(dto CreateCompanyDTO) Company {
+	return Company{
+		Name:         dto.Name,
+		TaxID:        dto.TaxID,
+		BillingEmail: dto.BillingEmail,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// CompanyMember is a customer account of a company. A customer can belong to
+// one company only.
+type CompanyMember struct {
+	CompanyID  int
+	CustomerID int
+	Role       CompanyRole
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentSeriesOccurrences(t *testing.T) {
+	t.Run("should repeat every interval weeks", func(t *testing.T) {
+		series := AppointmentSeries{Frequency: WeeklyRecurrence, Interval: 2}
+		start := time.Date(2030, 9, 24, 10, 0, 0, 0, time.UTC)
+
+		occurrences := series.Occurrences(start, 3)
+
+		assert.Equal(t, []time.Time{
+			start,
+			time.Date(2030, 10, 8, 10, 0, 0, 0, time.UTC),
+			time.Date(2030, 10, 22, 10, 0, 0, 0, time.UTC),
+		}, occurrences)
+	})
+
+	t.Run("should repeat on the same weekday of the month", func(t *testing.T) {
+		series := AppointmentSeries{Frequency: MonthlyRecurrence, Interval: 1}
+		// The second Tuesday of September.
+		start := time.Date(2030, 9, 10, 10, 0, 0, 0, time.UTC)
+
+		occurrences := series.Occurrences(start, 3)
+
+		assert.Equal(t, []time.Time{
+			start,
+			time.Date(2030, 10, 8, 10, 0, 0, 0, time.UTC),
+			time.Date(2030, 11, 12, 10, 0, 0, 0, time.UTC),
+		}, occurrences)
+	})
+
+	t.Run("should repeat on the last weekday of the month", func(t *testing.T) {
+		series := AppointmentSeries{Frequency: MonthlyRecurrence, Interval: 2}
+		// The last Tuesday of September.
+		start := time.Date(2030, 9, 24, 10, 0, 0, 0, time.UTC)
+
+		occurrences := series.Occurrences(start, 3)
+
+		assert.Equal(t, []time.Time{
+			start,
+			time.Date(2030, 11, 26, 10, 0, 0, 0, time.UTC),
+			time.Date(2031, 1, 28, 10, 0, 0, 0, time.UTC),
+		}, occurrences)
+	})
+}
@@ -0,0 +1,121 @@
+package pdf
+
+import (
+	"fmt"
+
+	"github.com/KsaweryZietara/garage/internal"
+)
+
+const (
+	margin     = 50.0
+	rowHeight  = 18.0
+	fontSize   = 10.0
+	bottomEdge = 80.0
+)
+
+var invoiceColumns = []struct {
+	title string
+	x     float64
+	right bool
+}{
+	{"Lp.", margin, false},
+	{"Nazwa", margin + 30, false},
+	{"Ilość", 300, true},
+	{"Cena brutto", 380, true},
+	{"VAT", 420, true},
+	{"Netto", 480, true},
+	{"Brutto", PageWidth - margin, true},
+}
+
+// Invoice renders an invoice as a PDF document.
+func Invoice(invoice internal.Invoice) []byte {
+	document := New()
+	y := PageHeight - margin
+
+	document.Text(margin, y, 18, true, "Faktura VAT "+invoice.DisplayNumber())
+	y -= 24
+	document.Text(margin, y, fontSize, false, "Data wystawienia: "+invoice.IssuedAt.Format("2006-01-02"))
+	y -= 36
+
+	document.Text(margin, y, fontSize, true, "Sprzedawca")
+	document.Text(PageWidth/2, y, fontSize, true, "Nabywca")
+	y -= 16
+	document.Text(margin, y, fontSize, false, invoice.SellerName)
+	document.Text(PageWidth/2, y, fontSize, false, invoice.BuyerName)
+	y -= 14
+	document.Text(margin, y, fontSize, false, invoice.SellerAddress)
+	document.Text(PageWidth/2, y, fontSize, false, invoice.BuyerEmail)
+	y -= 36
+
+	y = tableHeader(document, y)
+
+	var net, gross int
+	for i, item := range invoice.Items {
+		if y < bottomEdge {
+			document.AddPage()
+			y = tableHeader(document, PageHeight-margin)
+		}
+
+		pricing := internal.Pricing{Currency: invoice.Currency, TaxRate: item.TaxRate}
+		itemNet := pricing.Net(item.Total())
+		net += itemNet
+		gross += item.Total()
+
+		cells := []string{
+			fmt.Sprintf("%d.", i+1),
+			truncate(item.Name, 40),
+			fmt.Sprintf("%d", item.Quantity),
+			internal.FormatAmount(item.UnitPrice),
+			formatRate(item.TaxRate),
+			internal.FormatAmount(itemNet),
+			internal.FormatAmount(item.Total()),
+		}
+		for j, column := range invoiceColumns {
+			if column.right {
+				document.TextRight(column.x, y, fontSize, false, cells[j])
+			} else {
+				document.Text(column.x, y, fontSize, false, cells[j])
+			}
+		}
+		y -= rowHeight
+	}
+
+	document.Line(margin, y+rowHeight-4, PageWidth-margin, y+rowHeight-4)
+	y -= 8
+	right := PageWidth - margin
+	document.TextRight(right, y, fontSize, false, "Netto: "+internal.FormatMoney(net, invoice.Currency))
+	y -= 16
+	document.TextRight(right, y, fontSize, false, "VAT: "+internal.FormatMoney(gross-net, invoice.Currency))
+	y -= 20
+	document.TextRight(right, y, 12, true, "Do zapłaty: "+internal.FormatMoney(gross, invoice.Currency))
+
+	return document.Bytes()
+}
+
+func tableHeader(document *Document, y float64) float64 {
+	for _, column := range invoiceColumns {
+		if column.right {
+			document.TextRight(column.x, y, fontSize, true, column.title)
+		} else {
+			document.Text(column.x, y, fontSize, true, column.title)
+		}
+	}
+	document.Line(margin, y-6, PageWidth-margin, y-6)
+	return y - rowHeight - 4
+}
+
+// formatRate formats a tax rate given in basis points as a percentage.
+func formatRate(rate int) string {
+	if rate%100 == 0 {
+		return fmt.Sprintf("%d%%", rate/100)
+	}
+	return fmt.Sprintf("%d,%02d%%", rate/100, rate%100)
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,79 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	t.Run("should write a document with a valid cross-reference table", func(t *testing.T) {
+		document := New()
+		document.Text(50, 800, 12, false, "first page")
+		document.AddPage()
+		document.Text(50, 800, 12, true, "second page")
+		output := document.Bytes()
+
+		assert.True(t, bytes.HasPrefix(output, []byte("%PDF-1.4\n")))
+		assert.True(t, bytes.HasSuffix(output, []byte("%%EOF\n")))
+		assert.Contains(t, string(output), "/Count 2")
+
+		match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(output)
+		require.NotNil(t, match)
+		xref, err := strconv.Atoi(string(match[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(output[xref:], []byte("xref\n")))
+
+		offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(output, -1)
+		require.Len(t, offsets, 8)
+		for i, offset := range offsets {
+			position, err := strconv.Atoi(string(offset[1]))
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(output[position:], []byte(fmt.Sprintf("%d 0 obj", i+1))))
+		}
+	})
+
+	t.Run("should escape and encode text", func(t *testing.T) {
+		document := New()
+		document.Text(50, 800, 12, false, "Wymiana (olej) \\ łożysk €")
+		output := string(document.Bytes())
+
+		assert.Contains(t, output, `(Wymiana \(olej\) \\ lozysk ?) Tj`)
+	})
+}
+
+func TestInvoice(t *testing.T) {
+	invoice := internal.Invoice{
+		Number:        7,
+		IssuedAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Currency:      "PLN",
+		SellerName:    "Garage",
+		SellerAddress: "Street 1, 00-001 City",
+		BuyerName:     "John Doe",
+		BuyerEmail:    "john@example.com",
+		Items: []internal.InvoiceItem{
+			{Kind: internal.ServiceItem, Name: "Oil change", Quantity: 1, UnitPrice: 12300, TaxRate: 2300},
+			{Kind: internal.PartItem, Name: "Oil filter", Quantity: 2, UnitPrice: 2460, TaxRate: 2300},
+		},
+	}
+
+	output := string(Invoice(invoice))
+
+	assert.Contains(t, output, "(Faktura VAT FV/000007/2026) Tj")
+	assert.Contains(t, output, "(Oil filter) Tj")
+	assert.Contains(t, output, "(49,20) Tj")
+	assert.Contains(t, output, "(Do zaplaty: 172,20 PLN) Tj")
+}
+
+func TestFormatRate(t *testing.T) {
+	assert.Equal(t, "23%", formatRate(2300))
+	assert.Equal(t, "5,50%", formatRate(550))
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const additionalItemsTable = "additional_items"
+
+type AdditionalItem struct {
+	connection *dbr.Connection
+}
+
+func NewAdditionalItem(connection *dbr.Connection) *AdditionalItem {
+	return &AdditionalItem{
+		connection: connection,
+	}
+}
+
+func (a *AdditionalItem) Insert(item internal.AdditionalItem) (internal.AdditionalItem, error) {
+	sess := a.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(additionalItemsTable).
+		Columns("appointment_id", "description", "price", "time", "status", "created_at").
+		Record(item).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.AdditionalItem{}, err
+	}
+
+	item.ID = id
+	return item, nil
+}
+
+func (a *AdditionalItem) GetByID(ID int) (internal.AdditionalItem, error) {
+	sess := a.connection.NewSession(nil)
+
+	var item internal.AdditionalItem
+	err := sess.Select("*").
+		From(additionalItemsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&item)
+
+	if err != nil {
+		return internal.AdditionalItem{}, err
+	}
+
+	return item, nil
+}
+
+func (a *AdditionalItem) ListByAppointmentID(appointmentID int) ([]internal.AdditionalItem, error) {
+	sess := a.connection.NewSession(nil)
+
+	var items []internal.AdditionalItem
+	_, err := sess.Select("*").
+		From(additionalItemsTable).
+		Where(dbr.Eq("appointment_id", appointmentID)).
+		OrderBy("id").
+		Load(&items)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (a *AdditionalItem) Update(item internal.AdditionalItem) error {
+	sess := a.connection.NewSession(nil)
+
+	_, err := sess.Update(additionalItemsTable).
+		Where(dbr.Eq("id", item.ID)).
+		Set("status", item.Status).
+		Set("decided_at", item.DecidedAt).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdditionalItem(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	additionalItemRepo := NewAdditionalItem(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now().Add(-time.Hour),
+		EndTime:    time.Now().Add(time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	item, err := additionalItemRepo.Insert(internal.NewAdditionalItem(internal.CreateAdditionalItemDTO{
+		Description: "Replace brake discs",
+		Price:       internal.MoneyDTO{Gross: 25000},
+		Time:        1,
+	}, appointment.ID))
+	require.NoError(t, err)
+
+	now := time.Now()
+	item.Status = internal.ApprovedItem
+	item.DecidedAt = &now
+	err = additionalItemRepo.Update(item)
+	require.NoError(t, err)
+
+	retrievedItem, err := additionalItemRepo.GetByID(item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.ApprovedItem, retrievedItem.Status)
+	assert.NotNil(t, retrievedItem.DecidedAt)
+
+	items, err := additionalItemRepo.ListByAppointmentID(appointment.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 25000, items[0].Price)
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/gocraft/dbr/v2"
 )
 
-const appointmentsTable = "appointments"
+const (
+	appointmentsTable        = "appointments"
+	appointmentServicesTable = "appointment_services"
+)
 
 type Appointment struct {
 	connection *dbr.Connection
@@ -20,20 +23,57 @@ func NewAppointment(connection *dbr.Connection) *Appointment {
 	}
 }
 
+// Insert stores the appointment together with its line items. Appointments
+// without line items get one for their main service.
 func (a *Appointment) Insert(appointment internal.Appointment) (internal.Appointment, error) {
 	sess := a.connection.NewSession(nil)
 
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Appointment{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
 	var id int
-	err := sess.InsertInto(appointmentsTable).
-		Columns("start_time", "end_time", "service_id", "employee_id", "customer_id", "model_id").
+	err = tx.InsertInto(appointmentsTable).
+		Columns("start_time", "end_time", "service_id", "employee_id", "customer_id", "model_id", "vehicle_id", "series_id", "company_id").
 		Record(appointment).
 		Returning("id").
 		Load(&id)
-
 	if err != nil {
 		return internal.Appointment{}, err
 	}
 
+	if len(appointment.Services) == 0 {
+		_, err = tx.InsertBySql(`
+			INSERT INTO appointment_services (appointment_id, service_id, name, price, time, currency, tax_rate)
+			SELECT ?, s.id, s.name, s.price, s.time, g.currency, g.tax_rate
+			FROM services AS s
+			JOIN garages AS g ON g.id = s.garage_id
+			WHERE s.id = ?
+			`, id, appointment.ServiceID).
+			Exec()
+		if err != nil {
+			return internal.Appointment{}, err
+		}
+	}
+
+	for i := range appointment.Services {
+		appointment.Services[i].AppointmentID = id
+		err = tx.InsertInto(appointmentServicesTable).
+			Columns("appointment_id", "service_id", "name", "price", "time", "currency", "tax_rate").
+			Record(appointment.Services[i]).
+			Returning("id").
+			Load(&appointment.Services[i].ID)
+		if err != nil {
+			return internal.Appointment{}, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.Appointment{}, err
+	}
+
 	appointment.ID = id
 	return appointment, nil
 }
@@ -74,18 +114,22 @@ func (a *Appointment) GetByTimeSlot(slot internal.TimeSlot, employeeID int) ([]i
 }
 
 func (a *Appointment) GetByEmployeeID(employeeID int, date time.Time) ([]internal.Appointment, error) {
-	sess := a.connection.NewSession(nil)
+	startOfDay, endOfDay := dayBounds(date)
+	return a.ListByEmployeeIDBetween(employeeID, startOfDay, endOfDay)
+}
 
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+// ListByEmployeeIDBetween returns the appointments of the employee overlapping
+// the period from start to end.
+func (a *Appointment) ListByEmployeeIDBetween(employeeID int, start, end time.Time) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
 
 	var appointments []internal.Appointment
 	_, err := sess.Select("*").
 		From(appointmentsTable).
 		Where(dbr.And(
 			dbr.Eq("employee_id", employeeID),
-			dbr.Lte("start_time", endOfDay),
-			dbr.Gte("end_time", startOfDay),
+			dbr.Lte("start_time", end),
+			dbr.Gte("end_time", start),
 		)).
 		OrderBy("start_time ASC").
 		Load(&appointments)
@@ -98,20 +142,23 @@ func (a *Appointment) GetByEmployeeID(employeeID int, date time.Time) ([]interna
 }
 
 func (a *Appointment) GetByGarageID(garageID int, date time.Time) ([]internal.Appointment, error) {
-	sess := a.connection.NewSession(nil)
+	startOfDay, endOfDay := dayBounds(date)
+	return a.ListByGarageIDBetween(garageID, startOfDay, endOfDay)
+}
 
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+// ListByGarageIDBetween returns the appointments of the garage overlapping the
+// period from start to end.
+func (a *Appointment) ListByGarageIDBetween(garageID int, start, end time.Time) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
 
 	var appointments []internal.Appointment
 	_, err := sess.Select("a.*").
 		From(dbr.I(appointmentsTable).As("a")).
-		LeftJoin(dbr.I(employeesTable).As("e"), "a.employee_id = e.id").
-		LeftJoin(dbr.I(garagesTable).As("g"), "e.garage_id = g.id").
+		Join(dbr.I(servicesTable).As("s"), "a.service_id = s.id").
 		Where(dbr.And(
-			dbr.Eq("g.id", garageID),
-			dbr.Lte("start_time", endOfDay),
-			dbr.Gte("end_time", startOfDay),
+			dbr.Eq("s.garage_id", garageID),
+			dbr.Lte("start_time", end),
+			dbr.Gte("end_time", start),
 		)).
 		OrderBy("a.start_time ASC").
 		Load(&appointments)
@@ -161,8 +208,12 @@ func (a *Appointment) Update(appointment internal.Appointment) error {
 
 	_, err := sess.Update(appointmentsTable).
 		Where(dbr.Eq("id", appointment.ID)).
+		Set("start_time", appointment.StartTime).
+		Set("end_time", appointment.EndTime).
 		Set("rating", appointment.Rating).
 		Set("comment", appointment.Comment).
+		Set("mileage", appointment.Mileage).
+		Set("notes", appointment.Notes).
 		Exec()
 
 	return err
@@ -174,9 +225,9 @@ func (a *Appointment) ListByGarageID(garageID int) ([]internal.Appointment, erro
 	var appointments []internal.Appointment
 	_, err := sess.Select("a.*").
 		From(dbr.I(appointmentsTable).As("a")).
-		Join(dbr.I("employees").As("e"), "a.employee_id = e.id").
+		Join(dbr.I(servicesTable).As("s"), "a.service_id = s.id").
 		Where(dbr.And(
-			dbr.Eq("e.garage_id", garageID),
+			dbr.Eq("s.garage_id", garageID),
 			dbr.Neq("a.rating", nil),
 		)).
 		OrderBy("a.end_time DESC").
@@ -189,12 +240,144 @@ func (a *Appointment) ListByGarageID(garageID int) ([]internal.Appointment, erro
 	return appointments, nil
 }
 
+// Delete removes the appointment and puts the parts used for it back in
+// stock.
 func (a *Appointment) Delete(ID int) error {
 	sess := a.connection.NewSession(nil)
 
-	_, err := sess.DeleteFrom(appointmentsTable).
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.UpdateBySql(`
+		UPDATE parts AS p SET quantity = p.quantity + ap.quantity
+		FROM (
+		    SELECT part_id, SUM(quantity) AS quantity FROM appointment_parts
+		    WHERE appointment_id = ? GROUP BY part_id
+		) AS ap
+		WHERE p.id = ap.part_id
+		`, ID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.DeleteFrom(appointmentPartsTable).
+		Where(dbr.Eq("appointment_id", ID)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.DeleteFrom(appointmentsTable).
 		Where(dbr.Eq("id", ID)).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit()
+}
+
+func (a *Appointment) ListByVehicleID(vehicleID int) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
+
+	var appointments []internal.Appointment
+	_, err := sess.Select("*").
+		From(appointmentsTable).
+		Where(dbr.Eq("vehicle_id", vehicleID)).
+		OrderBy("start_time DESC").
+		Load(&appointments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+func (a *Appointment) ListBySeriesID(seriesID int) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
+
+	var appointments []internal.Appointment
+	_, err := sess.Select("*").
+		From(appointmentsTable).
+		Where(dbr.Eq("series_id", seriesID)).
+		OrderBy("start_time").
+		Load(&appointments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+func (a *Appointment) ListByCompanyID(companyID int) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
+
+	var appointments []internal.Appointment
+	_, err := sess.Select("*").
+		From(appointmentsTable).
+		Where(dbr.Eq("company_id", companyID)).
+		OrderBy("start_time DESC").
+		Load(&appointments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+// ListUninvoicedByCompanyID returns the appointments of the company at the
+// garage ending between start and end that are not invoiced yet.
+func (a *Appointment) ListUninvoicedByCompanyID(companyID, garageID int, start, end time.Time) ([]internal.Appointment, error) {
+	sess := a.connection.NewSession(nil)
+
+	var appointments []internal.Appointment
+	_, err := sess.Select("a.*").
+		From(dbr.I(appointmentsTable).As("a")).
+		Join(dbr.I(servicesTable).As("s"), "a.service_id = s.id").
+		LeftJoin(dbr.I(invoiceAppointmentsTable).As("ia"), "ia.appointment_id = a.id").
+		Where(dbr.And(
+			dbr.Eq("a.company_id", companyID),
+			dbr.Eq("s.garage_id", garageID),
+			dbr.Gte("a.end_time", start),
+			dbr.Lt("a.end_time", end),
+			dbr.Eq("ia.invoice_id", nil),
+		)).
+		OrderBy("a.start_time").
+		Load(&appointments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+func (a *Appointment) ListServices(appointmentID int) ([]internal.AppointmentService, error) {
+	sess := a.connection.NewSession(nil)
+
+	var services []internal.AppointmentService
+	_, err := sess.Select("*").
+		From(appointmentServicesTable).
+		Where(dbr.Eq("appointment_id", appointmentID)).
+		OrderBy("id").
+		Load(&services)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func dayBounds(date time.Time) (time.Time, time.Time) {
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+	return startOfDay, endOfDay
 }
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const appointmentSeriesTable = "appointment_series"
+
+type AppointmentSeries struct {
+	connection *dbr.Connection
+}
+
+func NewAppointmentSeries(connection *dbr.Connection) *AppointmentSeries {
+	return &AppointmentSeries{
+		connection: connection,
+	}
+}
+
+func (a *AppointmentSeries) Insert(series internal.AppointmentSeries) (internal.AppointmentSeries, error) {
+	sess := a.connection.NewSession(nil)
+
+	err := sess.InsertInto(appointmentSeriesTable).
+		Columns("customer_id", "frequency", "interval", "count", "created_at").
+		Record(&series).
+		Returning("id").
+		Load(&series.ID)
+
+	return series, err
+}
+
+func (a *AppointmentSeries) GetByID(ID int) (internal.AppointmentSeries, error) {
+	sess := a.connection.NewSession(nil)
+
+	var series internal.AppointmentSeries
+	err := sess.Select("*").
+		From(appointmentSeriesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&series)
+
+	return series, err
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppointmentSeries(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	seriesRepo := NewAppointmentSeries(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{
+		Name:     "Inspection",
+		Time:     1,
+		Price:    100,
+		GarageID: garage.ID,
+	})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "customer@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	series, err := seriesRepo.Insert(internal.AppointmentSeries{
+		CustomerID: customer.ID,
+		Frequency:  internal.WeeklyRecurrence,
+		Interval:   2,
+		Count:      3,
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	retrieved, err := seriesRepo.GetByID(series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, retrieved.CustomerID)
+	assert.Equal(t, internal.WeeklyRecurrence, retrieved.Frequency)
+	assert.Equal(t, 2, retrieved.Interval)
+	assert.Equal(t, 3, retrieved.Count)
+
+	start := time.Date(2030, 9, 24, 10, 0, 0, 0, time.UTC)
+	for i, occurrence := range series.Occurrences(start, series.Count) {
+		appointment := internal.Appointment{
+			StartTime:  occurrence,
+			EndTime:    occurrence.Add(time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: employee.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		}
+		if i != 1 {
+			appointment.SeriesID = &series.ID
+		}
+		_, err = appointmentRepo.Insert(appointment)
+		require.NoError(t, err)
+	}
+
+	appointments, err := appointmentRepo.ListBySeriesID(series.ID)
+	require.NoError(t, err)
+	require.Len(t, appointments, 2)
+	assert.True(t, appointments[0].StartTime.Equal(start))
+	assert.True(t, appointments[1].StartTime.Equal(start.AddDate(0, 0, 28)))
+
+	appointments[1].StartTime = start.AddDate(0, 0, 29)
+	appointments[1].EndTime = appointments[1].StartTime.Add(time.Hour)
+	err = appointmentRepo.Update(appointments[1])
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.GetByID(appointments[1].ID)
+	require.NoError(t, err)
+	assert.True(t, appointment.StartTime.Equal(start.AddDate(0, 0, 29)))
+	assert.Equal(t, series.ID, *appointment.SeriesID)
+}
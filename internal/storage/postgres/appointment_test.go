@@ -305,6 +305,14 @@ func TestGetAppointmentsByEmployeeIDOrCustomerID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, foundAppointments, 3)
 
+	foundAppointments, err = appointmentRepo.ListByEmployeeIDBetween(employee3.ID, time.Now().Add(-72*time.Hour), time.Now().Add(72*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, foundAppointments, 3)
+
+	foundAppointments, err = appointmentRepo.ListByGarageIDBetween(garage.ID, time.Now().Add(-72*time.Hour), time.Now().Add(72*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, foundAppointments, 5)
+
 	foundAppointments, err = appointmentRepo.GetByCustomerID(customer.ID)
 	require.NoError(t, err)
 	assert.Len(t, foundAppointments, 3)
@@ -398,3 +406,86 @@ func TestDeleteAppointment(t *testing.T) {
 	_, err = appointmentRepo.GetByID(appointment.ID)
 	assert.EqualError(t, err, "dbr: not found")
 }
+
+func TestAppointmentServices(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	oilChange, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 2, Price: 100, GarageID: garage.ID})
+	require.NoError(t, err)
+	tyreSwap, err := serviceRepo.Insert(internal.Service{Name: "Tyre swap", Time: 1, Price: 50, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(3 * time.Hour),
+		ServiceID:  oilChange.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+		Services: []internal.AppointmentService{
+			internal.NewAppointmentService(oilChange, garage.Pricing()),
+			internal.NewAppointmentService(tyreSwap, garage.Pricing()),
+		},
+	})
+	require.NoError(t, err)
+
+	services, err := appointmentRepo.ListServices(appointment.ID)
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	assert.Equal(t, oilChange.ID, services[0].ServiceID)
+	assert.Equal(t, "Tyre swap", services[1].Name)
+	assert.Equal(t, internal.DefaultCurrency, services[0].Currency)
+	assert.Equal(t, internal.DefaultTaxRate, services[0].TaxRate)
+	assert.Equal(t, 150, internal.NewTotalPriceDTO(services).Gross)
+
+	appointment, err = appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(time.Hour),
+		ServiceID:  tyreSwap.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	services, err = appointmentRepo.ListServices(appointment.ID)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, tyreSwap.ID, services[0].ServiceID)
+	assert.Equal(t, tyreSwap.Price, services[0].Price)
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const calendarTokensTable = "calendar_tokens"
+
+type CalendarToken struct {
+	connection *dbr.Connection
+}
+
+func NewCalendarToken(connection *dbr.Connection) *CalendarToken {
+	return &CalendarToken{
+		connection: connection,
+	}
+}
+
+// Insert stores the token in place of the previous token of the same employee
+// or garage, which stops working.
+func (c *CalendarToken) Insert(token internal.CalendarToken) (internal.CalendarToken, error) {
+	sess := c.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.CalendarToken{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.DeleteFrom(calendarTokensTable).
+		Where(tokenOwnerCondition(token)).
+		Exec()
+	if err != nil {
+		return internal.CalendarToken{}, err
+	}
+
+	_, err = tx.InsertInto(calendarTokensTable).
+		Columns("id", "employee_id", "garage_id", "created_at").
+		Record(token).
+		Exec()
+	if err != nil {
+		return internal.CalendarToken{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.CalendarToken{}, err
+	}
+
+	return token, nil
+}
+
+func (c *CalendarToken) GetByID(ID string) (internal.CalendarToken, error) {
+	sess := c.connection.NewSession(nil)
+
+	var token internal.CalendarToken
+	err := sess.Select("*").
+		From(calendarTokensTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&token)
+
+	return token, err
+}
+
+func (c *CalendarToken) GetByEmployeeID(employeeID int) (internal.CalendarToken, error) {
+	return c.getBy(internal.CalendarToken{EmployeeID: &employeeID})
+}
+
+func (c *CalendarToken) GetByGarageID(garageID int) (internal.CalendarToken, error) {
+	return c.getBy(internal.CalendarToken{GarageID: &garageID})
+}
+
+func (c *CalendarToken) DeleteByEmployeeID(employeeID int) error {
+	return c.deleteBy(internal.CalendarToken{EmployeeID: &employeeID})
+}
+
+func (c *CalendarToken) DeleteByGarageID(garageID int) error {
+	return c.deleteBy(internal.CalendarToken{GarageID: &garageID})
+}
+
+func (c *CalendarToken) getBy(owner internal.CalendarToken) (internal.CalendarToken, error) {
+	sess := c.connection.NewSession(nil)
+
+	var token internal.CalendarToken
+	err := sess.Select("*").
+		From(calendarTokensTable).
+		Where(tokenOwnerCondition(owner)).
+		LoadOne(&token)
+
+	return token, err
+}
+
+func (c *CalendarToken) deleteBy(owner internal.CalendarToken) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(calendarTokensTable).
+		Where(tokenOwnerCondition(owner)).
+		Exec()
+
+	return err
+}
+
+func tokenOwnerCondition(token internal.CalendarToken) dbr.Builder {
+	if token.EmployeeID != nil {
+		return dbr.Eq("employee_id", *token.EmployeeID)
+	}
+	return dbr.Eq("garage_id", *token.GarageID)
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarToken(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	calendarTokenRepo := NewCalendarToken(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	employeeToken, err := calendarTokenRepo.Insert(internal.CalendarToken{
+		ID:         uuid.New().String(),
+		EmployeeID: &employee.ID,
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	garageToken, err := calendarTokenRepo.Insert(internal.CalendarToken{
+		ID:        uuid.New().String(),
+		GarageID:  &garage.ID,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	retrieved, err := calendarTokenRepo.GetByID(employeeToken.ID)
+	require.NoError(t, err)
+	assert.Equal(t, employee.ID, *retrieved.EmployeeID)
+	assert.Nil(t, retrieved.GarageID)
+
+	retrieved, err = calendarTokenRepo.GetByGarageID(garage.ID)
+	require.NoError(t, err)
+	assert.Equal(t, garageToken.ID, retrieved.ID)
+
+	rotated, err := calendarTokenRepo.Insert(internal.CalendarToken{
+		ID:         uuid.New().String(),
+		EmployeeID: &employee.ID,
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	_, err = calendarTokenRepo.GetByID(employeeToken.ID)
+	assert.Error(t, err)
+
+	retrieved, err = calendarTokenRepo.GetByEmployeeID(employee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.ID, retrieved.ID)
+
+	err = calendarTokenRepo.DeleteByEmployeeID(employee.ID)
+	require.NoError(t, err)
+	_, err = calendarTokenRepo.GetByEmployeeID(employee.ID)
+	assert.Error(t, err)
+
+	_, err = calendarTokenRepo.GetByGarageID(garage.ID)
+	assert.NoError(t, err)
+
+	err = calendarTokenRepo.DeleteByGarageID(garage.ID)
+	require.NoError(t, err)
+	_, err = calendarTokenRepo.GetByGarageID(garage.ID)
+	assert.Error(t, err)
+}
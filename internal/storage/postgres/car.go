@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"errors"
+
 	"github.com/KsaweryZietara/garage/internal"
 
 	"github.com/gocraft/dbr/v2"
@@ -11,6 +13,10 @@ const (
 	modelsTable = "models"
 )
 
+// modelReferences lists the tables pointing at a car model which have to follow
+// it when the model is merged into another one.
+var modelReferences = []string{appointmentsTable, vehiclesTable, serviceRulesTable}
+
 type Car struct {
 	connection *dbr.Connection
 }
@@ -27,6 +33,7 @@ func (c *Car) ListMakes() ([]internal.Make, error) {
 	var makes []internal.Make
 	_, err := sess.Select("*").
 		From(makesTable).
+		Where(dbr.Expr("merged_into_id IS NULL")).
 		OrderBy("name").
 		Load(&makes)
 
@@ -43,7 +50,10 @@ func (c *Car) ListModels(makeID int) ([]internal.Model, error) {
 	var models []internal.Model
 	_, err := sess.Select("*").
 		From(modelsTable).
-		Where(dbr.Eq("make_id", makeID)).
+		Where(dbr.And(
+			dbr.Eq("make_id", makeID),
+			dbr.Expr("merged_into_id IS NULL"),
+		)).
 		OrderBy("name").
 		Load(&models)
 
@@ -70,3 +80,270 @@ func (c *Car) GetByModelID(modelID int) (internal.Car, error) {
 
 	return car, nil
 }
+
+func (c *Car) GetMakeByID(ID int) (internal.Make, error) {
+	sess := c.connection.NewSession(nil)
+
+	var carMake internal.Make
+	err := sess.Select("*").
+		From(makesTable).
+		Where(dbr.And(
+			dbr.Eq("id", ID),
+			dbr.Expr("merged_into_id IS NULL"),
+		)).
+		LoadOne(&carMake)
+
+	if err != nil {
+		return internal.Make{}, err
+	}
+
+	return carMake, nil
+}
+
+func (c *Car) GetModelByID(ID int) (internal.Model, error) {
+	sess := c.connection.NewSession(nil)
+
+	var model internal.Model
+	err := sess.Select("*").
+		From(modelsTable).
+		Where(dbr.And(
+			dbr.Eq("id", ID),
+			dbr.Expr("merged_into_id IS NULL"),
+		)).
+		LoadOne(&model)
+
+	if err != nil {
+		return internal.Model{}, err
+	}
+
+	return model, nil
+}
+
+func (c *Car) InsertMake(carMake internal.Make) (internal.Make, error) {
+	sess := c.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(makesTable).
+		Columns("name").
+		Record(carMake).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Make{}, err
+	}
+
+	carMake.ID = id
+	return carMake, nil
+}
+
+func (c *Car) UpdateMake(carMake internal.Make) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(makesTable).
+		Where(dbr.Eq("id", carMake.ID)).
+		Set("name", carMake.Name).
+		Exec()
+
+	return err
+}
+
+func (c *Car) InsertModel(model internal.Model) (internal.Model, error) {
+	sess := c.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(modelsTable).
+		Columns("name", "make_id", "year_from", "year_to", "body_type").
+		Record(model).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Model{}, err
+	}
+
+	model.ID = id
+	return model, nil
+}
+
+func (c *Car) UpdateModel(model internal.Model) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(modelsTable).
+		Where(dbr.Eq("id", model.ID)).
+		Set("name", model.Name).
+		Set("year_from", model.YearFrom).
+		Set("year_to", model.YearTo).
+		Set("body_type", model.BodyType).
+		Exec()
+
+	return err
+}
+
+// UpsertMake returns the id of the make with the given name, creating it when
+// it does not exist yet. Names of merged makes resolve to the make they were
+// merged into.
+func (c *Car) UpsertMake(name string) (int, error) {
+	sess := c.connection.NewSession(nil)
+
+	var id int
+	err := sess.SelectBySql(`
+		INSERT INTO makes (name) VALUES (?)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING COALESCE(merged_into_id, id)
+		`, name).
+		LoadOne(&id)
+
+	return id, err
+}
+
+// UpsertModel creates the model or fills in the attributes of an existing one
+// with the same make and name. Attributes missing from the given model are left
+// untouched. Names of merged models resolve to the model they were merged into.
+func (c *Car) UpsertModel(model internal.Model) (int, error) {
+	sess := c.connection.NewSession(nil)
+
+	var id int
+	err := sess.SelectBySql(`
+		SELECT t.merged_into_id FROM models AS t
+		WHERE t.make_id = ? AND t.name = ? AND t.merged_into_id IS NOT NULL
+		    AND NOT EXISTS (
+		        SELECT 1 FROM models AS m
+		        WHERE m.make_id = t.make_id AND m.name = t.name AND m.merged_into_id IS NULL
+		    )
+		LIMIT 1
+		`, model.MakeID, model.Name).
+		LoadOne(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, dbr.ErrNotFound) {
+		return 0, err
+	}
+
+	err = sess.SelectBySql(`
+		INSERT INTO models (name, make_id, year_from, year_to, body_type) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (make_id, name) WHERE merged_into_id IS NULL DO UPDATE SET
+		    year_from = COALESCE(EXCLUDED.year_from, models.year_from),
+		    year_to = COALESCE(EXCLUDED.year_to, models.year_to),
+		    body_type = COALESCE(EXCLUDED.body_type, models.body_type)
+		RETURNING COALESCE(merged_into_id, id)
+		`, model.Name, model.MakeID, model.YearFrom, model.YearTo, model.BodyType).
+		LoadOne(&id)
+
+	return id, err
+}
+
+// MergeMakes moves every model of the source make to the target one. Models
+// named the same in both makes are merged as well.
+func (c *Car) MergeMakes(sourceID, targetID int) error {
+	sess := c.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var duplicates []struct {
+		SourceID int
+		TargetID int
+	}
+	_, err = tx.SelectBySql(`
+		SELECT s.id AS source_id, t.id AS target_id
+		FROM models AS s
+		JOIN models AS t ON t.make_id = ? AND t.name = s.name AND t.merged_into_id IS NULL
+		WHERE s.make_id = ? AND s.merged_into_id IS NULL
+		`, targetID, sourceID).
+		Load(&duplicates)
+	if err != nil {
+		return err
+	}
+
+	for _, duplicate := range duplicates {
+		if err = mergeModels(tx, duplicate.SourceID, duplicate.TargetID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Update(modelsTable).
+		Where(dbr.And(
+			dbr.Eq("make_id", sourceID),
+			dbr.Expr("merged_into_id IS NULL"),
+		)).
+		Set("make_id", targetID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Update(serviceRulesTable).
+		Where(dbr.Eq("make_id", sourceID)).
+		Set("make_id", targetID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Update(makesTable).
+		Where(dbr.Or(
+			dbr.Eq("id", sourceID),
+			dbr.Eq("merged_into_id", sourceID),
+		)).
+		Set("merged_into_id", targetID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (c *Car) MergeModels(sourceID, targetID int) error {
+	sess := c.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	if err = mergeModels(tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func mergeModels(tx *dbr.Tx, sourceID, targetID int) error {
+	for _, table := range modelReferences {
+		_, err := tx.Update(table).
+			Where(dbr.Eq("model_id", sourceID)).
+			Set("model_id", targetID).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Service rules name the make next to the model, so they follow the target
+	// model into its make.
+	_, err := tx.UpdateBySql(`
+		UPDATE service_rules SET make_id = (SELECT make_id FROM models WHERE id = ?)
+		WHERE model_id = ?
+		`, targetID, targetID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Update(modelsTable).
+		Where(dbr.Or(
+			dbr.Eq("id", sourceID),
+			dbr.Eq("merged_into_id", sourceID),
+		)).
+		Set("merged_into_id", targetID).
+		Exec()
+
+	return err
+}
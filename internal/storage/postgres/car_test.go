@@ -3,6 +3,8 @@ package postgres
 import (
 	"testing"
 
+	"github.com/KsaweryZietara/garage/internal"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,3 +28,72 @@ func TestCar(t *testing.T) {
 	assert.NotEmpty(t, car.Make)
 	assert.NotEmpty(t, car.Model)
 }
+
+func TestCarCatalog(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	carRepo := NewCar(connection)
+
+	carMake, err := carRepo.InsertMake(internal.Make{Name: "Catalog Make"})
+	require.NoError(t, err)
+
+	carMake.Name = "Renamed Make"
+	err = carRepo.UpdateMake(carMake)
+	require.NoError(t, err)
+
+	loadedMake, err := carRepo.GetMakeByID(carMake.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Make", loadedMake.Name)
+
+	upsertedID, err := carRepo.UpsertMake("Renamed Make")
+	require.NoError(t, err)
+	assert.Equal(t, carMake.ID, upsertedID)
+
+	model, err := carRepo.InsertModel(internal.Model{Name: "Alpha", MakeID: carMake.ID})
+	require.NoError(t, err)
+
+	yearFrom := 2001
+	upsertedID, err = carRepo.UpsertModel(internal.Model{Name: "Alpha", MakeID: carMake.ID, YearFrom: &yearFrom})
+	require.NoError(t, err)
+	assert.Equal(t, model.ID, upsertedID)
+
+	loadedModel, err := carRepo.GetModelByID(model.ID)
+	require.NoError(t, err)
+	require.NotNil(t, loadedModel.YearFrom)
+	assert.Equal(t, yearFrom, *loadedModel.YearFrom)
+
+	duplicate, err := carRepo.InsertModel(internal.Model{Name: "Alpha 2", MakeID: carMake.ID})
+	require.NoError(t, err)
+
+	err = carRepo.MergeModels(duplicate.ID, model.ID)
+	require.NoError(t, err)
+
+	_, err = carRepo.GetModelByID(duplicate.ID)
+	assert.Error(t, err)
+
+	upsertedID, err = carRepo.UpsertModel(internal.Model{Name: "Alpha 2", MakeID: carMake.ID})
+	require.NoError(t, err)
+	assert.Equal(t, model.ID, upsertedID)
+
+	otherMake, err := carRepo.InsertMake(internal.Make{Name: "Other Make"})
+	require.NoError(t, err)
+	_, err = carRepo.InsertModel(internal.Model{Name: "Alpha", MakeID: otherMake.ID})
+	require.NoError(t, err)
+	_, err = carRepo.InsertModel(internal.Model{Name: "Beta", MakeID: otherMake.ID})
+	require.NoError(t, err)
+
+	err = carRepo.MergeMakes(otherMake.ID, carMake.ID)
+	require.NoError(t, err)
+
+	_, err = carRepo.GetMakeByID(otherMake.ID)
+	assert.Error(t, err)
+
+	models, err := carRepo.ListModels(carMake.ID)
+	require.NoError(t, err)
+	assert.Len(t, models, 2)
+
+	upsertedID, err = carRepo.UpsertMake("Other Make")
+	require.NoError(t, err)
+	assert.Equal(t, carMake.ID, upsertedID)
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	companiesTable      = "companies"
+	companyMembersTable = "company_members"
+)
+
+type Company struct {
+	connection *dbr.Connection
+}
+
+func NewCompany(connection *dbr.Connection) *Company {
+	return &Company{
+		connection: connection,
+	}
+}
+
+// Insert stores the company with the customer creating it as its admin.
+func (c *Company) Insert(company internal.Company, adminID int) (internal.Company, error) {
+	sess := c.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Company{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	err = tx.InsertInto(companiesTable).
+		Columns("name", "tax_id", "billing_email", "created_at").
+		Record(&company).
+		Returning("id").
+		Load(&company.ID)
+	if err != nil {
+		return internal.Company{}, err
+	}
+
+	_, err = tx.InsertInto(companyMembersTable).
+		Columns("company_id", "customer_id", "role").
+		Record(internal.CompanyMember{
+			CompanyID:  company.ID,
+			CustomerID: adminID,
+			Role:       internal.CompanyAdmin,
+		}).
+		Exec()
+	if err != nil {
+		return internal.Company{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.Company{}, err
+	}
+
+	return company, nil
+}
+
+func (c *Company) GetByID(ID int) (internal.Company, error) {
+	sess := c.connection.NewSession(nil)
+
+	var company internal.Company
+	err := sess.Select("*").
+		From(companiesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&company)
+
+	return company, err
+}
+
+func (c *Company) Update(company internal.Company) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(companiesTable).
+		Where(dbr.Eq("id", company.ID)).
+		Set("name", company.Name).
+		Set("tax_id", company.TaxID).
+		Set("billing_email", company.BillingEmail).
+		Exec()
+
+	return err
+}
+
+// GetMember returns the membership of the customer, customers belong to one
+// company at most.
+func (c *Company) GetMember(customerID int) (internal.CompanyMember, error) {
+	sess := c.connection.NewSession(nil)
+
+	var member internal.CompanyMember
+	err := sess.Select("*").
+		From(companyMembersTable).
+		Where(dbr.Eq("customer_id", customerID)).
+		LoadOne(&member)
+
+	return member, err
+}
+
+func (c *Company) ListMembers(companyID int) ([]internal.CompanyMember, error) {
+	sess := c.connection.NewSession(nil)
+
+	var members []internal.CompanyMember
+	_, err := sess.Select("*").
+		From(companyMembersTable).
+		Where(dbr.Eq("company_id", companyID)).
+		OrderBy("customer_id").
+		Load(&members)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (c *Company) InsertMember(member internal.CompanyMember) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.InsertInto(companyMembersTable).
+		Columns("company_id", "customer_id", "role").
+		Record(member).
+		Exec()
+
+	return err
+}
+
+func (c *Company) UpdateMember(member internal.CompanyMember) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(companyMembersTable).
+		Where(dbr.And(
+			dbr.Eq("company_id", member.CompanyID),
+			dbr.Eq("customer_id", member.CustomerID),
+		)).
+		Set("role", member.Role).
+		Exec()
+
+	return err
+}
+
+func (c *Company) DeleteMember(companyID, customerID int) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(companyMembersTable).
+		Where(dbr.And(
+			dbr.Eq("company_id", companyID),
+			dbr.Eq("customer_id", customerID),
+		)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompany(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	vehicleRepo := NewVehicle(connection)
+	appointmentRepo := NewAppointment(connection)
+	invoiceRepo := NewInvoice(connection)
+	companyRepo := NewCompany(connection)
+
+	admin, err := customerRepo.Insert(internal.Customer{Email: "admin@test.com", Password: "password123"})
+	require.NoError(t, err)
+	dispatcher, err := customerRepo.Insert(internal.Customer{Email: "dispatcher@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	company, err := companyRepo.Insert(internal.Company{
+		Name:         "Taxi",
+		TaxID:        "5260250274",
+		BillingEmail: "billing@test.com",
+		CreatedAt:    time.Now(),
+	}, admin.ID)
+	require.NoError(t, err)
+
+	retrieved, err := companyRepo.GetByID(company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "5260250274", retrieved.TaxID)
+
+	retrieved.Name = "Taxi Sp. z o.o."
+	require.NoError(t, companyRepo.Update(retrieved))
+	retrieved, err = companyRepo.GetByID(company.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Taxi Sp. z o.o.", retrieved.Name)
+
+	member, err := companyRepo.GetMember(admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, company.ID, member.CompanyID)
+	assert.Equal(t, internal.CompanyAdmin, member.Role)
+
+	err = companyRepo.InsertMember(internal.CompanyMember{CompanyID: company.ID, CustomerID: dispatcher.ID, Role: internal.CompanyDispatcher})
+	require.NoError(t, err)
+	err = companyRepo.InsertMember(internal.CompanyMember{CompanyID: company.ID, CustomerID: dispatcher.ID, Role: internal.CompanyAdmin})
+	assert.Error(t, err)
+
+	err = companyRepo.UpdateMember(internal.CompanyMember{CompanyID: company.ID, CustomerID: dispatcher.ID, Role: internal.CompanyAdmin})
+	require.NoError(t, err)
+	members, err := companyRepo.ListMembers(company.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, internal.CompanyAdmin, members[1].Role)
+
+	require.NoError(t, companyRepo.DeleteMember(company.ID, dispatcher.ID))
+	_, err = companyRepo.GetMember(dispatcher.ID)
+	assert.Error(t, err)
+
+	vehicle, err := vehicleRepo.Insert(internal.Vehicle{CustomerID: admin.ID, ModelID: 1, PlateNumber: "WX12345", CompanyID: &company.ID})
+	require.NoError(t, err)
+	_, err = vehicleRepo.Insert(internal.Vehicle{CustomerID: admin.ID, ModelID: 1, PlateNumber: "WX54321"})
+	require.NoError(t, err)
+
+	vehicles, err := vehicleRepo.ListByCompanyID(company.ID)
+	require.NoError(t, err)
+	require.Len(t, vehicles, 1)
+	assert.Equal(t, vehicle.ID, vehicles[0].ID)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     owner.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Inspection", Time: 1, Price: 10000, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	month := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var appointments []internal.Appointment
+	for _, day := range []int{2, 16} {
+		appointment, err := appointmentRepo.Insert(internal.Appointment{
+			StartTime:  month.AddDate(0, 0, day).Add(9 * time.Hour),
+			EndTime:    month.AddDate(0, 0, day).Add(10 * time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: owner.ID,
+			CustomerID: admin.ID,
+			ModelID:    1,
+			VehicleID:  &vehicle.ID,
+			CompanyID:  &company.ID,
+		})
+		require.NoError(t, err)
+		appointments = append(appointments, appointment)
+	}
+
+	history, err := appointmentRepo.ListByCompanyID(company.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, appointments[1].ID, history[0].ID)
+
+	_, err = invoiceRepo.Insert(internal.NewInvoice(appointments[0], garage, admin, []internal.InvoiceItem{
+		{Kind: internal.ServiceItem, Name: service.Name, Quantity: 1, UnitPrice: service.Price, TaxRate: garage.TaxRate},
+	}))
+	require.NoError(t, err)
+
+	uninvoiced, err := appointmentRepo.ListUninvoicedByCompanyID(company.ID, garage.ID, month, month.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Len(t, uninvoiced, 1)
+	assert.Equal(t, appointments[1].ID, uninvoiced[0].ID)
+
+	invoice, err := invoiceRepo.Insert(internal.NewCompanyInvoice(retrieved, garage, month, uninvoiced, []internal.InvoiceItem{
+		{Kind: internal.ServiceItem, Name: service.Name, Quantity: 1, UnitPrice: service.Price, TaxRate: garage.TaxRate},
+	}))
+	require.NoError(t, err)
+
+	uninvoiced, err = appointmentRepo.ListUninvoicedByCompanyID(company.ID, garage.ID, month, month.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.Empty(t, uninvoiced)
+
+	retrievedInvoice, err := invoiceRepo.GetByAppointmentID(appointments[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.ID, retrievedInvoice.ID)
+	assert.Nil(t, retrievedInvoice.CustomerID)
+	assert.Equal(t, []int{appointments[1].ID}, retrievedInvoice.AppointmentIDs)
+	assert.Equal(t, "billing@test.com", retrievedInvoice.BuyerEmail)
+
+	invoices, err := invoiceRepo.ListByCompanyID(company.ID)
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	assert.True(t, invoices[0].Period.Equal(month))
+	require.Len(t, invoices[0].Items, 1)
+}
@@ -22,7 +22,7 @@ func (c *Customer) Insert(customer internal.Customer) (internal.Customer, error)
 	sess := c.connection.NewSession(nil)
 	var id int
 	err := sess.InsertInto(customersTable).
-		Columns("email", "password").
+		Columns("email", "password", "name", "surname", "phone_number", "guest").
 		Record(customer).
 		Returning("id").
 		Load(&id)
@@ -49,3 +49,46 @@ func (c *Customer) GetByEmail(email string) (internal.Customer, error) {
 
 	return customer, nil
 }
+
+func (c *Customer) GetByID(ID int) (internal.Customer, error) {
+	var customer internal.Customer
+	sess := c.connection.NewSession(nil)
+	err := sess.Select("*").
+		From(customersTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&customer)
+
+	if err != nil {
+		return internal.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (c *Customer) UpdateProfile(customer internal.Customer) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(customersTable).
+		Where(dbr.Eq("id", customer.ID)).
+		Set("name", customer.Name).
+		Set("surname", customer.Surname).
+		Set("phone_number", customer.PhoneNumber).
+		Set("preferred_contact", customer.PreferredContact).
+		Exec()
+
+	return err
+}
+
+// Claim turns a guest into a full account with the given credentials.
+func (c *Customer) Claim(customer internal.Customer) error {
+	sess := c.connection.NewSession(nil)
+
+	_, err := sess.Update(customersTable).
+		Where(dbr.Eq("id", customer.ID)).
+		Set("email", customer.Email).
+		Set("password", customer.Password).
+		Set("guest", false).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const customerInvitationsTable = "customer_invitations"
+
+type CustomerInvitation struct {
+	connection *dbr.Connection
+}
+
+func NewCustomerInvitation(connection *dbr.Connection) *CustomerInvitation {
+	return &CustomerInvitation{
+		connection: connection,
+	}
+}
+
+func (c *CustomerInvitation) Insert(invitation internal.CustomerInvitation) (internal.CustomerInvitation, error) {
+	sess := c.connection.NewSession(nil)
+	_, err := sess.InsertInto(customerInvitationsTable).
+		Columns("id", "customer_id").
+		Record(invitation).
+		Exec()
+
+	if err != nil {
+		return internal.CustomerInvitation{}, err
+	}
+
+	return invitation, nil
+}
+
+func (c *CustomerInvitation) GetByID(ID string) (internal.CustomerInvitation, error) {
+	sess := c.connection.NewSession(nil)
+	var invitation internal.CustomerInvitation
+	err := sess.Select("*").
+		From(customerInvitationsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&invitation)
+
+	return invitation, err
+}
+
+func (c *CustomerInvitation) DeleteByCustomerID(ID int) error {
+	sess := c.connection.NewSession(nil)
+	_, err := sess.DeleteFrom(customerInvitationsTable).
+		Where(dbr.Eq("customer_id", ID)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerInvitation(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	customerRepo := NewCustomer(connection)
+	customerInvitationRepo := NewCustomerInvitation(connection)
+
+	customer, err := customerRepo.Insert(internal.NewGuest(internal.GuestDTO{
+		Name:        "John",
+		PhoneNumber: "123456789",
+		Email:       "john.doe@example.com",
+	}))
+	assert.NoError(t, err)
+
+	newInvitation := internal.CustomerInvitation{
+		ID:         uuid.New().String(),
+		CustomerID: customer.ID,
+	}
+	createdInvitation, err := customerInvitationRepo.Insert(newInvitation)
+	assert.NoError(t, err)
+	assert.Equal(t, newInvitation, createdInvitation)
+
+	invitation, err := customerInvitationRepo.GetByID(newInvitation.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, newInvitation, invitation)
+
+	err = customerInvitationRepo.DeleteByCustomerID(customer.ID)
+	assert.NoError(t, err)
+
+	_, err = customerInvitationRepo.GetByID(newInvitation.ID)
+	assert.EqualError(t, err, "dbr: not found")
+}
@@ -25,4 +25,37 @@ func TestCustomer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, newCustomer.Email, retrievedCustomer.Email)
 	assert.Equal(t, newCustomer.Password, retrievedCustomer.Password)
+	assert.Equal(t, internal.EmailContact, retrievedCustomer.PreferredContact)
+
+	retrievedCustomer.Name = "John"
+	retrievedCustomer.Surname = "Doe"
+	retrievedCustomer.PhoneNumber = "123456789"
+	retrievedCustomer.PreferredContact = internal.PhoneContact
+	err = customerRepo.UpdateProfile(retrievedCustomer)
+	assert.NoError(t, err)
+
+	updatedCustomer, err := customerRepo.GetByID(retrievedCustomer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, retrievedCustomer, updatedCustomer)
+
+	firstGuest, err := customerRepo.Insert(internal.NewGuest(internal.GuestDTO{Name: "Jan", PhoneNumber: "123456789"}))
+	assert.NoError(t, err)
+	_, err = customerRepo.Insert(internal.NewGuest(internal.GuestDTO{Name: "Anna", PhoneNumber: "987654321"}))
+	assert.NoError(t, err)
+
+	guest, err := customerRepo.GetByID(firstGuest.ID)
+	assert.NoError(t, err)
+	assert.True(t, guest.Guest)
+	assert.Equal(t, "Jan", guest.Name)
+
+	guest.Email = "jan@test.com"
+	guest.Password = "password123"
+	err = customerRepo.Claim(guest)
+	assert.NoError(t, err)
+
+	claimed, err := customerRepo.GetByEmail("jan@test.com")
+	assert.NoError(t, err)
+	assert.Equal(t, guest.ID, claimed.ID)
+	assert.False(t, claimed.Guest)
+	assert.Equal(t, "password123", claimed.Password)
 }
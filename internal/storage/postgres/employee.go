@@ -5,7 +5,11 @@ import (
 	"github.com/gocraft/dbr/v2"
 )
 
-const employeesTable = "employees"
+const (
+	employeesTable        = "employees"
+	employeeGaragesTable  = "employee_garages"
+	employeeServicesTable = "employee_services"
+)
 
 type Employee struct {
 	connection *dbr.Connection
@@ -74,7 +78,28 @@ func (e *Employee) ListConfirmedByGarageID(garageID int) ([]internal.Employee, e
 	_, err := sess.Select("*").
 		From(employeesTable).
 		Where(dbr.And(
-			dbr.Eq("garage_id", garageID),
+			worksAtGarage(garageID),
+			dbr.Eq("confirmed", true),
+			dbr.Eq("is_deleted", false),
+		)).
+		Load(&employees)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+func (e *Employee) ListQualifiedByServiceID(garageID, serviceID int) ([]internal.Employee, error) {
+	sess := e.connection.NewSession(nil)
+
+	var employees []internal.Employee
+	_, err := sess.Select("*").
+		From(employeesTable).
+		Where(dbr.And(
+			worksAtGarage(garageID),
+			qualifiedFor(serviceID),
 			dbr.Eq("confirmed", true),
 			dbr.Eq("is_deleted", false),
 		)).
@@ -94,7 +119,7 @@ func (e *Employee) ListByGarageID(garageID int) ([]internal.Employee, error) {
 	_, err := sess.Select("*").
 		From(employeesTable).
 		Where(dbr.And(
-			dbr.Eq("garage_id", garageID),
+			worksAtGarage(garageID),
 			dbr.Eq("is_deleted", false),
 		)).
 		Load(&employees)
@@ -162,3 +187,162 @@ func (e *Employee) UpdateProfilePicture(ID int, profilePicture []byte) error {
 
 	return err
 }
+
+func (e *Employee) UpdateGarageRole(ID int, garageRoleID *int) error {
+	sess := e.connection.NewSession(nil)
+
+	_, err := sess.Update(employeesTable).
+		Where(dbr.Eq("id", ID)).
+		Set("garage_role_id", garageRoleID).
+		Exec()
+
+	return err
+}
+
+func (e *Employee) ListOwnersByGarageID(garageID int) ([]internal.Employee, error) {
+	sess := e.connection.NewSession(nil)
+
+	var employees []internal.Employee
+	_, err := sess.Select("e.*").
+		From(dbr.I(employeesTable).As("e")).
+		Join(dbr.I(garageOwnersTable).As("o"), "o.employee_id = e.id").
+		Where(dbr.And(
+			dbr.Eq("o.garage_id", garageID),
+			dbr.Eq("e.is_deleted", false),
+		)).
+		OrderBy("e.id").
+		Load(&employees)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+func (e *Employee) AddGarage(ID, garageID int) error {
+	sess := e.connection.NewSession(nil)
+
+	_, err := sess.InsertInto(employeeGaragesTable).
+		Pair("employee_id", ID).
+		Pair("garage_id", garageID).
+		Exec()
+
+	return err
+}
+
+func (e *Employee) RemoveGarage(ID, garageID int) error {
+	sess := e.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(employeeGaragesTable).
+		Where(dbr.And(
+			dbr.Eq("employee_id", ID),
+			dbr.Eq("garage_id", garageID),
+		)).
+		Exec()
+
+	return err
+}
+
+func (e *Employee) UpdateActiveGarage(ID int, garageID *int) error {
+	sess := e.connection.NewSession(nil)
+
+	_, err := sess.Update(employeesTable).
+		Where(dbr.Eq("id", ID)).
+		Set("active_garage_id", garageID).
+		Exec()
+
+	return err
+}
+
+func (e *Employee) IsQualified(ID, serviceID int) (bool, error) {
+	sess := e.connection.NewSession(nil)
+
+	var count int
+	err := sess.Select("COUNT(*)").
+		From(employeesTable).
+		Where(dbr.And(
+			dbr.Eq("id", ID),
+			qualifiedFor(serviceID),
+		)).
+		LoadOne(&count)
+
+	return count > 0, err
+}
+
+func (e *Employee) ListServiceIDs(ID, garageID int) ([]int, error) {
+	sess := e.connection.NewSession(nil)
+
+	var serviceIDs []int
+	_, err := sess.Select("es.service_id").
+		From(dbr.I(employeeServicesTable).As("es")).
+		Join(dbr.I(servicesTable).As("s"), "s.id = es.service_id").
+		Where(dbr.And(
+			dbr.Eq("es.employee_id", ID),
+			dbr.Eq("s.garage_id", garageID),
+			dbr.Eq("s.is_deleted", false),
+		)).
+		OrderBy("es.service_id").
+		Load(&serviceIDs)
+
+	return serviceIDs, err
+}
+
+// UpdateServices replaces the services the employee is qualified for within
+// the given garage. Assignments in other garages are kept.
+func (e *Employee) UpdateServices(ID, garageID int, serviceIDs []int) error {
+	sess := e.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.DeleteFrom(employeeServicesTable).
+		Where(dbr.And(
+			dbr.Eq("employee_id", ID),
+			dbr.Expr("service_id IN (SELECT id FROM services WHERE garage_id = ?)", garageID),
+		)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, serviceID := range serviceIDs {
+		_, err = tx.InsertInto(employeeServicesTable).
+			Pair("employee_id", ID).
+			Pair("service_id", serviceID).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// worksAtGarage matches employees whose main garage is the given one as well
+// as those additionally assigned to it.
+func worksAtGarage(garageID int) dbr.Builder {
+	return dbr.Or(
+		dbr.Eq("garage_id", garageID),
+		dbr.Expr("id IN (SELECT employee_id FROM employee_garages WHERE garage_id = ?)", garageID),
+	)
+}
+
+// qualifiedFor matches employees assigned to the given service as well as those
+// without any assignment in the garage of the service, who may perform all of
+// its services.
+func qualifiedFor(serviceID int) dbr.Builder {
+	return dbr.Or(
+		dbr.Expr(`NOT EXISTS (
+			SELECT 1 FROM employee_services AS es
+			JOIN services AS s ON s.id = es.service_id
+			WHERE es.employee_id = employees.id
+			AND s.is_deleted = FALSE
+			AND s.garage_id = (SELECT garage_id FROM services WHERE id = ?)
+		)`, serviceID),
+		dbr.Expr("id IN (SELECT employee_id FROM employee_services WHERE service_id = ?)", serviceID),
+	)
+}
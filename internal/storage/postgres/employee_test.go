@@ -167,4 +167,33 @@ func TestEmployee(t *testing.T) {
 	employee, err = employeeRepo.GetByID(employee4.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, profilePicture, employee.ProfilePicture)
+
+	serviceRepo := NewService(connection)
+	service1, err := serviceRepo.Insert(internal.Service{Name: "Oil", Time: 1, Price: 100, GarageID: garage.ID})
+	assert.NoError(t, err)
+	service2, err := serviceRepo.Insert(internal.Service{Name: "Bodywork", Time: 1, Price: 100, GarageID: garage.ID})
+	assert.NoError(t, err)
+
+	err = employeeRepo.UpdateServices(employee4.ID, garage.ID, []int{service1.ID})
+	assert.NoError(t, err)
+
+	serviceIDs, err := employeeRepo.ListServiceIDs(employee4.ID, garage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{service1.ID}, serviceIDs)
+
+	qualified, err := employeeRepo.IsQualified(employee4.ID, service1.ID)
+	assert.NoError(t, err)
+	assert.True(t, qualified)
+
+	qualified, err = employeeRepo.IsQualified(employee4.ID, service2.ID)
+	assert.NoError(t, err)
+	assert.False(t, qualified)
+
+	employees, err = employeeRepo.ListQualifiedByServiceID(garage.ID, service1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(employees))
+
+	employees, err = employeeRepo.ListQualifiedByServiceID(garage.ID, service2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(employees))
 }
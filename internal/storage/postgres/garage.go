@@ -9,8 +9,9 @@ import (
 )
 
 const (
-	garagesTable = "garages"
-	pageSize     = 20
+	garagesTable      = "garages"
+	garageOwnersTable = "garage_owners"
+	pageSize          = 20
 )
 
 type Garage struct {
@@ -23,36 +24,42 @@ func NewGarage(connection *dbr.Connection) *Garage {
 	}
 }
 
+// Insert creates the garage and makes the employee in OwnerID its first owner.
 func (g *Garage) Insert(garage internal.Garage) (internal.Garage, error) {
 	sess := g.connection.NewSession(nil)
 
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Garage{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
 	var id int
-	err := sess.InsertInto(garagesTable).
-		Columns("name", "city", "street", "number", "postal_code", "phone_number", "latitude", "longitude", "owner_id").
+	err = tx.InsertInto(garagesTable).
+		Columns("name", "city", "street", "number", "postal_code", "phone_number", "latitude", "longitude", "owner_id", "organization_id", "currency", "tax_rate", "waitlist_mode").
 		Record(garage).
 		Returning("id").
 		Load(&id)
+	if err != nil {
+		return internal.Garage{}, err
+	}
 
+	_, err = tx.InsertInto(garageOwnersTable).
+		Pair("garage_id", id).
+		Pair("employee_id", garage.OwnerID).
+		Exec()
 	if err != nil {
 		return internal.Garage{}, err
 	}
 
+	if err = tx.Commit(); err != nil {
+		return internal.Garage{}, err
+	}
+
 	garage.ID = id
 	return garage, nil
 }
 
-func (g *Garage) GetByOwnerID(employeeID int) (internal.Garage, error) {
-	sess := g.connection.NewSession(nil)
-
-	var garage internal.Garage
-	err := sess.Select("*").
-		From(garagesTable).
-		Where(dbr.Eq("owner_id", employeeID)).
-		LoadOne(&garage)
-
-	return garage, err
-}
-
 func (g *Garage) GetByID(ID int) (internal.Garage, error) {
 	sess := g.connection.NewSession(nil)
 
@@ -85,8 +92,7 @@ func (g *Garage) List(page int, query string, latitude, longitude float64, sortB
         	    * sin( radians(g.latitude) ) ) ), 0) AS distance
         	FROM garages AS g
         	LEFT JOIN services AS s ON s.garage_id = g.id
-        	LEFT JOIN employees AS e ON e.garage_id = g.id
-        	LEFT JOIN appointments AS a ON a.employee_id = e.id
+        	LEFT JOIN appointments AS a ON a.service_id = s.id
         	WHERE LOWER(g.name) LIKE ? OR LOWER(s.name) LIKE ?
         	GROUP BY g.id, g.name, city, street, number, postal_code, phone_number, owner_id
 			ORDER BY distance
@@ -102,8 +108,7 @@ func (g *Garage) List(page int, query string, latitude, longitude float64, sortB
         	    * sin( radians(g.latitude) ) ) ), 0) AS distance
         	FROM garages AS g
         	LEFT JOIN services AS s ON s.garage_id = g.id
-        	LEFT JOIN employees AS e ON e.garage_id = g.id
-        	LEFT JOIN appointments AS a ON a.employee_id = e.id
+        	LEFT JOIN appointments AS a ON a.service_id = s.id
         	WHERE LOWER(g.name) LIKE ? OR LOWER(s.name) LIKE ?
         	GROUP BY g.id, g.name, city, street, number, postal_code, phone_number, owner_id
 			ORDER BY rating DESC
@@ -117,8 +122,7 @@ func (g *Garage) List(page int, query string, latitude, longitude float64, sortB
         SELECT DISTINCT g.*, COALESCE(AVG(a.rating), 0) AS rating
         FROM garages AS g
         LEFT JOIN services AS s ON s.garage_id = g.id
-        LEFT JOIN employees AS e ON e.garage_id = g.id
-        LEFT JOIN appointments AS a ON a.employee_id = e.id
+        LEFT JOIN appointments AS a ON a.service_id = s.id
         WHERE LOWER(g.name) LIKE ? OR LOWER(s.name) LIKE ?
         GROUP BY g.id, g.name, city, street, number, postal_code, phone_number, owner_id
 		ORDER BY rating DESC
@@ -148,6 +152,9 @@ func (g *Garage) Update(garage internal.Garage) error {
 		Set("phone_number", garage.PhoneNumber).
 		Set("latitude", garage.Latitude).
 		Set("longitude", garage.Longitude).
+		Set("currency", garage.Currency).
+		Set("tax_rate", garage.TaxRate).
+		Set("waitlist_mode", garage.WaitlistMode).
 		Exec()
 
 	return err
@@ -163,3 +170,138 @@ func (g *Garage) UpdateLogo(garageID int, logo []byte) error {
 
 	return err
 }
+
+// AddOwner makes the employee an owner of the garage, granting them the owner
+// role.
+func (g *Garage) AddOwner(garageID, employeeID int) error {
+	sess := g.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.InsertInto(garageOwnersTable).
+		Pair("garage_id", garageID).
+		Pair("employee_id", employeeID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Update(employeesTable).
+		Where(dbr.Eq("id", employeeID)).
+		Set("role", internal.OwnerRole).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveOwner stops the employee from owning the garage, keeping them at the
+// location as a mechanic.
+func (g *Garage) RemoveOwner(garageID, employeeID int) error {
+	sess := g.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.DeleteFrom(garageOwnersTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", garageID),
+			dbr.Eq("employee_id", employeeID),
+		)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if err = demoteOwner(tx, garageID, employeeID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// demoteOwner makes the former owner a mechanic of the garage, unless they
+// still own another garage.
+func demoteOwner(tx *dbr.Tx, garageID, employeeID int) error {
+	var count int
+	err := tx.Select("COUNT(*)").
+		From(garageOwnersTable).
+		Where(dbr.Eq("employee_id", employeeID)).
+		LoadOne(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	_, err = tx.Update(employeesTable).
+		Where(dbr.Eq("id", employeeID)).
+		Set("role", internal.MechanicRole).
+		Set("garage_id", garageID).
+		Set("garage_role_id", nil).
+		Exec()
+
+	return err
+}
+
+// ListByEmployeeID returns every garage the employee works at, either as one of
+// its owners or as a mechanic assigned to the location.
+func (g *Garage) ListByEmployeeID(employeeID int) ([]internal.Garage, error) {
+	sess := g.connection.NewSession(nil)
+
+	var garages []internal.Garage
+	_, err := sess.SelectBySql(`
+		SELECT g.*
+		FROM garages AS g
+		WHERE g.id IN (
+			SELECT garage_id FROM garage_owners WHERE employee_id = ?
+			UNION
+			SELECT garage_id FROM employees WHERE id = ? AND garage_id IS NOT NULL
+			UNION
+			SELECT garage_id FROM employee_garages WHERE employee_id = ?
+		)
+		ORDER BY g.id
+		`, employeeID, employeeID, employeeID).
+		Load(&garages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return garages, nil
+}
+
+func (g *Garage) ListByOrganizationID(organizationID int) ([]internal.Garage, error) {
+	sess := g.connection.NewSession(nil)
+
+	var garages []internal.Garage
+	_, err := sess.Select("*").
+		From(garagesTable).
+		Where(dbr.Eq("organization_id", organizationID)).
+		OrderBy("id").
+		Load(&garages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return garages, nil
+}
+
+func (g *Garage) UpdateOrganization(ID int, organizationID *int) error {
+	sess := g.connection.NewSession(nil)
+
+	_, err := sess.Update(garagesTable).
+		Where(dbr.Eq("id", ID)).
+		Set("organization_id", organizationID).
+		Exec()
+
+	return err
+}
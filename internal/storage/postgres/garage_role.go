@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	garageRolesTable           = "garage_roles"
+	garageRolePermissionsTable = "garage_role_permissions"
+)
+
+type GarageRole struct {
+	connection *dbr.Connection
+}
+
+func NewGarageRole(connection *dbr.Connection) *GarageRole {
+	return &GarageRole{
+		connection: connection,
+	}
+}
+
+func (g *GarageRole) Insert(role internal.GarageRole) (internal.GarageRole, error) {
+	sess := g.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.GarageRole{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var id int
+	err = tx.InsertInto(garageRolesTable).
+		Columns("name", "garage_id").
+		Record(role).
+		Returning("id").
+		Load(&id)
+	if err != nil {
+		return internal.GarageRole{}, err
+	}
+
+	if err = insertPermissions(tx, id, role.Permissions); err != nil {
+		return internal.GarageRole{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.GarageRole{}, err
+	}
+
+	role.ID = id
+	return role, nil
+}
+
+func (g *GarageRole) GetByID(ID int) (internal.GarageRole, error) {
+	sess := g.connection.NewSession(nil)
+
+	var role internal.GarageRole
+	err := sess.Select("*").
+		From(garageRolesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&role)
+	if err != nil {
+		return internal.GarageRole{}, err
+	}
+
+	role.Permissions, err = loadPermissions(sess, role.ID)
+	if err != nil {
+		return internal.GarageRole{}, err
+	}
+
+	return role, nil
+}
+
+func (g *GarageRole) ListByGarageID(garageID int) ([]internal.GarageRole, error) {
+	sess := g.connection.NewSession(nil)
+
+	var roles []internal.GarageRole
+	_, err := sess.Select("*").
+		From(garageRolesTable).
+		Where(dbr.Eq("garage_id", garageID)).
+		OrderBy("name").
+		Load(&roles)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roles {
+		roles[i].Permissions, err = loadPermissions(sess, roles[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+func (g *GarageRole) Update(role internal.GarageRole) error {
+	sess := g.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.Update(garageRolesTable).
+		Where(dbr.Eq("id", role.ID)).
+		Set("name", role.Name).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.DeleteFrom(garageRolePermissionsTable).
+		Where(dbr.Eq("garage_role_id", role.ID)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if err = insertPermissions(tx, role.ID, role.Permissions); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (g *GarageRole) Delete(ID int) error {
+	sess := g.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(garageRolesTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
+
+func insertPermissions(tx *dbr.Tx, roleID int, permissions []internal.Permission) error {
+	for _, permission := range permissions {
+		_, err := tx.InsertInto(garageRolePermissionsTable).
+			Pair("garage_role_id", roleID).
+			Pair("permission", permission).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadPermissions(sess *dbr.Session, roleID int) ([]internal.Permission, error) {
+	var permissions []internal.Permission
+	_, err := sess.Select("permission").
+		From(garageRolePermissionsTable).
+		Where(dbr.Eq("garage_role_id", roleID)).
+		OrderBy("permission").
+		Load(&permissions)
+
+	return permissions, err
+}
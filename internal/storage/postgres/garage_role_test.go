@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGarageRole(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	garageRoleRepo := NewGarageRole(connection)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     owner.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	assert.NoError(t, err)
+
+	role, err := garageRoleRepo.Insert(internal.GarageRole{
+		Name:     "receptionist",
+		GarageID: garage.ID,
+		Permissions: []internal.Permission{
+			internal.AppointmentsManagePermission,
+		},
+	})
+	assert.NoError(t, err)
+
+	mechanic, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test2@test.com",
+		Password:  "password123",
+		Role:      internal.MechanicRole,
+		GarageID:  &garage.ID,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	err = employeeRepo.UpdateGarageRole(mechanic.ID, &role.ID)
+	assert.NoError(t, err)
+
+	retrievedMechanic, err := employeeRepo.GetByID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, role.ID, *retrievedMechanic.GarageRoleID)
+
+	retrievedRole, err := garageRoleRepo.GetByID(role.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "receptionist", retrievedRole.Name)
+	assert.Equal(t, []internal.Permission{internal.AppointmentsManagePermission}, retrievedRole.Permissions)
+
+	retrievedRole.Name = "manager"
+	retrievedRole.Permissions = []internal.Permission{
+		internal.ServicesWritePermission,
+		internal.StaffManagePermission,
+	}
+	err = garageRoleRepo.Update(retrievedRole)
+	assert.NoError(t, err)
+
+	roles, err := garageRoleRepo.ListByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, "manager", roles[0].Name)
+	assert.True(t, roles[0].HasPermission(internal.ServicesWritePermission))
+	assert.False(t, roles[0].HasPermission(internal.AppointmentsManagePermission))
+
+	err = garageRoleRepo.Delete(role.ID)
+	assert.NoError(t, err)
+
+	retrievedMechanic, err = employeeRepo.GetByID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, retrievedMechanic.GarageRoleID)
+
+	roles, err = garageRoleRepo.ListByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 0)
+}
@@ -40,11 +40,12 @@ func TestGarage(t *testing.T) {
 	createdGarage, err := garageRepo.Insert(newGarage)
 	assert.NoError(t, err)
 
-	garage, err := garageRepo.GetByOwnerID(employee.ID)
+	owners, err := employeeRepo.ListOwnersByGarageID(createdGarage.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, createdGarage, garage)
+	assert.Len(t, owners, 1)
+	assert.Equal(t, employee.ID, owners[0].ID)
 
-	garage, err = garageRepo.GetByID(createdGarage.ID)
+	garage, err := garageRepo.GetByID(createdGarage.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, createdGarage, garage)
 
@@ -139,3 +140,54 @@ func TestListGarage(t *testing.T) {
 		assert.Equal(t, tc.expectedCount, len(garages))
 	}
 }
+
+func TestRemoveGarageOwner(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	var garages []internal.Garage
+	for _, name := range []string{"First Garage", "Second Garage"} {
+		garage, err := garageRepo.Insert(internal.Garage{
+			Name:        name,
+			City:        "Test City",
+			Street:      "Test Street",
+			Number:      "123",
+			PostalCode:  "12345",
+			PhoneNumber: "1234567890",
+			OwnerID:     owner.ID,
+			Latitude:    10,
+			Longitude:   10,
+		})
+		assert.NoError(t, err)
+		garages = append(garages, garage)
+	}
+
+	err = garageRepo.RemoveOwner(garages[0].ID, owner.ID)
+	assert.NoError(t, err)
+
+	employee, err := employeeRepo.GetByID(owner.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, internal.OwnerRole, employee.Role)
+	assert.Nil(t, employee.GarageID)
+
+	err = garageRepo.RemoveOwner(garages[1].ID, owner.ID)
+	assert.NoError(t, err)
+
+	employee, err = employeeRepo.GetByID(owner.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, internal.MechanicRole, employee.Role)
+	assert.Equal(t, &garages[1].ID, employee.GarageID)
+}
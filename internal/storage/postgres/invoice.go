@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	invoicesTable            = "invoices"
+	invoiceItemsTable        = "invoice_items"
+	invoiceAppointmentsTable = "invoice_appointments"
+)
+
+type Invoice struct {
+	connection *dbr.Connection
+}
+
+func NewInvoice(connection *dbr.Connection) *Invoice {
+	return &Invoice{
+		connection: connection,
+	}
+}
+
+// Insert stores the invoice with the next number of its garage. The garage row
+// stays locked until the transaction ends, so concurrent invoices of the same
+// garage never share a number or leave gaps.
+func (i *Invoice) Insert(invoice internal.Invoice) (internal.Invoice, error) {
+	sess := i.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Invoice{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	err = tx.UpdateBySql(`
+		UPDATE garages SET last_invoice_number = last_invoice_number + 1
+		WHERE id = ?
+		RETURNING last_invoice_number
+		`, invoice.GarageID).
+		Load(&invoice.Number)
+	if err != nil {
+		return internal.Invoice{}, err
+	}
+
+	err = tx.InsertInto(invoicesTable).
+		Columns("garage_id", "appointment_id", "customer_id", "company_id", "period", "number", "issued_at", "currency", "seller_name", "seller_address", "buyer_name", "buyer_email").
+		Record(invoice).
+		Returning("id").
+		Load(&invoice.ID)
+	if err != nil {
+		return internal.Invoice{}, err
+	}
+
+	for _, appointmentID := range invoice.AppointmentIDs {
+		_, err = tx.InsertInto(invoiceAppointmentsTable).
+			Pair("invoice_id", invoice.ID).
+			Pair("appointment_id", appointmentID).
+			Exec()
+		if err != nil {
+			return internal.Invoice{}, err
+		}
+	}
+
+	for j := range invoice.Items {
+		invoice.Items[j].InvoiceID = invoice.ID
+		err = tx.InsertInto(invoiceItemsTable).
+			Columns("invoice_id", "kind", "name", "quantity", "unit_price", "tax_rate").
+			Record(invoice.Items[j]).
+			Returning("id").
+			Load(&invoice.Items[j].ID)
+		if err != nil {
+			return internal.Invoice{}, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+// GetByAppointmentID returns the invoice covering the appointment together
+// with its items, which for companies is the invoice of the whole month.
+func (i *Invoice) GetByAppointmentID(appointmentID int) (internal.Invoice, error) {
+	sess := i.connection.NewSession(nil)
+
+	var invoice internal.Invoice
+	err := sess.Select("i.*").
+		From(dbr.I(invoicesTable).As("i")).
+		Join(dbr.I(invoiceAppointmentsTable).As("ia"), "ia.invoice_id = i.id").
+		Where(dbr.Eq("ia.appointment_id", appointmentID)).
+		LoadOne(&invoice)
+	if err != nil {
+		return internal.Invoice{}, err
+	}
+
+	return invoice, i.loadDetails(sess, &invoice)
+}
+
+func (i *Invoice) GetByID(ID int) (internal.Invoice, error) {
+	sess := i.connection.NewSession(nil)
+
+	var invoice internal.Invoice
+	err := sess.Select("*").
+		From(invoicesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&invoice)
+	if err != nil {
+		return internal.Invoice{}, err
+	}
+
+	return invoice, i.loadDetails(sess, &invoice)
+}
+
+// ListByCompanyID returns the monthly invoices of the company issued by all
+// garages, newest first.
+func (i *Invoice) ListByCompanyID(companyID int) ([]internal.Invoice, error) {
+	sess := i.connection.NewSession(nil)
+
+	var invoices []internal.Invoice
+	_, err := sess.Select("*").
+		From(invoicesTable).
+		Where(dbr.Eq("company_id", companyID)).
+		OrderBy("issued_at DESC").
+		Load(&invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	for j := range invoices {
+		if err = i.loadDetails(sess, &invoices[j]); err != nil {
+			return nil, err
+		}
+	}
+
+	return invoices, nil
+}
+
+// loadDetails fills in the items and appointments of the invoice.
+func (i *Invoice) loadDetails(sess *dbr.Session, invoice *internal.Invoice) error {
+	_, err := sess.Select("*").
+		From(invoiceItemsTable).
+		Where(dbr.Eq("invoice_id", invoice.ID)).
+		OrderBy("id").
+		Load(&invoice.Items)
+	if err != nil {
+		return err
+	}
+
+	_, err = sess.Select("appointment_id").
+		From(invoiceAppointmentsTable).
+		Where(dbr.Eq("invoice_id", invoice.ID)).
+		OrderBy("appointment_id").
+		Load(&invoice.AppointmentIDs)
+
+	return err
+}
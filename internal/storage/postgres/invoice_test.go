@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	invoiceRepo := NewInvoice(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	var numbers []int
+	for i := 0; i < 2; i++ {
+		appointment, err := appointmentRepo.Insert(internal.Appointment{
+			StartTime:  time.Now().Add(-2 * time.Hour),
+			EndTime:    time.Now().Add(-time.Hour),
+			ServiceID:  service.ID,
+			EmployeeID: employee.ID,
+			CustomerID: customer.ID,
+			ModelID:    1,
+		})
+		require.NoError(t, err)
+
+		invoice, err := invoiceRepo.Insert(internal.NewInvoice(appointment, garage, customer, []internal.InvoiceItem{
+			{Kind: internal.ServiceItem, Name: service.Name, Quantity: 1, UnitPrice: service.Price, TaxRate: garage.TaxRate},
+			{Kind: internal.PartItem, Name: "Oil filter", Quantity: 2, UnitPrice: 2460, TaxRate: garage.TaxRate},
+		}))
+		require.NoError(t, err)
+		numbers = append(numbers, invoice.Number)
+
+		retrievedInvoice, err := invoiceRepo.GetByAppointmentID(appointment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, invoice.ID, retrievedInvoice.ID)
+		assert.Equal(t, "test@test.com", retrievedInvoice.BuyerName)
+		require.Len(t, retrievedInvoice.Items, 2)
+		assert.Equal(t, internal.PartItem, retrievedInvoice.Items[1].Kind)
+		assert.Equal(t, 4920, retrievedInvoice.Items[1].Total())
+
+		_, err = invoiceRepo.Insert(internal.NewInvoice(appointment, garage, customer, nil))
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, []int{1, 2}, numbers)
+}
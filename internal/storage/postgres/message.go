@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const messagesTable = "messages"
+
+type Message struct {
+	connection *dbr.Connection
+}
+
+func NewMessage(connection *dbr.Connection) *Message {
+	return &Message{
+		connection: connection,
+	}
+}
+
+func (m *Message) Insert(message internal.Message) (internal.Message, error) {
+	sess := m.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(messagesTable).
+		Columns("appointment_id", "sender_role", "customer_id", "employee_id", "content", "created_at").
+		Record(message).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Message{}, err
+	}
+
+	message.ID = id
+	return message, nil
+}
+
+func (m *Message) ListByAppointmentID(appointmentID int) ([]internal.Message, error) {
+	sess := m.connection.NewSession(nil)
+
+	var messages []internal.Message
+	_, err := sess.Select("*").
+		From(messagesTable).
+		Where(dbr.Eq("appointment_id", appointmentID)).
+		OrderBy("id").
+		Load(&messages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MarkAsRead marks the unread messages of the appointment sent by the
+// customer, or by the garage staff when fromCustomer is false, as read.
+func (m *Message) MarkAsRead(appointmentID int, fromCustomer bool) error {
+	sess := m.connection.NewSession(nil)
+
+	_, err := sess.Update(messagesTable).
+		Where(dbr.And(
+			dbr.Eq("appointment_id", appointmentID),
+			dbr.Eq("read_at", nil),
+			senderCondition(fromCustomer),
+		)).
+		Set("read_at", time.Now()).
+		Exec()
+
+	return err
+}
+
+// CountUnread returns the number of unread messages per appointment sent by
+// the customer, or by the garage staff when fromCustomer is false.
+func (m *Message) CountUnread(appointmentIDs []int, fromCustomer bool) (map[int]int, error) {
+	counts := make(map[int]int, len(appointmentIDs))
+	if len(appointmentIDs) == 0 {
+		return counts, nil
+	}
+
+	sess := m.connection.NewSession(nil)
+
+	var rows []struct {
+		AppointmentID int
+		Count         int
+	}
+	_, err := sess.Select("appointment_id", "COUNT(*) AS count").
+		From(messagesTable).
+		Where(dbr.And(
+			dbr.Eq("appointment_id", appointmentIDs),
+			dbr.Eq("read_at", nil),
+			senderCondition(fromCustomer),
+		)).
+		GroupBy("appointment_id").
+		Load(&rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.AppointmentID] = row.Count
+	}
+
+	return counts, nil
+}
+
+func senderCondition(fromCustomer bool) dbr.Builder {
+	if fromCustomer {
+		return dbr.Eq("sender_role", internal.CustomerRole)
+	}
+	return dbr.Neq("sender_role", internal.CustomerRole)
+}
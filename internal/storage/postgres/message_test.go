@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	messageRepo := NewMessage(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now().Add(time.Hour),
+		EndTime:    time.Now().Add(2 * time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	dto := internal.CreateMessageDTO{Content: "Is the car ready?"}
+	for i := 0; i < 2; i++ {
+		_, err = messageRepo.Insert(internal.NewCustomerMessage(dto, appointment.ID, customer.ID))
+		require.NoError(t, err)
+	}
+	_, err = messageRepo.Insert(internal.NewEmployeeMessage(internal.CreateMessageDTO{Content: "Not yet"}, appointment.ID, employee))
+	require.NoError(t, err)
+
+	counts, err := messageRepo.CountUnread([]int{appointment.ID}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts[appointment.ID])
+
+	err = messageRepo.MarkAsRead(appointment.ID, true)
+	require.NoError(t, err)
+
+	counts, err = messageRepo.CountUnread([]int{appointment.ID}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, counts[appointment.ID])
+
+	counts, err = messageRepo.CountUnread([]int{appointment.ID}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[appointment.ID])
+
+	messages, err := messageRepo.ListByAppointmentID(appointment.ID)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	assert.True(t, messages[0].FromCustomer())
+	assert.NotNil(t, messages[0].ReadAt)
+	assert.Nil(t, messages[2].ReadAt)
+}
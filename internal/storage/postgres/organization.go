@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const organizationsTable = "organizations"
+
+type Organization struct {
+	connection *dbr.Connection
+}
+
+func NewOrganization(connection *dbr.Connection) *Organization {
+	return &Organization{
+		connection: connection,
+	}
+}
+
+func (o *Organization) Insert(organization internal.Organization) (internal.Organization, error) {
+	sess := o.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(organizationsTable).
+		Columns("name").
+		Record(organization).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Organization{}, err
+	}
+
+	organization.ID = id
+	return organization, nil
+}
+
+func (o *Organization) GetByID(ID int) (internal.Organization, error) {
+	sess := o.connection.NewSession(nil)
+
+	var organization internal.Organization
+	err := sess.Select("*").
+		From(organizationsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&organization)
+
+	if err != nil {
+		return internal.Organization{}, err
+	}
+
+	return organization, nil
+}
+
+func (o *Organization) Report(ID int, from, to time.Time) ([]internal.GarageReport, error) {
+	sess := o.connection.NewSession(nil)
+
+	var reports []internal.GarageReport
+	_, err := sess.SelectBySql(`
+		SELECT g.id AS garage_id, g.name AS garage_name, g.currency,
+		    COUNT(a.id) AS appointments,
+		    COALESCE(SUM(li.price), 0) AS revenue,
+		    COALESCE(SUM(li.net), 0) AS revenue_net,
+		    COUNT(a.rating) AS reviews,
+		    COALESCE(AVG(a.rating), 0) AS rating
+		FROM garages AS g
+		LEFT JOIN services AS s ON s.garage_id = g.id
+		LEFT JOIN appointments AS a ON a.service_id = s.id AND a.start_time >= ? AND a.start_time < ?
+		LEFT JOIN (
+		    SELECT appointment_id, SUM(price) AS price,
+		        SUM((price * 10000 + (10000 + tax_rate) / 2) / (10000 + tax_rate)) AS net
+		    FROM appointment_services GROUP BY appointment_id
+		) AS li ON li.appointment_id = a.id
+		WHERE g.organization_id = ?
+		GROUP BY g.id, g.name, g.currency
+		ORDER BY g.id
+		`, from, to, ID).
+		Load(&reports)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganization(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	appointmentRepo := NewAppointment(connection)
+	customerRepo := NewCustomer(connection)
+	organizationRepo := NewOrganization(connection)
+
+	organization, err := organizationRepo.Insert(internal.Organization{Name: "Garage Group"})
+	assert.NoError(t, err)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	garage1, err := garageRepo.Insert(internal.Garage{
+		Name:           "Garage 1",
+		City:           "Test City",
+		Street:         "Test Street",
+		Number:         "123",
+		PostalCode:     "12345",
+		PhoneNumber:    "1234567890",
+		OwnerID:        owner.ID,
+		OrganizationID: &organization.ID,
+		Latitude:       10,
+		Longitude:      10,
+	})
+	assert.NoError(t, err)
+
+	garage2, err := garageRepo.Insert(internal.Garage{
+		Name:        "Garage 2",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "124",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     owner.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	assert.NoError(t, err)
+
+	err = garageRepo.UpdateOrganization(garage2.ID, &organization.ID)
+	assert.NoError(t, err)
+
+	garages, err := garageRepo.ListByOrganizationID(organization.ID)
+	assert.NoError(t, err)
+	assert.Len(t, garages, 2)
+
+	garages, err = garageRepo.ListByEmployeeID(owner.ID)
+	assert.NoError(t, err)
+	assert.Len(t, garages, 2)
+
+	mechanic, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test2@test.com",
+		Password:  "password123",
+		Role:      internal.MechanicRole,
+		GarageID:  &garage1.ID,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	err = employeeRepo.AddGarage(mechanic.ID, garage2.ID)
+	assert.NoError(t, err)
+
+	garages, err = garageRepo.ListByEmployeeID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Len(t, garages, 2)
+
+	employees, err := employeeRepo.ListConfirmedByGarageID(garage2.ID)
+	assert.NoError(t, err)
+	assert.Len(t, employees, 1)
+	assert.Equal(t, mechanic.ID, employees[0].ID)
+
+	err = employeeRepo.UpdateActiveGarage(mechanic.ID, &garage2.ID)
+	assert.NoError(t, err)
+
+	mechanic, err = employeeRepo.GetByID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, garage2.ID, *mechanic.ActiveGarageID)
+
+	service, err := serviceRepo.Insert(internal.Service{
+		Name:     "Oil change",
+		Time:     1,
+		Price:    100,
+		GarageID: garage2.ID,
+	})
+	assert.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{
+		Email:    "test@test.com",
+		Password: "password123",
+	})
+	assert.NoError(t, err)
+
+	startTime := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	_, err = appointmentRepo.Insert(internal.Appointment{
+		StartTime:  startTime,
+		EndTime:    startTime.Add(time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: mechanic.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	assert.NoError(t, err)
+
+	reports, err := organizationRepo.Report(organization.ID, startTime.AddDate(0, 0, -1), startTime.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, 0, reports[0].Appointments)
+	assert.Equal(t, 1, reports[1].Appointments)
+	assert.Equal(t, 100, reports[1].Revenue)
+	assert.Equal(t, garage2.Pricing().Net(100), reports[1].RevenueNet)
+	assert.Equal(t, garage2.Currency, reports[1].Currency)
+
+	err = employeeRepo.RemoveGarage(mechanic.ID, garage2.ID)
+	assert.NoError(t, err)
+
+	garages, err = garageRepo.ListByEmployeeID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Len(t, garages, 1)
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const ownershipTransfersTable = "ownership_transfers"
+
+type OwnershipTransfer struct {
+	connection *dbr.Connection
+}
+
+func NewOwnershipTransfer(connection *dbr.Connection) *OwnershipTransfer {
+	return &OwnershipTransfer{
+		connection: connection,
+	}
+}
+
+func (o *OwnershipTransfer) Insert(transfer internal.OwnershipTransfer) (internal.OwnershipTransfer, error) {
+	sess := o.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(ownershipTransfersTable).
+		Columns("garage_id", "from_employee_id", "to_employee_id").
+		Record(transfer).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.OwnershipTransfer{}, err
+	}
+
+	transfer.ID = id
+	return transfer, nil
+}
+
+func (o *OwnershipTransfer) GetByID(ID int) (internal.OwnershipTransfer, error) {
+	sess := o.connection.NewSession(nil)
+
+	var transfer internal.OwnershipTransfer
+	err := sess.Select("*").
+		From(ownershipTransfersTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&transfer)
+
+	if err != nil {
+		return internal.OwnershipTransfer{}, err
+	}
+
+	return transfer, nil
+}
+
+func (o *OwnershipTransfer) ListPendingByEmployeeID(employeeID int) ([]internal.OwnershipTransfer, error) {
+	sess := o.connection.NewSession(nil)
+
+	var transfers []internal.OwnershipTransfer
+	_, err := sess.Select("*").
+		From(ownershipTransfersTable).
+		Where(dbr.And(
+			dbr.Or(
+				dbr.Eq("from_employee_id", employeeID),
+				dbr.Eq("to_employee_id", employeeID),
+			),
+			dbr.Eq("completed", false),
+		)).
+		OrderBy("created_at DESC").
+		Load(&transfers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (o *OwnershipTransfer) ListPendingByGarageID(garageID int) ([]internal.OwnershipTransfer, error) {
+	sess := o.connection.NewSession(nil)
+
+	var transfers []internal.OwnershipTransfer
+	_, err := sess.Select("*").
+		From(ownershipTransfersTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", garageID),
+			dbr.Eq("completed", false),
+		)).
+		OrderBy("created_at DESC").
+		Load(&transfers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (o *OwnershipTransfer) Update(transfer internal.OwnershipTransfer) error {
+	sess := o.connection.NewSession(nil)
+
+	_, err := sess.Update(ownershipTransfersTable).
+		Where(dbr.Eq("id", transfer.ID)).
+		Set("from_confirmed", transfer.FromConfirmed).
+		Set("to_confirmed", transfer.ToConfirmed).
+		Exec()
+
+	return err
+}
+
+// Complete hands the garage over to the receiving employee. The previous owner
+// stays in the garage as a mechanic.
+func (o *OwnershipTransfer) Complete(transfer internal.OwnershipTransfer) error {
+	sess := o.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.DeleteFrom(garageOwnersTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", transfer.GarageID),
+			dbr.Eq("employee_id", transfer.FromEmployeeID),
+		)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.InsertInto(garageOwnersTable).
+		Pair("garage_id", transfer.GarageID).
+		Pair("employee_id", transfer.ToEmployeeID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Update(employeesTable).
+		Where(dbr.Eq("id", transfer.ToEmployeeID)).
+		Set("role", internal.OwnerRole).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if err = demoteOwner(tx, transfer.GarageID, transfer.FromEmployeeID); err != nil {
+		return err
+	}
+
+	_, err = tx.Update(ownershipTransfersTable).
+		Where(dbr.Eq("id", transfer.ID)).
+		Set("from_confirmed", true).
+		Set("to_confirmed", true).
+		Set("completed", true).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (o *OwnershipTransfer) Delete(ID int) error {
+	sess := o.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(ownershipTransfersTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipTransfer(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	transferRepo := NewOwnershipTransfer(connection)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     owner.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	assert.NoError(t, err)
+
+	mechanic, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test2@test.com",
+		Password:  "password123",
+		Role:      internal.MechanicRole,
+		GarageID:  &garage.ID,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	owners, err := employeeRepo.ListOwnersByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, owners, 1)
+	assert.Equal(t, owner.ID, owners[0].ID)
+
+	transfer, err := transferRepo.Insert(internal.OwnershipTransfer{
+		GarageID:       garage.ID,
+		FromEmployeeID: owner.ID,
+		ToEmployeeID:   mechanic.ID,
+	})
+	assert.NoError(t, err)
+
+	transfer.ToConfirmed = true
+	err = transferRepo.Update(transfer)
+	assert.NoError(t, err)
+
+	transfers, err := transferRepo.ListPendingByEmployeeID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+	assert.False(t, transfers[0].FromConfirmed)
+	assert.True(t, transfers[0].ToConfirmed)
+
+	transfers, err = transferRepo.ListPendingByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+
+	err = transferRepo.Complete(transfer)
+	assert.NoError(t, err)
+
+	transfers, err = transferRepo.ListPendingByEmployeeID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 0)
+
+	transfers, err = transferRepo.ListPendingByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 0)
+
+	owners, err = employeeRepo.ListOwnersByGarageID(garage.ID)
+	assert.NoError(t, err)
+	assert.Len(t, owners, 1)
+	assert.Equal(t, mechanic.ID, owners[0].ID)
+
+	newOwner, err := employeeRepo.GetByID(mechanic.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, internal.OwnerRole, newOwner.Role)
+
+	previousOwner, err := employeeRepo.GetByID(owner.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, internal.MechanicRole, previousOwner.Role)
+	assert.Equal(t, garage.ID, *previousOwner.GarageID)
+}
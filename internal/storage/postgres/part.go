@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	partsTable            = "parts"
+	appointmentPartsTable = "appointment_parts"
+)
+
+type Part struct {
+	connection *dbr.Connection
+}
+
+func NewPart(connection *dbr.Connection) *Part {
+	return &Part{
+		connection: connection,
+	}
+}
+
+func (p *Part) Insert(part internal.Part) (internal.Part, error) {
+	sess := p.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(partsTable).
+		Columns("garage_id", "sku", "name", "quantity", "cost", "reorder_threshold").
+		Record(part).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Part{}, err
+	}
+
+	part.ID = id
+	return part, nil
+}
+
+func (p *Part) ListByGarageID(garageID int) ([]internal.Part, error) {
+	sess := p.connection.NewSession(nil)
+
+	var parts []internal.Part
+	_, err := sess.Select("*").
+		From(partsTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", garageID),
+			dbr.Eq("is_deleted", false),
+		)).
+		OrderBy("name").
+		Load(&parts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+func (p *Part) GetByID(ID int) (internal.Part, error) {
+	sess := p.connection.NewSession(nil)
+
+	var part internal.Part
+	err := sess.Select("*").
+		From(partsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&part)
+
+	if err != nil {
+		return internal.Part{}, err
+	}
+
+	return part, nil
+}
+
+func (p *Part) Update(part internal.Part) error {
+	sess := p.connection.NewSession(nil)
+
+	_, err := sess.Update(partsTable).
+		Where(dbr.Eq("id", part.ID)).
+		Set("sku", part.SKU).
+		Set("name", part.Name).
+		Set("quantity", part.Quantity).
+		Set("cost", part.Cost).
+		Set("reorder_threshold", part.ReorderThreshold).
+		Exec()
+
+	return err
+}
+
+func (p *Part) Delete(ID int) error {
+	sess := p.connection.NewSession(nil)
+
+	_, err := sess.Update(partsTable).
+		Where(dbr.Eq("id", ID)).
+		Set("is_deleted", true).
+		Exec()
+
+	return err
+}
+
+// Use takes the parts from stock and records them on the appointment. It
+// returns the part with the remaining quantity, or internal.ErrInsufficientStock when
+// there are not enough units left.
+func (p *Part) Use(appointmentPart internal.AppointmentPart) (internal.AppointmentPart, internal.Part, error) {
+	sess := p.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.AppointmentPart{}, internal.Part{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var parts []internal.Part
+	err = tx.UpdateBySql(`
+		UPDATE parts SET quantity = quantity - ?
+		WHERE id = ? AND quantity >= ?
+		RETURNING *
+		`, appointmentPart.Quantity, appointmentPart.PartID, appointmentPart.Quantity).
+		Load(&parts)
+	if err != nil {
+		return internal.AppointmentPart{}, internal.Part{}, err
+	}
+	if len(parts) == 0 {
+		return internal.AppointmentPart{}, internal.Part{}, internal.ErrInsufficientStock
+	}
+
+	appointmentPart.Cost = parts[0].Cost
+	appointmentPart.CreatedAt = time.Now()
+	err = tx.InsertInto(appointmentPartsTable).
+		Columns("appointment_id", "part_id", "quantity", "cost", "created_at").
+		Record(appointmentPart).
+		Returning("id").
+		Load(&appointmentPart.ID)
+	if err != nil {
+		return internal.AppointmentPart{}, internal.Part{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.AppointmentPart{}, internal.Part{}, err
+	}
+
+	return appointmentPart, parts[0], nil
+}
+
+// Return removes the part from the appointment and puts it back in stock. It
+// returns internal.ErrNotFound when the part was already removed.
+func (p *Part) Return(appointmentPart internal.AppointmentPart) error {
+	sess := p.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	result, err := tx.DeleteFrom(appointmentPartsTable).
+		Where(dbr.Eq("id", appointmentPart.ID)).
+		Exec()
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return internal.ErrNotFound
+	}
+
+	_, err = tx.UpdateBySql(`
+		UPDATE parts SET quantity = quantity + ? WHERE id = ?
+		`, appointmentPart.Quantity, appointmentPart.PartID).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *Part) GetAppointmentPartByID(ID int) (internal.AppointmentPart, error) {
+	sess := p.connection.NewSession(nil)
+
+	var appointmentPart internal.AppointmentPart
+	err := sess.Select("*").
+		From(appointmentPartsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&appointmentPart)
+
+	if err != nil {
+		return internal.AppointmentPart{}, err
+	}
+
+	return appointmentPart, nil
+}
+
+func (p *Part) ListByAppointmentID(appointmentID int) ([]internal.AppointmentPart, error) {
+	sess := p.connection.NewSession(nil)
+
+	var appointmentParts []internal.AppointmentPart
+	_, err := sess.Select("*").
+		From(appointmentPartsTable).
+		Where(dbr.Eq("appointment_id", appointmentID)).
+		OrderBy("id").
+		Load(&appointmentParts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appointmentParts, nil
+}
+
+// Usage sums up the parts of the garage used between from and to.
+func (p *Part) Usage(garageID int, from, to time.Time) ([]internal.PartUsage, error) {
+	sess := p.connection.NewSession(nil)
+
+	var usages []internal.PartUsage
+	_, err := sess.SelectBySql(`
+		SELECT ap.part_id, SUM(ap.quantity) AS quantity, SUM(ap.quantity * ap.cost) AS cost
+		FROM appointment_parts AS ap
+		JOIN parts AS p ON p.id = ap.part_id
+		WHERE p.garage_id = ? AND ap.created_at >= ? AND ap.created_at < ?
+		GROUP BY ap.part_id
+		`, garageID, from, to).
+		Load(&usages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
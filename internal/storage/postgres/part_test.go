@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPart(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	partRepo := NewPart(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now().Add(-2 * time.Hour),
+		EndTime:    time.Now().Add(-time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	part, err := partRepo.Insert(internal.Part{
+		GarageID:         garage.ID,
+		SKU:              "OF-100",
+		Name:             "Oil filter",
+		Quantity:         5,
+		Cost:             2460,
+		ReorderThreshold: 2,
+	})
+	require.NoError(t, err)
+
+	parts, err := partRepo.ListByGarageID(garage.ID)
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	assert.Equal(t, "OF-100", parts[0].SKU)
+
+	appointmentPart, updatedPart, err := partRepo.Use(internal.AppointmentPart{
+		AppointmentID: appointment.ID,
+		PartID:        part.ID,
+		Quantity:      3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updatedPart.Quantity)
+	assert.True(t, updatedPart.IsLowStock())
+	assert.Equal(t, 2460, appointmentPart.Cost)
+
+	_, _, err = partRepo.Use(internal.AppointmentPart{
+		AppointmentID: appointment.ID,
+		PartID:        part.ID,
+		Quantity:      3,
+	})
+	assert.ErrorIs(t, err, internal.ErrInsufficientStock)
+
+	appointmentParts, err := partRepo.ListByAppointmentID(appointment.ID)
+	require.NoError(t, err)
+	require.Len(t, appointmentParts, 1)
+
+	usages, err := partRepo.Usage(garage.ID, time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, 3, usages[0].Quantity)
+	assert.Equal(t, 7380, usages[0].Cost)
+
+	err = partRepo.Return(appointmentParts[0])
+	require.NoError(t, err)
+
+	err = partRepo.Return(appointmentParts[0])
+	assert.ErrorIs(t, err, internal.ErrNotFound)
+
+	retrievedPart, err := partRepo.GetByID(part.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, retrievedPart.Quantity)
+
+	_, _, err = partRepo.Use(internal.AppointmentPart{
+		AppointmentID: appointment.ID,
+		PartID:        part.ID,
+		Quantity:      2,
+	})
+	require.NoError(t, err)
+
+	err = appointmentRepo.Delete(appointment.ID)
+	require.NoError(t, err)
+
+	retrievedPart, err = partRepo.GetByID(part.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, retrievedPart.Quantity)
+
+	retrievedPart.Name = "Oil filter XL"
+	err = partRepo.Update(retrievedPart)
+	require.NoError(t, err)
+
+	err = partRepo.Delete(part.ID)
+	require.NoError(t, err)
+
+	parts, err = partRepo.ListByGarageID(garage.ID)
+	require.NoError(t, err)
+	assert.Len(t, parts, 0)
+}
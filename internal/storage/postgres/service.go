@@ -1,12 +1,18 @@
 package postgres
 
 import (
+	"strings"
+	"time"
+
 	"github.com/KsaweryZietara/garage/internal"
 
 	"github.com/gocraft/dbr/v2"
 )
 
-const servicesTable = "services"
+const (
+	servicesTable        = "services"
+	serviceVersionsTable = "service_versions"
+)
 
 type Service struct {
 	connection *dbr.Connection
@@ -21,9 +27,15 @@ func NewService(connection *dbr.Connection) *Service {
 func (s *Service) Insert(service internal.Service) (internal.Service, error) {
 	sess := s.connection.NewSession(nil)
 
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Service{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
 	var id int
-	err := sess.InsertInto(servicesTable).
-		Columns("name", "time", "price", "garage_id").
+	err = tx.InsertInto(servicesTable).
+		Columns("name", "description", "time", "price", "max_price", "category_id", "garage_id").
 		Record(service).
 		Returning("id").
 		Load(&id)
@@ -32,6 +44,22 @@ func (s *Service) Insert(service internal.Service) (internal.Service, error) {
 		return internal.Service{}, err
 	}
 
+	_, err = tx.InsertInto(serviceVersionsTable).
+		Pair("service_id", id).
+		Pair("price", service.Price).
+		Pair("max_price", service.MaxPrice).
+		Pair("time", service.Time).
+		Pair("effective_from", time.Now()).
+		Exec()
+
+	if err != nil {
+		return internal.Service{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.Service{}, err
+	}
+
 	service.ID = id
 	return service, nil
 }
@@ -40,11 +68,10 @@ func (s *Service) ListByGarageID(garageID int) ([]internal.Service, error) {
 	sess := s.connection.NewSession(nil)
 
 	var services []internal.Service
-	_, err := sess.Select("*").
-		From(servicesTable).
+	_, err := selectServices(sess).
 		Where(dbr.And(
-			dbr.Eq("garage_id", garageID),
-			dbr.Eq("is_deleted", false),
+			dbr.Eq("s.garage_id", garageID),
+			dbr.Eq("s.is_deleted", false),
 		)).
 		Load(&services)
 
@@ -55,13 +82,48 @@ func (s *Service) ListByGarageID(garageID int) ([]internal.Service, error) {
 	return services, nil
 }
 
+// Search lists services across garages whose name or description matches the
+// query. A zero category ID matches every category.
+func (s *Service) Search(page int, query string, categoryID int) ([]internal.Service, error) {
+	sess := s.connection.NewSession(nil)
+	likeQuery := "%" + strings.ToLower(query) + "%"
+
+	if page < 1 {
+		page = 1
+	}
+
+	conditions := []dbr.Builder{
+		dbr.Eq("s.is_deleted", false),
+		dbr.Or(
+			dbr.Expr("LOWER(s.name) LIKE ?", likeQuery),
+			dbr.Expr("LOWER(s.description) LIKE ?", likeQuery),
+		),
+	}
+	if categoryID != 0 {
+		conditions = append(conditions, dbr.Eq("s.category_id", categoryID))
+	}
+
+	var services []internal.Service
+	_, err := selectServices(sess).
+		Where(dbr.And(conditions...)).
+		OrderBy("price").
+		OrderBy("id").
+		Paginate(uint64(page), pageSize).
+		Load(&services)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
 func (s *Service) GetByID(ID int) (internal.Service, error) {
 	sess := s.connection.NewSession(nil)
 
 	var service internal.Service
-	_, err := sess.Select("*").
-		From(servicesTable).
-		Where(dbr.Eq("id", ID)).
+	_, err := selectServices(sess).
+		Where(dbr.Eq("s.id", ID)).
 		Load(&service)
 
 	if err != nil {
@@ -71,6 +133,83 @@ func (s *Service) GetByID(ID int) (internal.Service, error) {
 	return service, nil
 }
 
+// Update changes the descriptive fields of a service. Price and time are
+// changed through InsertVersion.
+func (s *Service) Update(service internal.Service) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.Update(servicesTable).
+		Where(dbr.Eq("id", service.ID)).
+		Set("name", service.Name).
+		Set("description", service.Description).
+		Set("category_id", service.CategoryID).
+		Exec()
+
+	return err
+}
+
+// InsertVersion schedules a new price and time for a service. Versions that
+// are already in effect are also written to the service row, which keeps
+// aggregated queries over services up to date.
+func (s *Service) InsertVersion(version internal.ServiceVersion) (internal.ServiceVersion, error) {
+	sess := s.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.ServiceVersion{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var id int
+	err = tx.InsertInto(serviceVersionsTable).
+		Columns("service_id", "price", "max_price", "time", "effective_from").
+		Record(version).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.ServiceVersion{}, err
+	}
+
+	if !version.EffectiveFrom.After(time.Now()) {
+		_, err = tx.Update(servicesTable).
+			Where(dbr.Eq("id", version.ServiceID)).
+			Set("price", version.Price).
+			Set("max_price", version.MaxPrice).
+			Set("time", version.Time).
+			Exec()
+
+		if err != nil {
+			return internal.ServiceVersion{}, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.ServiceVersion{}, err
+	}
+
+	version.ID = id
+	return version, nil
+}
+
+func (s *Service) ListVersions(serviceID int) ([]internal.ServiceVersion, error) {
+	sess := s.connection.NewSession(nil)
+
+	var versions []internal.ServiceVersion
+	_, err := sess.Select("*").
+		From(serviceVersionsTable).
+		Where(dbr.Eq("service_id", serviceID)).
+		OrderBy("effective_from").
+		OrderBy("id").
+		Load(&versions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
 func (s *Service) Delete(ID int) error {
 	sess := s.connection.NewSession(nil)
 
@@ -81,3 +220,21 @@ func (s *Service) Delete(ID int) error {
 
 	return err
 }
+
+// selectServices selects services with the price and time of their latest
+// version that is already in effect.
+func selectServices(sess *dbr.Session) *dbr.SelectStmt {
+	return sess.Select(
+		"s.id", "s.name", "s.description", "s.category_id", "s.is_deleted", "s.garage_id",
+		"COALESCE(v.time, s.time) AS time",
+		"COALESCE(v.price, s.price) AS price",
+		"CASE WHEN v.id IS NULL THEN s.max_price ELSE v.max_price END AS max_price",
+	).
+		From(dbr.I(servicesTable).As("s")).
+		LeftJoin(dbr.Expr(`LATERAL (
+			SELECT id, price, max_price, time FROM service_versions
+			WHERE service_id = s.id AND effective_from <= ?
+			ORDER BY effective_from DESC, id DESC
+			LIMIT 1
+		) AS v`, time.Now()), "TRUE")
+}
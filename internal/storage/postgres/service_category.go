@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const serviceCategoriesTable = "service_categories"
+
+type ServiceCategory struct {
+	connection *dbr.Connection
+}
+
+func NewServiceCategory(connection *dbr.Connection) *ServiceCategory {
+	return &ServiceCategory{
+		connection: connection,
+	}
+}
+
+// List returns the categories shared by every garage.
+func (s *ServiceCategory) List() ([]internal.ServiceCategory, error) {
+	sess := s.connection.NewSession(nil)
+
+	var categories []internal.ServiceCategory
+	_, err := sess.Select("*").
+		From(serviceCategoriesTable).
+		Where(dbr.Eq("garage_id", nil)).
+		OrderBy("name").
+		Load(&categories)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// ListByGarageID returns the shared categories together with the ones of the
+// garage.
+func (s *ServiceCategory) ListByGarageID(garageID int) ([]internal.ServiceCategory, error) {
+	sess := s.connection.NewSession(nil)
+
+	var categories []internal.ServiceCategory
+	_, err := sess.Select("*").
+		From(serviceCategoriesTable).
+		Where(dbr.Or(
+			dbr.Eq("garage_id", nil),
+			dbr.Eq("garage_id", garageID),
+		)).
+		OrderBy("name").
+		Load(&categories)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (s *ServiceCategory) GetByID(ID int) (internal.ServiceCategory, error) {
+	sess := s.connection.NewSession(nil)
+
+	var category internal.ServiceCategory
+	err := sess.Select("*").
+		From(serviceCategoriesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&category)
+
+	if err != nil {
+		return internal.ServiceCategory{}, err
+	}
+
+	return category, nil
+}
+
+// Upsert returns the shared category with the given name, or the one of the
+// garage, creating it for the garage when neither exists yet.
+func (s *ServiceCategory) Upsert(garageID int, name string) (internal.ServiceCategory, error) {
+	sess := s.connection.NewSession(nil)
+
+	var category internal.ServiceCategory
+	err := sess.Select("*").
+		From(serviceCategoriesTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", nil),
+			dbr.Eq("name", name),
+		)).
+		LoadOne(&category)
+	if err == nil {
+		return category, nil
+	}
+	if !errors.Is(err, dbr.ErrNotFound) {
+		return internal.ServiceCategory{}, err
+	}
+
+	err = sess.SelectBySql(`
+		INSERT INTO service_categories (name, garage_id) VALUES (?, ?)
+		ON CONFLICT (garage_id, name) WHERE garage_id IS NOT NULL DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, name, garage_id
+		`, name, garageID).
+		LoadOne(&category)
+
+	if err != nil {
+		return internal.ServiceCategory{}, err
+	}
+
+	return category, nil
+}
+
+func (s *ServiceCategory) Update(category internal.ServiceCategory) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.Update(serviceCategoriesTable).
+		Where(dbr.Eq("id", category.ID)).
+		Set("name", category.Name).
+		Exec()
+
+	return err
+}
+
+func (s *ServiceCategory) Delete(ID int) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(serviceCategoriesTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceCategory(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	serviceCategoryRepo := NewServiceCategory(connection)
+
+	categories, err := serviceCategoryRepo.List()
+	require.NoError(t, err)
+	assert.NotEmpty(t, categories)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	require.NoError(t, err)
+
+	category, err := serviceCategoryRepo.Upsert(garage.ID, "Detailing")
+	require.NoError(t, err)
+	assert.NotZero(t, category.ID)
+	assert.Equal(t, &garage.ID, category.GarageID)
+
+	sameCategory, err := serviceCategoryRepo.Upsert(garage.ID, "Detailing")
+	require.NoError(t, err)
+	assert.Equal(t, category.ID, sameCategory.ID)
+
+	sharedCategory, err := serviceCategoryRepo.Upsert(garage.ID, categories[0].Name)
+	require.NoError(t, err)
+	assert.Equal(t, categories[0].ID, sharedCategory.ID)
+	assert.Nil(t, sharedCategory.GarageID)
+
+	sharedCategories, err := serviceCategoryRepo.List()
+	require.NoError(t, err)
+	assert.Len(t, sharedCategories, len(categories))
+
+	garageCategories, err := serviceCategoryRepo.ListByGarageID(garage.ID)
+	require.NoError(t, err)
+	assert.Len(t, garageCategories, len(categories)+1)
+
+	category.Name = "Car detailing"
+	err = serviceCategoryRepo.Update(category)
+	require.NoError(t, err)
+
+	retrievedCategory, err := serviceCategoryRepo.GetByID(category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Car detailing", retrievedCategory.Name)
+
+	maxPrice := 300
+	_, err = serviceRepo.Insert(internal.Service{
+		Name:        "Interior cleaning",
+		Description: "Vacuuming and upholstery washing",
+		Time:        60,
+		Price:       100,
+		MaxPrice:    &maxPrice,
+		CategoryID:  &category.ID,
+		GarageID:    garage.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = serviceRepo.Insert(internal.Service{
+		Name:     "Oil change",
+		Time:     30,
+		Price:    80,
+		GarageID: garage.ID,
+	})
+	require.NoError(t, err)
+
+	services, err := serviceRepo.Search(1, "UPHOLSTERY", 0)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "Interior cleaning", services[0].Name)
+	assert.Equal(t, &maxPrice, services[0].MaxPrice)
+
+	services, err = serviceRepo.Search(1, "", category.ID)
+	require.NoError(t, err)
+	assert.Len(t, services, 1)
+
+	services, err = serviceRepo.Search(1, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, services, 2)
+
+	err = serviceCategoryRepo.Delete(category.ID)
+	require.NoError(t, err)
+
+	services, err = serviceRepo.Search(1, "cleaning", 0)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Nil(t, services[0].CategoryID)
+}
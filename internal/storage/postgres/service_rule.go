@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const serviceRulesTable = "service_rules"
+
+type ServiceRule struct {
+	connection *dbr.Connection
+}
+
+func NewServiceRule(connection *dbr.Connection) *ServiceRule {
+	return &ServiceRule{
+		connection: connection,
+	}
+}
+
+func (s *ServiceRule) Insert(rule internal.ServiceRule) (internal.ServiceRule, error) {
+	sess := s.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(serviceRulesTable).
+		Columns("service_id", "type", "make_id", "model_id", "price", "time").
+		Record(rule).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.ServiceRule{}, err
+	}
+
+	rule.ID = id
+	return rule, nil
+}
+
+func (s *ServiceRule) GetByID(ID int) (internal.ServiceRule, error) {
+	sess := s.connection.NewSession(nil)
+
+	var rule internal.ServiceRule
+	err := sess.Select("*").
+		From(serviceRulesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&rule)
+
+	if err != nil {
+		return internal.ServiceRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (s *ServiceRule) ListByServiceID(serviceID int) ([]internal.ServiceRule, error) {
+	sess := s.connection.NewSession(nil)
+
+	var rules []internal.ServiceRule
+	_, err := sess.Select("*").
+		From(serviceRulesTable).
+		Where(dbr.Eq("service_id", serviceID)).
+		OrderBy("id").
+		Load(&rules)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *ServiceRule) Delete(ID int) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(serviceRulesTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRule(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	carRepo := NewCar(connection)
+	serviceRuleRepo := NewServiceRule(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{
+		Name:     "Test Service",
+		Time:     60,
+		Price:    100,
+		GarageID: garage.ID,
+	})
+	require.NoError(t, err)
+
+	model, err := carRepo.GetModelByID(1)
+	require.NoError(t, err)
+
+	price := 150
+	makeRule, err := serviceRuleRepo.Insert(internal.ServiceRule{
+		ServiceID: service.ID,
+		Type:      internal.OverrideRule,
+		MakeID:    model.MakeID,
+		Price:     &price,
+	})
+	require.NoError(t, err)
+
+	_, err = serviceRuleRepo.Insert(internal.ServiceRule{
+		ServiceID: service.ID,
+		Type:      internal.ExcludeRule,
+		MakeID:    model.MakeID,
+		ModelID:   &model.ID,
+	})
+	require.NoError(t, err)
+
+	rule, err := serviceRuleRepo.GetByID(makeRule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.OverrideRule, rule.Type)
+	assert.Nil(t, rule.ModelID)
+	require.NotNil(t, rule.Price)
+	assert.Equal(t, price, *rule.Price)
+
+	rules, err := serviceRuleRepo.ListByServiceID(service.ID)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	err = serviceRuleRepo.Delete(makeRule.ID)
+	require.NoError(t, err)
+
+	rules, err = serviceRuleRepo.ListByServiceID(service.ID)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, internal.ExcludeRule, rules[0].Type)
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"testing"
+	"time"
 
 	"github.com/KsaweryZietara/garage/internal"
 
@@ -70,6 +71,35 @@ func TestService(t *testing.T) {
 	assert.Equal(t, service1.Price, service.Price)
 	assert.Equal(t, false, service1.IsDeleted)
 
+	service.Name = "Updated Service"
+	err = serviceRepo.Update(service)
+	assert.NoError(t, err)
+
+	_, err = serviceRepo.InsertVersion(internal.ServiceVersion{
+		ServiceID:     service1.ID,
+		Price:         120,
+		Time:          45,
+		EffectiveFrom: time.Now().Add(-time.Minute),
+	})
+	assert.NoError(t, err)
+	_, err = serviceRepo.InsertVersion(internal.ServiceVersion{
+		ServiceID:     service1.ID,
+		Price:         150,
+		Time:          45,
+		EffectiveFrom: time.Now().Add(24 * time.Hour),
+	})
+	assert.NoError(t, err)
+
+	updatedService, err := serviceRepo.GetByID(service1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated Service", updatedService.Name)
+	assert.Equal(t, 120, updatedService.Price)
+	assert.Equal(t, 45, updatedService.Time)
+
+	versions, err := serviceRepo.ListVersions(service1.ID)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+
 	err = serviceRepo.Delete(service1.ID)
 	assert.NoError(t, err)
 
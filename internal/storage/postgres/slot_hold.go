@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const slotHoldsTable = "slot_holds"
+
+type SlotHold struct {
+	connection *dbr.Connection
+}
+
+func NewSlotHold(connection *dbr.Connection) *SlotHold {
+	return &SlotHold{
+		connection: connection,
+	}
+}
+
+func (s *SlotHold) Insert(hold internal.SlotHold) (internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(slotHoldsTable).
+		Columns("employee_id", "customer_id", "waitlist_entry_id", "start_time", "end_time", "expires_at", "created_at").
+		Record(hold).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.SlotHold{}, err
+	}
+
+	hold.ID = id
+	return hold, nil
+}
+
+// InsertCheckout holds the slot for the customer, or returns
+// internal.ErrSlotHeld when it is already held for another customer. Holds of
+// the same employee are made one at a time.
+func (s *SlotHold) InsertCheckout(hold internal.SlotHold) (internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.SlotHold{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var employeeIDs []int
+	_, err = tx.SelectBySql(`
+		SELECT id FROM employees WHERE id = ? FOR UPDATE
+		`, hold.EmployeeID).
+		Load(&employeeIDs)
+	if err != nil {
+		return internal.SlotHold{}, err
+	}
+
+	var count int
+	err = tx.Select("COUNT(*)").
+		From(slotHoldsTable).
+		Where(dbr.And(
+			dbr.Eq("employee_id", hold.EmployeeID),
+			dbr.Neq("customer_id", hold.CustomerID),
+			dbr.Lt("start_time", hold.EndTime),
+			dbr.Gt("end_time", hold.StartTime),
+			dbr.Gt("expires_at", time.Now()),
+		)).
+		LoadOne(&count)
+	if err != nil {
+		return internal.SlotHold{}, err
+	}
+	if count > 0 {
+		return internal.SlotHold{}, internal.ErrSlotHeld
+	}
+
+	err = tx.InsertInto(slotHoldsTable).
+		Columns("employee_id", "customer_id", "waitlist_entry_id", "start_time", "end_time", "expires_at", "created_at").
+		Record(hold).
+		Returning("id").
+		Load(&hold.ID)
+	if err != nil {
+		return internal.SlotHold{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.SlotHold{}, err
+	}
+
+	return hold, nil
+}
+
+func (s *SlotHold) GetByID(ID int) (internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	var hold internal.SlotHold
+	err := sess.Select("*").
+		From(slotHoldsTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&hold)
+
+	return hold, err
+}
+
+// ListActive returns the unexpired holds of the employee overlapping the slot.
+func (s *SlotHold) ListActive(slot internal.TimeSlot, employeeID int) ([]internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	var holds []internal.SlotHold
+	_, err := sess.Select("*").
+		From(slotHoldsTable).
+		Where(dbr.And(
+			dbr.Eq("employee_id", employeeID),
+			dbr.Lt("start_time", slot.EndTime),
+			dbr.Gt("end_time", slot.StartTime),
+			dbr.Gt("expires_at", time.Now()),
+		)).
+		OrderBy("id").
+		Load(&holds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// DeleteActive removes the unexpired holds of the employee overlapping the
+// slot and returns them.
+func (s *SlotHold) DeleteActive(slot internal.TimeSlot, employeeID int) ([]internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	var holds []internal.SlotHold
+	_, err := sess.SelectBySql(`
+		DELETE FROM slot_holds
+		WHERE employee_id = ? AND start_time < ? AND end_time > ? AND expires_at > ?
+		RETURNING *
+		`, employeeID, slot.EndTime, slot.StartTime, time.Now()).
+		Load(&holds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// DeleteExpired removes the expired holds and returns them. Every hold is
+// returned to one caller only, even with several instances running.
+func (s *SlotHold) DeleteExpired() ([]internal.SlotHold, error) {
+	sess := s.connection.NewSession(nil)
+
+	var holds []internal.SlotHold
+	_, err := sess.SelectBySql(`
+		DELETE FROM slot_holds
+		WHERE expires_at <= ?
+		RETURNING *
+		`, time.Now()).
+		Load(&holds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (s *SlotHold) Delete(ID int) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(slotHoldsTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
+
+// DeleteCheckoutByCustomerID removes the holds the customer made while
+// booking, keeping the ones offered from the waitlist.
+func (s *SlotHold) DeleteCheckoutByCustomerID(customerID int) error {
+	sess := s.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(slotHoldsTable).
+		Where(dbr.And(
+			dbr.Eq("customer_id", customerID),
+			dbr.Eq("waitlist_entry_id", nil),
+		)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlotHold(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	customerRepo := NewCustomer(connection)
+	slotHoldRepo := NewSlotHold(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.MechanicRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	start := time.Date(2030, 9, 24, 10, 0, 0, 0, time.UTC)
+	active, err := slotHoldRepo.Insert(internal.SlotHold{
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		StartTime:  start,
+		EndTime:    start.Add(2 * time.Hour),
+		ExpiresAt:  time.Now().Add(time.Hour),
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	expired, err := slotHoldRepo.Insert(internal.SlotHold{
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		ExpiresAt:  time.Now().Add(-time.Minute),
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	holds, err := slotHoldRepo.ListActive(internal.TimeSlot{StartTime: start.Add(time.Hour), EndTime: start.Add(3 * time.Hour)}, employee.ID)
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, active.ID, holds[0].ID)
+
+	holds, err = slotHoldRepo.ListActive(internal.TimeSlot{StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)}, employee.ID)
+	require.NoError(t, err)
+	assert.Empty(t, holds)
+
+	holds, err = slotHoldRepo.DeleteExpired()
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, expired.ID, holds[0].ID)
+
+	holds, err = slotHoldRepo.DeleteActive(internal.TimeSlot{StartTime: start, EndTime: start.Add(time.Hour)}, employee.ID)
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, active.ID, holds[0].ID)
+
+	holds, err = slotHoldRepo.ListActive(internal.TimeSlot{StartTime: start, EndTime: start.Add(2 * time.Hour)}, employee.ID)
+	require.NoError(t, err)
+	assert.Empty(t, holds)
+
+	checkout, err := slotHoldRepo.Insert(internal.SlotHold{
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		ExpiresAt:  time.Now().Add(time.Minute),
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	hold, err := slotHoldRepo.GetByID(checkout.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, hold.CustomerID)
+	assert.Nil(t, hold.WaitlistEntryID)
+
+	err = slotHoldRepo.DeleteCheckoutByCustomerID(customer.ID)
+	require.NoError(t, err)
+	_, err = slotHoldRepo.GetByID(checkout.ID)
+	assert.Error(t, err)
+
+	checkout, err = slotHoldRepo.InsertCheckout(checkout)
+	require.NoError(t, err)
+
+	other, err := customerRepo.Insert(internal.Customer{Email: "other@test.com", Password: "password123"})
+	require.NoError(t, err)
+	_, err = slotHoldRepo.InsertCheckout(internal.SlotHold{
+		EmployeeID: employee.ID,
+		CustomerID: other.ID,
+		StartTime:  start.Add(30 * time.Minute),
+		EndTime:    start.Add(2 * time.Hour),
+		ExpiresAt:  time.Now().Add(time.Minute),
+		CreatedAt:  time.Now(),
+	})
+	assert.ErrorIs(t, err, internal.ErrSlotHeld)
+
+	err = slotHoldRepo.Delete(checkout.ID)
+	require.NoError(t, err)
+	_, err = slotHoldRepo.GetByID(checkout.ID)
+	assert.Error(t, err)
+}
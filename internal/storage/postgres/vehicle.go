@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const vehiclesTable = "vehicles"
+
+type Vehicle struct {
+	connection *dbr.Connection
+}
+
+func NewVehicle(connection *dbr.Connection) *Vehicle {
+	return &Vehicle{
+		connection: connection,
+	}
+}
+
+func (v *Vehicle) Insert(vehicle internal.Vehicle) (internal.Vehicle, error) {
+	sess := v.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(vehiclesTable).
+		Columns("customer_id", "model_id", "year", "plate_number", "vin", "share_history", "company_id").
+		Record(vehicle).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.Vehicle{}, err
+	}
+
+	vehicle.ID = id
+	return vehicle, nil
+}
+
+func (v *Vehicle) GetByID(ID int) (internal.Vehicle, error) {
+	sess := v.connection.NewSession(nil)
+
+	var vehicle internal.Vehicle
+	err := sess.Select("*").
+		From(vehiclesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&vehicle)
+
+	if err != nil {
+		return internal.Vehicle{}, err
+	}
+
+	return vehicle, nil
+}
+
+func (v *Vehicle) ListByCustomerID(customerID int) ([]internal.Vehicle, error) {
+	sess := v.connection.NewSession(nil)
+
+	var vehicles []internal.Vehicle
+	_, err := sess.Select("*").
+		From(vehiclesTable).
+		Where(dbr.And(
+			dbr.Eq("customer_id", customerID),
+			dbr.Eq("is_deleted", false),
+		)).
+		OrderBy("id").
+		Load(&vehicles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vehicles, nil
+}
+
+func (v *Vehicle) ListByCompanyID(companyID int) ([]internal.Vehicle, error) {
+	sess := v.connection.NewSession(nil)
+
+	var vehicles []internal.Vehicle
+	_, err := sess.Select("*").
+		From(vehiclesTable).
+		Where(dbr.And(
+			dbr.Eq("company_id", companyID),
+			dbr.Eq("is_deleted", false),
+		)).
+		OrderBy("id").
+		Load(&vehicles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vehicles, nil
+}
+
+func (v *Vehicle) Update(vehicle internal.Vehicle) error {
+	sess := v.connection.NewSession(nil)
+
+	_, err := sess.Update(vehiclesTable).
+		Where(dbr.Eq("id", vehicle.ID)).
+		Set("model_id", vehicle.ModelID).
+		Set("year", vehicle.Year).
+		Set("plate_number", vehicle.PlateNumber).
+		Set("vin", vehicle.VIN).
+		Set("share_history", vehicle.ShareHistory).
+		Set("company_id", vehicle.CompanyID).
+		Exec()
+
+	return err
+}
+
+func (v *Vehicle) Delete(ID int) error {
+	sess := v.connection.NewSession(nil)
+
+	_, err := sess.Update(vehiclesTable).
+		Where(dbr.Eq("id", ID)).
+		Set("is_deleted", true).
+		Exec()
+
+	return err
+}
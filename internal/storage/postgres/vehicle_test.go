@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVehicle(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	customerRepo := NewCustomer(connection)
+	vehicleRepo := NewVehicle(connection)
+
+	customer, err := customerRepo.Insert(internal.Customer{
+		Email:    "test@test.com",
+		Password: "password123",
+	})
+	assert.NoError(t, err)
+
+	year := 2015
+	vehicle, err := vehicleRepo.Insert(internal.Vehicle{
+		CustomerID:  customer.ID,
+		ModelID:     1,
+		Year:        &year,
+		PlateNumber: "WA12345",
+		VIN:         "WVWZZZ1JZXW000001",
+	})
+	assert.NoError(t, err)
+
+	retrievedVehicle, err := vehicleRepo.GetByID(vehicle.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, vehicle, retrievedVehicle)
+
+	vehicle.ModelID = 2
+	vehicle.Year = nil
+	vehicle.PlateNumber = "KR54321"
+	err = vehicleRepo.Update(vehicle)
+	assert.NoError(t, err)
+
+	vehicles, err := vehicleRepo.ListByCustomerID(customer.ID)
+	assert.NoError(t, err)
+	assert.Len(t, vehicles, 1)
+	assert.Equal(t, vehicle, vehicles[0])
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	appointmentRepo := NewAppointment(connection)
+
+	owner, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "test@test.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	assert.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     owner.ID,
+		Latitude:    10,
+		Longitude:   10,
+	})
+	assert.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{
+		Name:     "Oil change",
+		Time:     1,
+		Price:    100,
+		GarageID: garage.ID,
+	})
+	assert.NoError(t, err)
+
+	startTime := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  startTime,
+		EndTime:    startTime.Add(time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: owner.ID,
+		CustomerID: customer.ID,
+		ModelID:    vehicle.ModelID,
+		VehicleID:  &vehicle.ID,
+	})
+	assert.NoError(t, err)
+
+	mileage := 120000
+	notes := "Replaced oil filter"
+	appointment.Mileage = &mileage
+	appointment.Notes = &notes
+	err = appointmentRepo.Update(appointment)
+	assert.NoError(t, err)
+
+	appointments, err := appointmentRepo.ListByVehicleID(vehicle.ID)
+	assert.NoError(t, err)
+	assert.Len(t, appointments, 1)
+	assert.Equal(t, mileage, *appointments[0].Mileage)
+	assert.Equal(t, notes, *appointments[0].Notes)
+
+	err = vehicleRepo.Delete(vehicle.ID)
+	assert.NoError(t, err)
+
+	vehicles, err = vehicleRepo.ListByCustomerID(customer.ID)
+	assert.NoError(t, err)
+	assert.Len(t, vehicles, 0)
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const waitlistEntriesTable = "waitlist_entries"
+
+type WaitlistEntry struct {
+	connection *dbr.Connection
+}
+
+func NewWaitlistEntry(connection *dbr.Connection) *WaitlistEntry {
+	return &WaitlistEntry{
+		connection: connection,
+	}
+}
+
+func (w *WaitlistEntry) Insert(entry internal.WaitlistEntry) (internal.WaitlistEntry, error) {
+	sess := w.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(waitlistEntriesTable).
+		Columns("customer_id", "garage_id", "service_id", "employee_id", "from_time", "to_time", "status", "created_at").
+		Record(entry).
+		Returning("id").
+		Load(&id)
+
+	if err != nil {
+		return internal.WaitlistEntry{}, err
+	}
+
+	entry.ID = id
+	return entry, nil
+}
+
+func (w *WaitlistEntry) GetByID(ID int) (internal.WaitlistEntry, error) {
+	sess := w.connection.NewSession(nil)
+
+	var entry internal.WaitlistEntry
+	err := sess.Select("*").
+		From(waitlistEntriesTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&entry)
+
+	return entry, err
+}
+
+func (w *WaitlistEntry) ListByCustomerID(customerID int) ([]internal.WaitlistEntry, error) {
+	sess := w.connection.NewSession(nil)
+
+	var entries []internal.WaitlistEntry
+	_, err := sess.Select("*").
+		From(waitlistEntriesTable).
+		Where(dbr.Eq("customer_id", customerID)).
+		OrderDesc("created_at").
+		Load(&entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (w *WaitlistEntry) ListByGarageID(garageID int) ([]internal.WaitlistEntry, error) {
+	sess := w.connection.NewSession(nil)
+
+	var entries []internal.WaitlistEntry
+	_, err := sess.Select("*").
+		From(waitlistEntriesTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", garageID),
+			dbr.Eq("status", []internal.WaitlistStatus{internal.WaitingEntry, internal.OfferedEntry}),
+		)).
+		OrderBy("created_at").
+		Load(&entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ListWaiting returns the entries still waiting for a slot of the employee
+// starting at the given time, the longest waiting first.
+func (w *WaitlistEntry) ListWaiting(garageID, employeeID int, startTime time.Time) ([]internal.WaitlistEntry, error) {
+	sess := w.connection.NewSession(nil)
+
+	var entries []internal.WaitlistEntry
+	_, err := sess.Select("*").
+		From(waitlistEntriesTable).
+		Where(dbr.And(
+			dbr.Eq("garage_id", garageID),
+			dbr.Eq("status", internal.WaitingEntry),
+			dbr.Or(
+				dbr.Eq("employee_id", employeeID),
+				dbr.Eq("employee_id", nil),
+			),
+			dbr.Lte("from_time", startTime),
+			dbr.Gt("to_time", startTime),
+		)).
+		OrderBy("created_at").
+		OrderBy("id").
+		Load(&entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// UpdateStatus changes the status of the entry if it currently has one of the
+// expected statuses.
+func (w *WaitlistEntry) UpdateStatus(ID int, status internal.WaitlistStatus, expected ...internal.WaitlistStatus) error {
+	sess := w.connection.NewSession(nil)
+
+	_, err := sess.Update(waitlistEntriesTable).
+		Where(dbr.And(
+			dbr.Eq("id", ID),
+			dbr.Eq("status", expected),
+		)).
+		Set("status", status).
+		Exec()
+
+	return err
+}
+
+func (w *WaitlistEntry) Delete(ID int) error {
+	sess := w.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(waitlistEntriesTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitlistEntry(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	waitlistEntryRepo := NewWaitlistEntry(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:         "Test Garage",
+		City:         "Test City",
+		Street:       "Test Street",
+		Number:       "123",
+		PostalCode:   "12345",
+		PhoneNumber:  "1234567890",
+		OwnerID:      employee.ID,
+		Latitude:     10,
+		Longitude:    10,
+		Currency:     internal.DefaultCurrency,
+		TaxRate:      internal.DefaultTaxRate,
+		WaitlistMode: internal.BroadcastWaitlist,
+	})
+	require.NoError(t, err)
+
+	retrievedGarage, err := garageRepo.GetByID(garage.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.BroadcastWaitlist, retrievedGarage.WaitlistMode)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	anyEmployee, err := waitlistEntryRepo.Insert(internal.NewWaitlistEntry(internal.CreateWaitlistEntryDTO{
+		ServiceID: service.ID,
+		From:      "2030-09-23",
+		To:        "2030-09-24",
+	}, customer.ID, garage.ID))
+	require.NoError(t, err)
+
+	otherEmployee, err := waitlistEntryRepo.Insert(internal.NewWaitlistEntry(internal.CreateWaitlistEntryDTO{
+		ServiceID:  service.ID,
+		EmployeeID: employee.ID + 1,
+		From:       "2030-09-23",
+		To:         "2030-09-24",
+	}, customer.ID, garage.ID))
+	require.NoError(t, err)
+
+	entries, err := waitlistEntryRepo.ListWaiting(garage.ID, employee.ID, time.Date(2030, 9, 24, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, anyEmployee.ID, entries[0].ID)
+
+	entries, err = waitlistEntryRepo.ListWaiting(garage.ID, employee.ID, time.Date(2030, 9, 25, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	err = waitlistEntryRepo.UpdateStatus(anyEmployee.ID, internal.BookedEntry, internal.OfferedEntry)
+	require.NoError(t, err)
+	entry, err := waitlistEntryRepo.GetByID(anyEmployee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.WaitingEntry, entry.Status)
+
+	err = waitlistEntryRepo.UpdateStatus(anyEmployee.ID, internal.OfferedEntry, internal.WaitingEntry)
+	require.NoError(t, err)
+	entry, err = waitlistEntryRepo.GetByID(anyEmployee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, internal.OfferedEntry, entry.Status)
+
+	entries, err = waitlistEntryRepo.ListByGarageID(garage.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	err = waitlistEntryRepo.Delete(otherEmployee.ID)
+	require.NoError(t, err)
+
+	entries, err = waitlistEntryRepo.ListByCustomerID(customer.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, anyEmployee.ID, entries[0].ID)
+}
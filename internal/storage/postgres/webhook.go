@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	webhooksTable          = "webhooks"
+	webhookEventTypesTable = "webhook_event_types"
+	webhookDeliveriesTable = "webhook_deliveries"
+	webhookDeliveriesLimit = 100
+)
+
+type Webhook struct {
+	connection *dbr.Connection
+}
+
+func NewWebhook(connection *dbr.Connection) *Webhook {
+	return &Webhook{
+		connection: connection,
+	}
+}
+
+func (w *Webhook) Insert(webhook internal.Webhook) (internal.Webhook, error) {
+	sess := w.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return internal.Webhook{}, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	var id int
+	err = tx.InsertInto(webhooksTable).
+		Columns("garage_id", "url", "secret", "active", "created_at").
+		Record(webhook).
+		Returning("id").
+		Load(&id)
+	if err != nil {
+		return internal.Webhook{}, err
+	}
+
+	if err = insertEventTypes(tx, id, webhook.EventTypes); err != nil {
+		return internal.Webhook{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return internal.Webhook{}, err
+	}
+
+	webhook.ID = id
+	return webhook, nil
+}
+
+func (w *Webhook) GetByID(ID int) (internal.Webhook, error) {
+	sess := w.connection.NewSession(nil)
+
+	var webhook internal.Webhook
+	err := sess.Select("*").
+		From(webhooksTable).
+		Where(dbr.Eq("id", ID)).
+		LoadOne(&webhook)
+	if err != nil {
+		return internal.Webhook{}, err
+	}
+
+	webhook.EventTypes, err = loadEventTypes(sess, webhook.ID)
+	if err != nil {
+		return internal.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (w *Webhook) ListByGarageID(garageID int) ([]internal.Webhook, error) {
+	sess := w.connection.NewSession(nil)
+
+	var webhooks []internal.Webhook
+	_, err := sess.Select("*").
+		From(webhooksTable).
+		Where(dbr.Eq("garage_id", garageID)).
+		OrderBy("id").
+		Load(&webhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].EventTypes, err = loadEventTypes(sess, webhooks[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return webhooks, nil
+}
+
+// ListSubscribed returns the active webhooks of the garage subscribed to the
+// event type.
+func (w *Webhook) ListSubscribed(garageID int, eventType string) ([]internal.Webhook, error) {
+	sess := w.connection.NewSession(nil)
+
+	var webhooks []internal.Webhook
+	_, err := sess.Select("w.*").
+		From(dbr.I(webhooksTable).As("w")).
+		Join(dbr.I(webhookEventTypesTable).As("et"), "et.webhook_id = w.id").
+		Where(dbr.And(
+			dbr.Eq("w.garage_id", garageID),
+			dbr.Eq("w.active", true),
+			dbr.Eq("et.event_type", eventType),
+		)).
+		OrderBy("w.id").
+		Load(&webhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (w *Webhook) Update(webhook internal.Webhook) error {
+	sess := w.connection.NewSession(nil)
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	_, err = tx.Update(webhooksTable).
+		Where(dbr.Eq("id", webhook.ID)).
+		Set("url", webhook.URL).
+		Set("secret", webhook.Secret).
+		Set("active", webhook.Active).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.DeleteFrom(webhookEventTypesTable).
+		Where(dbr.Eq("webhook_id", webhook.ID)).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if err = insertEventTypes(tx, webhook.ID, webhook.EventTypes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (w *Webhook) Delete(ID int) error {
+	sess := w.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(webhooksTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
+
+func (w *Webhook) InsertDelivery(delivery internal.WebhookDelivery) (internal.WebhookDelivery, error) {
+	sess := w.connection.NewSession(nil)
+
+	var id int
+	err := sess.InsertInto(webhookDeliveriesTable).
+		Columns("webhook_id", "event_type", "payload", "attempt", "status_code", "error", "created_at").
+		Record(delivery).
+		Returning("id").
+		Load(&id)
+	if err != nil {
+		return internal.WebhookDelivery{}, err
+	}
+
+	delivery.ID = id
+	return delivery, nil
+}
+
+// ListDeliveries returns the most recent delivery attempts of the webhook,
+// newest first.
+func (w *Webhook) ListDeliveries(webhookID int) ([]internal.WebhookDelivery, error) {
+	sess := w.connection.NewSession(nil)
+
+	var deliveries []internal.WebhookDelivery
+	_, err := sess.Select("*").
+		From(webhookDeliveriesTable).
+		Where(dbr.Eq("webhook_id", webhookID)).
+		OrderDesc("id").
+		Limit(webhookDeliveriesLimit).
+		Load(&deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func insertEventTypes(tx *dbr.Tx, webhookID int, eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		_, err := tx.InsertInto(webhookEventTypesTable).
+			Pair("webhook_id", webhookID).
+			Pair("event_type", eventType).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadEventTypes(sess *dbr.Session, webhookID int) ([]string, error) {
+	var eventTypes []string
+	_, err := sess.Select("event_type").
+		From(webhookEventTypesTable).
+		Where(dbr.Eq("webhook_id", webhookID)).
+		OrderBy("event_type").
+		Load(&eventTypes)
+
+	return eventTypes, err
+}
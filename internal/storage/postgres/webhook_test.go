@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	webhookRepo := NewWebhook(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	webhook, err := webhookRepo.Insert(internal.NewWebhook(internal.CreateWebhookDTO{
+		URL:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef",
+		Active:     true,
+		EventTypes: []string{"review.created", "appointment.created"},
+	}, garage.ID))
+	require.NoError(t, err)
+
+	_, err = webhookRepo.Insert(internal.NewWebhook(internal.CreateWebhookDTO{
+		URL:        "https://example.com/inactive",
+		Secret:     "0123456789abcdef",
+		Active:     false,
+		EventTypes: []string{"appointment.created"},
+	}, garage.ID))
+	require.NoError(t, err)
+
+	retrieved, err := webhookRepo.GetByID(webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hooks", retrieved.URL)
+	assert.Equal(t, "0123456789abcdef", retrieved.Secret)
+	assert.Equal(t, []string{"appointment.created", "review.created"}, retrieved.EventTypes)
+
+	webhooks, err := webhookRepo.ListByGarageID(garage.ID)
+	require.NoError(t, err)
+	assert.Len(t, webhooks, 2)
+
+	subscribed, err := webhookRepo.ListSubscribed(garage.ID, "appointment.created")
+	require.NoError(t, err)
+	require.Len(t, subscribed, 1)
+	assert.Equal(t, webhook.ID, subscribed[0].ID)
+
+	subscribed, err = webhookRepo.ListSubscribed(garage.ID, "appointment.cancelled")
+	require.NoError(t, err)
+	assert.Empty(t, subscribed)
+
+	retrieved.EventTypes = []string{"appointment.cancelled"}
+	retrieved.URL = "https://example.com/updated"
+	err = webhookRepo.Update(retrieved)
+	require.NoError(t, err)
+
+	retrieved, err = webhookRepo.GetByID(webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/updated", retrieved.URL)
+	assert.Equal(t, []string{"appointment.cancelled"}, retrieved.EventTypes)
+
+	failed := "unexpected status code 500"
+	statusCodes := []int{http.StatusInternalServerError, http.StatusOK}
+	for i, statusCode := range statusCodes {
+		delivery := internal.WebhookDelivery{
+			WebhookID:  webhook.ID,
+			EventType:  "appointment.cancelled",
+			Payload:    `{"type":"appointment.cancelled"}`,
+			Attempt:    i + 1,
+			StatusCode: &statusCode,
+			CreatedAt:  time.Now(),
+		}
+		if statusCode != http.StatusOK {
+			delivery.Error = &failed
+		}
+		_, err = webhookRepo.InsertDelivery(delivery)
+		require.NoError(t, err)
+	}
+
+	deliveries, err := webhookRepo.ListDeliveries(webhook.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 2, deliveries[0].Attempt)
+	assert.Equal(t, http.StatusOK, *deliveries[0].StatusCode)
+	assert.Nil(t, deliveries[0].Error)
+	assert.Equal(t, failed, *deliveries[1].Error)
+
+	err = webhookRepo.Delete(webhook.ID)
+	require.NoError(t, err)
+
+	_, err = webhookRepo.GetByID(webhook.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/gocraft/dbr/v2"
+)
+
+const (
+	workOrdersTable      = "work_orders"
+	workOrderPhotosTable = "work_order_photos"
+)
+
+type WorkOrder struct {
+	connection *dbr.Connection
+}
+
+func NewWorkOrder(connection *dbr.Connection) *WorkOrder {
+	return &WorkOrder{
+		connection: connection,
+	}
+}
+
+// Save creates the work order of the appointment or updates its findings if
+// it already exists.
+func (w *WorkOrder) Save(workOrder internal.WorkOrder) (internal.WorkOrder, error) {
+	sess := w.connection.NewSession(nil)
+
+	workOrder.UpdatedAt = time.Now()
+	err := sess.SelectBySql(`
+		INSERT INTO work_orders (appointment_id, findings, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (appointment_id) DO UPDATE SET findings = EXCLUDED.findings, updated_at = EXCLUDED.updated_at
+		RETURNING id
+		`, workOrder.AppointmentID, workOrder.Findings, workOrder.UpdatedAt).
+		LoadOne(&workOrder.ID)
+
+	if err != nil {
+		return internal.WorkOrder{}, err
+	}
+
+	return workOrder, nil
+}
+
+func (w *WorkOrder) GetByAppointmentID(appointmentID int) (internal.WorkOrder, error) {
+	sess := w.connection.NewSession(nil)
+
+	var workOrder internal.WorkOrder
+	err := sess.Select("*").
+		From(workOrdersTable).
+		Where(dbr.Eq("appointment_id", appointmentID)).
+		LoadOne(&workOrder)
+	if err != nil {
+		return internal.WorkOrder{}, err
+	}
+
+	_, err = sess.Select("*").
+		From(workOrderPhotosTable).
+		Where(dbr.Eq("work_order_id", workOrder.ID)).
+		OrderBy("id").
+		Load(&workOrder.Photos)
+	if err != nil {
+		return internal.WorkOrder{}, err
+	}
+
+	return workOrder, nil
+}
+
+func (w *WorkOrder) InsertPhoto(photo internal.WorkOrderPhoto) (internal.WorkOrderPhoto, error) {
+	sess := w.connection.NewSession(nil)
+
+	photo.CreatedAt = time.Now()
+	err := sess.InsertInto(workOrderPhotosTable).
+		Columns("work_order_id", "photo", "created_at").
+		Record(photo).
+		Returning("id").
+		Load(&photo.ID)
+
+	if err != nil {
+		return internal.WorkOrderPhoto{}, err
+	}
+
+	return photo, nil
+}
+
+func (w *WorkOrder) DeletePhoto(ID int) error {
+	sess := w.connection.NewSession(nil)
+
+	_, err := sess.DeleteFrom(workOrderPhotosTable).
+		Where(dbr.Eq("id", ID)).
+		Exec()
+
+	return err
+}
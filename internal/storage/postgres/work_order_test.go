@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KsaweryZietara/garage/internal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkOrder(t *testing.T) {
+	cleanup := NewSuite(t)
+	defer cleanup()
+
+	employeeRepo := NewEmployee(connection)
+	garageRepo := NewGarage(connection)
+	serviceRepo := NewService(connection)
+	customerRepo := NewCustomer(connection)
+	appointmentRepo := NewAppointment(connection)
+	workOrderRepo := NewWorkOrder(connection)
+
+	employee, err := employeeRepo.Insert(internal.Employee{
+		Name:      "John",
+		Surname:   "Doe",
+		Email:     "john.doe@example.com",
+		Password:  "password123",
+		Role:      internal.OwnerRole,
+		Confirmed: true,
+	})
+	require.NoError(t, err)
+
+	garage, err := garageRepo.Insert(internal.Garage{
+		Name:        "Test Garage",
+		City:        "Test City",
+		Street:      "Test Street",
+		Number:      "123",
+		PostalCode:  "12345",
+		PhoneNumber: "1234567890",
+		OwnerID:     employee.ID,
+		Latitude:    10,
+		Longitude:   10,
+		Currency:    internal.DefaultCurrency,
+		TaxRate:     internal.DefaultTaxRate,
+	})
+	require.NoError(t, err)
+
+	service, err := serviceRepo.Insert(internal.Service{Name: "Oil change", Time: 1, Price: 12300, GarageID: garage.ID})
+	require.NoError(t, err)
+
+	customer, err := customerRepo.Insert(internal.Customer{Email: "test@test.com", Password: "password123"})
+	require.NoError(t, err)
+
+	appointment, err := appointmentRepo.Insert(internal.Appointment{
+		StartTime:  time.Now().Add(-2 * time.Hour),
+		EndTime:    time.Now().Add(-time.Hour),
+		ServiceID:  service.ID,
+		EmployeeID: employee.ID,
+		CustomerID: customer.ID,
+		ModelID:    1,
+	})
+	require.NoError(t, err)
+
+	_, err = workOrderRepo.GetByAppointmentID(appointment.ID)
+	assert.Error(t, err)
+
+	workOrder, err := workOrderRepo.Save(internal.WorkOrder{AppointmentID: appointment.ID, Findings: "Worn brake pads"})
+	require.NoError(t, err)
+
+	updatedWorkOrder, err := workOrderRepo.Save(internal.WorkOrder{AppointmentID: appointment.ID, Findings: "Worn brake pads and discs"})
+	require.NoError(t, err)
+	assert.Equal(t, workOrder.ID, updatedWorkOrder.ID)
+
+	photo, err := workOrderRepo.InsertPhoto(internal.WorkOrderPhoto{WorkOrderID: workOrder.ID, Photo: []byte("photo")})
+	require.NoError(t, err)
+
+	retrievedWorkOrder, err := workOrderRepo.GetByAppointmentID(appointment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Worn brake pads and discs", retrievedWorkOrder.Findings)
+	require.Len(t, retrievedWorkOrder.Photos, 1)
+	assert.Equal(t, []byte("photo"), retrievedWorkOrder.Photos[0].Photo)
+
+	err = workOrderRepo.DeletePhoto(photo.ID)
+	require.NoError(t, err)
+
+	retrievedWorkOrder, err = workOrderRepo.GetByAppointmentID(appointment.ID)
+	require.NoError(t, err)
+	assert.Len(t, retrievedWorkOrder.Photos, 0)
+}
@@ -19,6 +19,24 @@ type IStorage interface {
 	Customers() Customers
 	Appointments() Appointments
 	Cars() Cars
+	GarageRoles() GarageRoles
+	OwnershipTransfers() OwnershipTransfers
+	Organizations() Organizations
+	Vehicles() Vehicles
+	ServiceRules() ServiceRules
+	ServiceCategories() ServiceCategories
+	Invoices() Invoices
+	Parts() Parts
+	WorkOrders() WorkOrders
+	AdditionalItems() AdditionalItems
+	Messages() Messages
+	Webhooks() Webhooks
+	CalendarTokens() CalendarTokens
+	WaitlistEntries() WaitlistEntries
+	SlotHolds() SlotHolds
+	AppointmentSeries() AppointmentSeries
+	Companies() Companies
+	CustomerInvitations() CustomerInvitations
 }
 
 type Employees interface {
@@ -26,26 +44,43 @@ type Employees interface {
 	GetByEmail(email string) (internal.Employee, error)
 	Update(employee internal.Employee) error
 	ListConfirmedByGarageID(garageID int) ([]internal.Employee, error)
+	ListQualifiedByServiceID(garageID, serviceID int) ([]internal.Employee, error)
 	ListByGarageID(garageID int) ([]internal.Employee, error)
 	GetConfirmedByID(ID int) (internal.Employee, error)
 	GetByID(ID int) (internal.Employee, error)
 	Delete(ID int) error
 	UpdateProfilePicture(ID int, profilePicture []byte) error
+	UpdateGarageRole(ID int, garageRoleID *int) error
+	ListOwnersByGarageID(garageID int) ([]internal.Employee, error)
+	AddGarage(ID, garageID int) error
+	RemoveGarage(ID, garageID int) error
+	UpdateActiveGarage(ID int, garageID *int) error
+	IsQualified(ID, serviceID int) (bool, error)
+	ListServiceIDs(ID, garageID int) ([]int, error)
+	UpdateServices(ID, garageID int, serviceIDs []int) error
 }
 
 type Garages interface {
 	Insert(garage internal.Garage) (internal.Garage, error)
-	GetByOwnerID(employeeID int) (internal.Garage, error)
 	GetByID(ID int) (internal.Garage, error)
 	List(page int, query string, latitude, longitude float64, sortBy string) ([]internal.Garage, error)
 	Update(garage internal.Garage) error
 	UpdateLogo(ID int, logo []byte) error
+	AddOwner(garageID, employeeID int) error
+	RemoveOwner(garageID, employeeID int) error
+	ListByEmployeeID(employeeID int) ([]internal.Garage, error)
+	ListByOrganizationID(organizationID int) ([]internal.Garage, error)
+	UpdateOrganization(ID int, organizationID *int) error
 }
 
 type Services interface {
 	Insert(service internal.Service) (internal.Service, error)
 	ListByGarageID(garageID int) ([]internal.Service, error)
+	Search(page int, query string, categoryID int) ([]internal.Service, error)
 	GetByID(ID int) (internal.Service, error)
+	Update(service internal.Service) error
+	InsertVersion(version internal.ServiceVersion) (internal.ServiceVersion, error)
+	ListVersions(serviceID int) ([]internal.ServiceVersion, error)
 	Delete(ID int) error
 }
 
@@ -58,6 +93,9 @@ type ConfirmationCodes interface {
 type Customers interface {
 	Insert(customer internal.Customer) (internal.Customer, error)
 	GetByEmail(email string) (internal.Customer, error)
+	GetByID(ID int) (internal.Customer, error)
+	UpdateProfile(customer internal.Customer) error
+	Claim(customer internal.Customer) error
 }
 
 type Appointments interface {
@@ -65,27 +103,215 @@ type Appointments interface {
 	GetByTimeSlot(slot internal.TimeSlot, employeeID int) ([]internal.Appointment, error)
 	GetByEmployeeID(employeeID int, date time.Time) ([]internal.Appointment, error)
 	GetByGarageID(garageID int, date time.Time) ([]internal.Appointment, error)
+	ListByEmployeeIDBetween(employeeID int, start, end time.Time) ([]internal.Appointment, error)
+	ListByGarageIDBetween(garageID int, start, end time.Time) ([]internal.Appointment, error)
 	GetByCustomerID(customerID int) ([]internal.Appointment, error)
 	GetByID(ID int) (internal.Appointment, error)
 	Update(appointment internal.Appointment) error
 	ListByGarageID(garageID int) ([]internal.Appointment, error)
+	ListByVehicleID(vehicleID int) ([]internal.Appointment, error)
+	ListBySeriesID(seriesID int) ([]internal.Appointment, error)
+	ListByCompanyID(companyID int) ([]internal.Appointment, error)
+	ListUninvoicedByCompanyID(companyID, garageID int, start, end time.Time) ([]internal.Appointment, error)
 	Delete(ID int) error
+	ListServices(appointmentID int) ([]internal.AppointmentService, error)
 }
 
 type Cars interface {
 	ListMakes() ([]internal.Make, error)
 	ListModels(makeID int) ([]internal.Model, error)
 	GetByModelID(modelID int) (internal.Car, error)
+	GetMakeByID(ID int) (internal.Make, error)
+	GetModelByID(ID int) (internal.Model, error)
+	InsertMake(carMake internal.Make) (internal.Make, error)
+	UpdateMake(carMake internal.Make) error
+	InsertModel(model internal.Model) (internal.Model, error)
+	UpdateModel(model internal.Model) error
+	UpsertMake(name string) (int, error)
+	UpsertModel(model internal.Model) (int, error)
+	MergeMakes(sourceID, targetID int) error
+	MergeModels(sourceID, targetID int) error
+}
+
+type GarageRoles interface {
+	Insert(role internal.GarageRole) (internal.GarageRole, error)
+	GetByID(ID int) (internal.GarageRole, error)
+	ListByGarageID(garageID int) ([]internal.GarageRole, error)
+	Update(role internal.GarageRole) error
+	Delete(ID int) error
+}
+
+type OwnershipTransfers interface {
+	Insert(transfer internal.OwnershipTransfer) (internal.OwnershipTransfer, error)
+	GetByID(ID int) (internal.OwnershipTransfer, error)
+	ListPendingByEmployeeID(employeeID int) ([]internal.OwnershipTransfer, error)
+	ListPendingByGarageID(garageID int) ([]internal.OwnershipTransfer, error)
+	Update(transfer internal.OwnershipTransfer) error
+	Complete(transfer internal.OwnershipTransfer) error
+	Delete(ID int) error
+}
+
+type Organizations interface {
+	Insert(organization internal.Organization) (internal.Organization, error)
+	GetByID(ID int) (internal.Organization, error)
+	Report(ID int, from, to time.Time) ([]internal.GarageReport, error)
+}
+
+type Vehicles interface {
+	Insert(vehicle internal.Vehicle) (internal.Vehicle, error)
+	GetByID(ID int) (internal.Vehicle, error)
+	ListByCustomerID(customerID int) ([]internal.Vehicle, error)
+	ListByCompanyID(companyID int) ([]internal.Vehicle, error)
+	Update(vehicle internal.Vehicle) error
+	Delete(ID int) error
+}
+
+type ServiceRules interface {
+	Insert(rule internal.ServiceRule) (internal.ServiceRule, error)
+	GetByID(ID int) (internal.ServiceRule, error)
+	ListByServiceID(serviceID int) ([]internal.ServiceRule, error)
+	Delete(ID int) error
+}
+
+type ServiceCategories interface {
+	List() ([]internal.ServiceCategory, error)
+	ListByGarageID(garageID int) ([]internal.ServiceCategory, error)
+	GetByID(ID int) (internal.ServiceCategory, error)
+	Upsert(garageID int, name string) (internal.ServiceCategory, error)
+	Update(category internal.ServiceCategory) error
+	Delete(ID int) error
+}
+
+type Invoices interface {
+	Insert(invoice internal.Invoice) (internal.Invoice, error)
+	GetByAppointmentID(appointmentID int) (internal.Invoice, error)
+	GetByID(ID int) (internal.Invoice, error)
+	ListByCompanyID(companyID int) ([]internal.Invoice, error)
+}
+
+type Parts interface {
+	Insert(part internal.Part) (internal.Part, error)
+	ListByGarageID(garageID int) ([]internal.Part, error)
+	GetByID(ID int) (internal.Part, error)
+	Update(part internal.Part) error
+	Delete(ID int) error
+	Use(appointmentPart internal.AppointmentPart) (internal.AppointmentPart, internal.Part, error)
+	Return(appointmentPart internal.AppointmentPart) error
+	GetAppointmentPartByID(ID int) (internal.AppointmentPart, error)
+	ListByAppointmentID(appointmentID int) ([]internal.AppointmentPart, error)
+	Usage(garageID int, from, to time.Time) ([]internal.PartUsage, error)
+}
+
+type WorkOrders interface {
+	Save(workOrder internal.WorkOrder) (internal.WorkOrder, error)
+	GetByAppointmentID(appointmentID int) (internal.WorkOrder, error)
+	InsertPhoto(photo internal.WorkOrderPhoto) (internal.WorkOrderPhoto, error)
+	DeletePhoto(ID int) error
+}
+
+type AdditionalItems interface {
+	Insert(item internal.AdditionalItem) (internal.AdditionalItem, error)
+	GetByID(ID int) (internal.AdditionalItem, error)
+	ListByAppointmentID(appointmentID int) ([]internal.AdditionalItem, error)
+	Update(item internal.AdditionalItem) error
+}
+
+type Messages interface {
+	Insert(message internal.Message) (internal.Message, error)
+	ListByAppointmentID(appointmentID int) ([]internal.Message, error)
+	MarkAsRead(appointmentID int, fromCustomer bool) error
+	CountUnread(appointmentIDs []int, fromCustomer bool) (map[int]int, error)
+}
+
+type Webhooks interface {
+	Insert(webhook internal.Webhook) (internal.Webhook, error)
+	GetByID(ID int) (internal.Webhook, error)
+	ListByGarageID(garageID int) ([]internal.Webhook, error)
+	ListSubscribed(garageID int, eventType string) ([]internal.Webhook, error)
+	Update(webhook internal.Webhook) error
+	Delete(ID int) error
+	InsertDelivery(delivery internal.WebhookDelivery) (internal.WebhookDelivery, error)
+	ListDeliveries(webhookID int) ([]internal.WebhookDelivery, error)
+}
+
+type CalendarTokens interface {
+	Insert(token internal.CalendarToken) (internal.CalendarToken, error)
+	GetByID(ID string) (internal.CalendarToken, error)
+	GetByEmployeeID(employeeID int) (internal.CalendarToken, error)
+	GetByGarageID(garageID int) (internal.CalendarToken, error)
+	DeleteByEmployeeID(employeeID int) error
+	DeleteByGarageID(garageID int) error
+}
+
+type WaitlistEntries interface {
+	Insert(entry internal.WaitlistEntry) (internal.WaitlistEntry, error)
+	GetByID(ID int) (internal.WaitlistEntry, error)
+	ListByCustomerID(customerID int) ([]internal.WaitlistEntry, error)
+	ListByGarageID(garageID int) ([]internal.WaitlistEntry, error)
+	ListWaiting(garageID, employeeID int, startTime time.Time) ([]internal.WaitlistEntry, error)
+	UpdateStatus(ID int, status internal.WaitlistStatus, expected ...internal.WaitlistStatus) error
+	Delete(ID int) error
+}
+
+type SlotHolds interface {
+	Insert(hold internal.SlotHold) (internal.SlotHold, error)
+	InsertCheckout(hold internal.SlotHold) (internal.SlotHold, error)
+	GetByID(ID int) (internal.SlotHold, error)
+	ListActive(slot internal.TimeSlot, employeeID int) ([]internal.SlotHold, error)
+	DeleteActive(slot internal.TimeSlot, employeeID int) ([]internal.SlotHold, error)
+	DeleteExpired() ([]internal.SlotHold, error)
+	Delete(ID int) error
+	DeleteCheckoutByCustomerID(customerID int) error
+}
+
+type AppointmentSeries interface {
+	Insert(series internal.AppointmentSeries) (internal.AppointmentSeries, error)
+	GetByID(ID int) (internal.AppointmentSeries, error)
+}
+
+type Companies interface {
+	Insert(company internal.Company, adminID int) (internal.Company, error)
+	GetByID(ID int) (internal.Company, error)
+	Update(company internal.Company) error
+	GetMember(customerID int) (internal.CompanyMember, error)
+	ListMembers(companyID int) ([]internal.CompanyMember, error)
+	InsertMember(member internal.CompanyMember) error
+	UpdateMember(member internal.CompanyMember) error
+	DeleteMember(companyID, customerID int) error
+}
+
+type CustomerInvitations interface {
+	Insert(invitation internal.CustomerInvitation) (internal.CustomerInvitation, error)
+	GetByID(ID string) (internal.CustomerInvitation, error)
+	DeleteByCustomerID(ID int) error
 }
 
 type Storage struct {
-	employees         Employees
-	garages           Garages
-	services          Services
-	confirmationCodes ConfirmationCodes
-	customers         Customers
-	appointments      Appointments
-	cars              Cars
+	employees           Employees
+	garages             Garages
+	services            Services
+	confirmationCodes   ConfirmationCodes
+	customers           Customers
+	appointments        Appointments
+	cars                Cars
+	garageRoles         GarageRoles
+	ownershipTransfers  OwnershipTransfers
+	organizations       Organizations
+	vehicles            Vehicles
+	serviceRules        ServiceRules
+	serviceCategories   ServiceCategories
+	invoices            Invoices
+	parts               Parts
+	workOrders          WorkOrders
+	additionalItems     AdditionalItems
+	messages            Messages
+	webhooks            Webhooks
+	calendarTokens      CalendarTokens
+	waitlistEntries     WaitlistEntries
+	slotHolds           SlotHolds
+	appointmentSeries   AppointmentSeries
+	companies           Companies
+	customerInvitations CustomerInvitations
 }
 
 func New(url string, log *slog.Logger) (Storage, error) {
@@ -100,13 +326,31 @@ func New(url string, log *slog.Logger) (Storage, error) {
 	}
 
 	return Storage{
-		employees:         postgres.NewEmployee(connection),
-		garages:           postgres.NewGarage(connection),
-		services:          postgres.NewService(connection),
-		confirmationCodes: postgres.NewConfirmationCode(connection),
-		customers:         postgres.NewCustomer(connection),
-		appointments:      postgres.NewAppointment(connection),
-		cars:              postgres.NewCar(connection),
+		employees:           postgres.NewEmployee(connection),
+		garages:             postgres.NewGarage(connection),
+		services:            postgres.NewService(connection),
+		confirmationCodes:   postgres.NewConfirmationCode(connection),
+		customers:           postgres.NewCustomer(connection),
+		appointments:        postgres.NewAppointment(connection),
+		cars:                postgres.NewCar(connection),
+		garageRoles:         postgres.NewGarageRole(connection),
+		ownershipTransfers:  postgres.NewOwnershipTransfer(connection),
+		organizations:       postgres.NewOrganization(connection),
+		vehicles:            postgres.NewVehicle(connection),
+		serviceRules:        postgres.NewServiceRule(connection),
+		serviceCategories:   postgres.NewServiceCategory(connection),
+		invoices:            postgres.NewInvoice(connection),
+		parts:               postgres.NewPart(connection),
+		workOrders:          postgres.NewWorkOrder(connection),
+		additionalItems:     postgres.NewAdditionalItem(connection),
+		messages:            postgres.NewMessage(connection),
+		webhooks:            postgres.NewWebhook(connection),
+		calendarTokens:      postgres.NewCalendarToken(connection),
+		waitlistEntries:     postgres.NewWaitlistEntry(connection),
+		slotHolds:           postgres.NewSlotHold(connection),
+		appointmentSeries:   postgres.NewAppointmentSeries(connection),
+		companies:           postgres.NewCompany(connection),
+		customerInvitations: postgres.NewCustomerInvitation(connection),
 	}, nil
 }
 
@@ -130,13 +374,31 @@ func NewForTests(url string, log *slog.Logger) (Storage, func() error, error) {
 	}
 
 	return Storage{
-		employees:         postgres.NewEmployee(connection),
-		garages:           postgres.NewGarage(connection),
-		services:          postgres.NewService(connection),
-		confirmationCodes: postgres.NewConfirmationCode(connection),
-		customers:         postgres.NewCustomer(connection),
-		appointments:      postgres.NewAppointment(connection),
-		cars:              postgres.NewCar(connection),
+		employees:           postgres.NewEmployee(connection),
+		garages:             postgres.NewGarage(connection),
+		services:            postgres.NewService(connection),
+		confirmationCodes:   postgres.NewConfirmationCode(connection),
+		customers:           postgres.NewCustomer(connection),
+		appointments:        postgres.NewAppointment(connection),
+		cars:                postgres.NewCar(connection),
+		garageRoles:         postgres.NewGarageRole(connection),
+		ownershipTransfers:  postgres.NewOwnershipTransfer(connection),
+		organizations:       postgres.NewOrganization(connection),
+		vehicles:            postgres.NewVehicle(connection),
+		serviceRules:        postgres.NewServiceRule(connection),
+		serviceCategories:   postgres.NewServiceCategory(connection),
+		invoices:            postgres.NewInvoice(connection),
+		parts:               postgres.NewPart(connection),
+		workOrders:          postgres.NewWorkOrder(connection),
+		additionalItems:     postgres.NewAdditionalItem(connection),
+		messages:            postgres.NewMessage(connection),
+		webhooks:            postgres.NewWebhook(connection),
+		calendarTokens:      postgres.NewCalendarToken(connection),
+		waitlistEntries:     postgres.NewWaitlistEntry(connection),
+		slotHolds:           postgres.NewSlotHold(connection),
+		appointmentSeries:   postgres.NewAppointmentSeries(connection),
+		companies:           postgres.NewCompany(connection),
+		customerInvitations: postgres.NewCustomerInvitation(connection),
 	}, cleanup, nil
 }
 
@@ -167,3 +429,75 @@ func (s Storage) Appointments() Appointments {
 func (s Storage) Cars() Cars {
 	return s.cars
 }
+
+func (s Storage) GarageRoles() GarageRoles {
+	return s.garageRoles
+}
+
+func (s Storage) OwnershipTransfers() OwnershipTransfers {
+	return s.ownershipTransfers
+}
+
+func (s Storage) Organizations() Organizations {
+	return s.organizations
+}
+
+func (s Storage) Vehicles() Vehicles {
+	return s.vehicles
+}
+
+func (s Storage) ServiceRules() ServiceRules {
+	return s.serviceRules
+}
+
+func (s Storage) ServiceCategories() ServiceCategories {
+	return s.serviceCategories
+}
+
+func (s Storage) Invoices() Invoices {
+	return s.invoices
+}
+
+func (s Storage) Parts() Parts {
+	return s.parts
+}
+
+func (s Storage) WorkOrders() WorkOrders {
+	return s.workOrders
+}
+
+func (s Storage) AdditionalItems() AdditionalItems {
+	return s.additionalItems
+}
+
+func (s Storage) Messages() Messages {
+	return s.messages
+}
+
+func (s Storage) Webhooks() Webhooks {
+	return s.webhooks
+}
+
+func (s Storage) CalendarTokens() CalendarTokens {
+	return s.calendarTokens
+}
+
+func (s Storage) WaitlistEntries() WaitlistEntries {
+	return s.waitlistEntries
+}
+
+func (s Storage) SlotHolds() SlotHolds {
+	return s.slotHolds
+}
+
+func (s Storage) AppointmentSeries() AppointmentSeries {
+	return s.appointmentSeries
+}
+
+func (s Storage) Companies() Companies {
+	return s.companies
+}
+
+func (s Storage) CustomerInvitations() CustomerInvitations {
+	return s.customerInvitations
+}
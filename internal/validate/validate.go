@@ -2,11 +2,14 @@ package validate
 
 import (
 	"errors"
+	"net/url"
 	"regexp"
+	"slices"
 	"time"
 	"unicode"
 
 	"github.com/KsaweryZietara/garage/internal"
+	"github.com/KsaweryZietara/garage/internal/events"
 )
 
 func CreateEmployeeDTO(dto internal.CreateEmployeeDTO, validateEmail bool) error {
@@ -82,6 +85,18 @@ func CreateGarageDTO(dto internal.CreateGarageDTO) error {
 		return errors.New("invalid phone number format")
 	}
 
+	if dto.Currency != "" && !isCurrency(dto.Currency) {
+		return errors.New("currency must be a three-letter ISO 4217 code")
+	}
+
+	if dto.TaxRate != nil && (*dto.TaxRate < 0 || *dto.TaxRate > 10000) {
+		return errors.New("tax rate must be between 0 and 10000 basis points")
+	}
+
+	if dto.WaitlistMode != "" && !slices.Contains(internal.WaitlistModes, dto.WaitlistMode) {
+		return errors.New("unknown waitlist mode")
+	}
+
 	for _, service := range dto.Services {
 		if err := CreateServiceDTO(service); err != nil {
 			return err
@@ -140,18 +155,163 @@ func CreateAppointmentDTO(dto internal.CreateAppointmentDTO) error {
 		return errors.New("end time must be after start time")
 	}
 
-	if dto.ServiceID <= 0 {
+	if dto.ServiceID <= 0 && len(dto.ServiceIDs) == 0 {
 		return errors.New("service ID must be greater than zero")
 	}
 
+	for _, serviceID := range dto.ServiceIDs {
+		if serviceID <= 0 {
+			return errors.New("service ID must be greater than zero")
+		}
+	}
+
 	if dto.EmployeeID <= 0 {
 		return errors.New("employee ID must be greater than zero")
 	}
 
+	if dto.ModelID <= 0 && dto.VehicleID <= 0 {
+		return errors.New("model ID or vehicle ID must be greater than zero")
+	}
+
+	if dto.Recurrence != nil {
+		return RecurrenceDTO(*dto.Recurrence)
+	}
+
+	return nil
+}
+
+func CreateStaffAppointmentDTO(dto internal.CreateStaffAppointmentDTO) error {
+	if err := CreateAppointmentDTO(dto.Appointment); err != nil {
+		return err
+	}
+
+	if (dto.CustomerEmail == "") == (dto.Guest == nil) {
+		return errors.New("either customer email or guest is required")
+	}
+
+	if dto.Guest != nil {
+		if dto.Appointment.VehicleID > 0 {
+			return errors.New("guests cannot book saved vehicles")
+		}
+		return GuestDTO(*dto.Guest)
+	}
+
+	if !IsEmail(dto.CustomerEmail) {
+		return errors.New("invalid email format")
+	}
+
+	return nil
+}
+
+func GuestDTO(dto internal.GuestDTO) error {
+	if dto.Name == "" || dto.PhoneNumber == "" {
+		return errors.New("name and phone number cannot be empty")
+	}
+
+	if len(dto.Name) > 255 || len(dto.Surname) > 255 || len(dto.Email) > 255 {
+		return errors.New("name, surname and email cannot have more than 255 characters")
+	}
+
+	if !isAlpha(dto.Name) || !isAlpha(dto.Surname) {
+		return errors.New("name and surname cannot contain numbers")
+	}
+
+	if !isPhoneNumber(dto.PhoneNumber) {
+		return errors.New("invalid phone number format")
+	}
+
+	if dto.Email != "" && !IsEmail(dto.Email) {
+		return errors.New("invalid email format")
+	}
+
+	if dto.Invite && dto.Email == "" {
+		return errors.New("email is required to invite the guest")
+	}
+
+	if len(dto.PlateNumber) > 15 {
+		return errors.New("plate number cannot have more than 15 characters")
+	}
+
+	return nil
+}
+
+func RecurrenceDTO(dto internal.RecurrenceDTO) error {
+	if !slices.Contains(internal.RecurrenceFrequencies, dto.Frequency) {
+		return errors.New("unknown recurrence frequency")
+	}
+
+	if dto.Interval < 1 || dto.Interval > 12 {
+		return errors.New("recurrence interval must be between 1 and 12")
+	}
+
+	if dto.Count < 2 || dto.Count > 52 {
+		return errors.New("recurrence count must be between 2 and 52")
+	}
+
+	return nil
+}
+
+func RescheduleAppointmentDTO(dto internal.RescheduleAppointmentDTO) error {
+	if dto.StartTime.IsZero() {
+		return errors.New("start time cannot be empty")
+	}
+
+	if dto.StartTime.Before(time.Now()) {
+		return errors.New("start time cannot be in the past")
+	}
+
+	return nil
+}
+
+func CustomerProfileDTO(dto internal.CustomerProfileDTO) error {
+	if dto.Name == "" || dto.Surname == "" {
+		return errors.New("name and surname cannot be empty")
+	}
+
+	if len(dto.Name) > 255 || len(dto.Surname) > 255 {
+		return errors.New("name and surname cannot have more than 255 characters")
+	}
+
+	if !isAlpha(dto.Name) || !isAlpha(dto.Surname) {
+		return errors.New("name and surname cannot contain numbers")
+	}
+
+	if dto.PhoneNumber != "" && !isPhoneNumber(dto.PhoneNumber) {
+		return errors.New("invalid phone number format")
+	}
+
+	if !slices.Contains(internal.ContactMethods, dto.PreferredContact) {
+		return errors.New("unknown contact method")
+	}
+
+	if dto.PreferredContact != internal.EmailContact && dto.PhoneNumber == "" {
+		return errors.New("phone number is required for phone and sms contact")
+	}
+
+	return nil
+}
+
+func CreateVehicleDTO(dto internal.CreateVehicleDTO) error {
 	if dto.ModelID <= 0 {
 		return errors.New("model ID must be greater than zero")
 	}
 
+	if dto.PlateNumber == "" {
+		return errors.New("plate number cannot be empty")
+	}
+
+	if len(dto.PlateNumber) > 15 {
+		return errors.New("plate number cannot have more than 15 characters")
+	}
+
+	if dto.Year != nil && (*dto.Year < 1900 || *dto.Year > time.Now().Year()+1) {
+		return errors.New("invalid production year")
+	}
+
+	if dto.VIN != "" && !isVIN(dto.VIN) {
+		return errors.New("invalid VIN format")
+	}
+
 	return nil
 }
 
@@ -180,10 +340,189 @@ func CreateServiceDTO(dto internal.ServiceDTO) error {
 		return errors.New("service time must be greater than zero")
 	}
 
-	if dto.Price <= 0 {
+	if dto.Price.Gross <= 0 {
 		return errors.New("service price must be greater than zero")
 	}
 
+	if len(dto.Description) > 2000 {
+		return errors.New("service description cannot have more than 2000 characters")
+	}
+
+	if dto.MaxPrice != nil && dto.MaxPrice.Gross < dto.Price.Gross {
+		return errors.New("maximum price cannot be lower than price")
+	}
+
+	if dto.CategoryID != nil && *dto.CategoryID <= 0 {
+		return errors.New("category ID must be greater than zero")
+	}
+
+	return nil
+}
+
+func UpdateServiceDTO(dto internal.UpdateServiceDTO) error {
+	err := CreateServiceDTO(internal.ServiceDTO{
+		Name:        dto.Name,
+		Description: dto.Description,
+		Time:        dto.Time,
+		Price:       dto.Price,
+		MaxPrice:    dto.MaxPrice,
+		CategoryID:  dto.CategoryID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if dto.EffectiveFrom != nil && dto.EffectiveFrom.Before(time.Now().Add(-time.Minute)) {
+		return errors.New("effective date cannot be in the past")
+	}
+
+	return nil
+}
+
+func ServiceCategoryDTO(dto internal.ServiceCategoryDTO) error {
+	if dto.Name == "" {
+		return errors.New("category name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("category name cannot have more than 255 characters")
+	}
+
+	return nil
+}
+
+func EmployeeServicesDTO(dto internal.EmployeeServicesDTO) error {
+	for _, serviceID := range dto.ServiceIDs {
+		if serviceID <= 0 {
+			return errors.New("service ID must be greater than zero")
+		}
+	}
+
+	return nil
+}
+
+func ServiceRuleDTO(dto internal.ServiceRuleDTO) error {
+	if !slices.Contains(internal.ServiceRuleTypes, dto.Type) {
+		return errors.New("unknown rule type")
+	}
+
+	if dto.MakeID <= 0 {
+		return errors.New("make ID must be greater than zero")
+	}
+
+	if dto.ModelID != nil && *dto.ModelID <= 0 {
+		return errors.New("model ID must be greater than zero")
+	}
+
+	if (dto.Price != nil && *dto.Price <= 0) || (dto.Time != nil && *dto.Time <= 0) {
+		return errors.New("price and time overrides must be greater than zero")
+	}
+
+	if dto.Type == internal.ExcludeRule && (dto.Price != nil || dto.Time != nil) {
+		return errors.New("exclude rule cannot override price or time")
+	}
+
+	if dto.Type == internal.OverrideRule && dto.Price == nil && dto.Time == nil {
+		return errors.New("override rule must override price or time")
+	}
+
+	return nil
+}
+
+func GarageRoleDTO(dto internal.GarageRoleDTO) error {
+	if dto.Name == "" {
+		return errors.New("role name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("role name cannot have more than 255 characters")
+	}
+
+	for i, permission := range dto.Permissions {
+		if !slices.Contains(internal.Permissions, permission) {
+			return errors.New("unknown permission")
+		}
+		if slices.Contains(dto.Permissions[:i], permission) {
+			return errors.New("permissions cannot be duplicated")
+		}
+	}
+
+	return nil
+}
+
+func CreateOrganizationDTO(dto internal.CreateOrganizationDTO) error {
+	if dto.Name == "" {
+		return errors.New("organization name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("organization name cannot have more than 255 characters")
+	}
+
+	return nil
+}
+
+func AppointmentNotesDTO(dto internal.AppointmentNotesDTO) error {
+	if dto.Mileage != nil && *dto.Mileage < 0 {
+		return errors.New("mileage cannot be negative")
+	}
+
+	if len(dto.Notes) > 2000 {
+		return errors.New("notes cannot have more than 2000 characters")
+	}
+
+	return nil
+}
+
+func UpdateWorkOrderDTO(dto internal.UpdateWorkOrderDTO) error {
+	if dto.Mileage != nil && *dto.Mileage < 0 {
+		return errors.New("mileage cannot be negative")
+	}
+
+	if len(dto.Notes) > 2000 {
+		return errors.New("notes cannot have more than 2000 characters")
+	}
+
+	if len(dto.Findings) > 5000 {
+		return errors.New("findings cannot have more than 5000 characters")
+	}
+
+	return nil
+}
+
+func CatalogMakeDTO(dto internal.CatalogMakeDTO) error {
+	if dto.Name == "" {
+		return errors.New("make name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("make name cannot have more than 255 characters")
+	}
+
+	return nil
+}
+
+func CatalogModelDTO(dto internal.CatalogModelDTO) error {
+	if dto.Name == "" {
+		return errors.New("model name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("model name cannot have more than 255 characters")
+	}
+
+	if (dto.YearFrom != nil && *dto.YearFrom < 1900) || (dto.YearTo != nil && *dto.YearTo < 1900) {
+		return errors.New("invalid production year")
+	}
+
+	if dto.YearFrom != nil && dto.YearTo != nil && *dto.YearTo < *dto.YearFrom {
+		return errors.New("production end year cannot be before start year")
+	}
+
+	if dto.BodyType != nil && !slices.Contains(internal.BodyTypes, *dto.BodyType) {
+		return errors.New("unknown body type")
+	}
+
 	return nil
 }
 
@@ -196,6 +535,59 @@ func isAlpha(s string) bool {
 	return true
 }
 
+func CreateCompanyDTO(dto internal.CreateCompanyDTO) error {
+	if dto.Name == "" || dto.BillingEmail == "" {
+		return errors.New("name and billing email cannot be empty")
+	}
+
+	if len(dto.Name) > 255 || len(dto.BillingEmail) > 255 {
+		return errors.New("name and billing email cannot have more than 255 characters")
+	}
+
+	if len(dto.TaxID) > 32 {
+		return errors.New("tax ID cannot have more than 32 characters")
+	}
+
+	if !IsEmail(dto.BillingEmail) {
+		return errors.New("invalid email format")
+	}
+
+	return nil
+}
+
+func CreateCompanyMemberDTO(dto internal.CreateCompanyMemberDTO) error {
+	if !IsEmail(dto.Email) {
+		return errors.New("invalid email format")
+	}
+
+	return CompanyRole(dto.Role)
+}
+
+func CompanyRole(role internal.CompanyRole) error {
+	if !slices.Contains(internal.CompanyRoles, role) {
+		return errors.New("unknown company role")
+	}
+
+	return nil
+}
+
+func CreateCompanyInvoiceDTO(dto internal.CreateCompanyInvoiceDTO) error {
+	if dto.CompanyID <= 0 {
+		return errors.New("company ID must be greater than zero")
+	}
+
+	month, err := time.Parse("2006-01", dto.Month)
+	if err != nil {
+		return errors.New("invalid month format")
+	}
+
+	if month.AddDate(0, 1, 0).After(time.Now()) {
+		return errors.New("month is not over yet")
+	}
+
+	return nil
+}
+
 func IsEmail(s string) bool {
 	re := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return re.MatchString(s)
@@ -230,3 +622,179 @@ func isPhoneNumber(s string) bool {
 	re := regexp.MustCompile(`^\d{9}$`)
 	return re.MatchString(s)
 }
+
+func isCurrency(s string) bool {
+	re := regexp.MustCompile(`^[A-Z]{3}$`)
+	return re.MatchString(s)
+}
+
+func isVIN(s string) bool {
+	re := regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
+	return re.MatchString(s)
+}
+
+func CreateInvoiceDTO(dto internal.CreateInvoiceDTO) error {
+	for _, item := range dto.Items {
+		if item.Kind != internal.PartItem && item.Kind != internal.LabourItem {
+			return errors.New("invoice item kind must be PART or LABOUR")
+		}
+
+		if item.Name == "" {
+			return errors.New("invoice item name cannot be empty")
+		}
+
+		if len(item.Name) > 255 {
+			return errors.New("invoice item name cannot have more than 255 characters")
+		}
+
+		if item.Quantity <= 0 {
+			return errors.New("invoice item quantity must be greater than zero")
+		}
+
+		if item.UnitPrice.Gross <= 0 {
+			return errors.New("invoice item price must be greater than zero")
+		}
+	}
+
+	return nil
+}
+
+func PartDTO(dto internal.PartDTO) error {
+	if dto.SKU == "" {
+		return errors.New("sku cannot be empty")
+	}
+
+	if len(dto.SKU) > 64 {
+		return errors.New("sku cannot have more than 64 characters")
+	}
+
+	if dto.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	if len(dto.Name) > 255 {
+		return errors.New("name cannot have more than 255 characters")
+	}
+
+	if dto.Quantity < 0 {
+		return errors.New("quantity cannot be negative")
+	}
+
+	if dto.Cost.Gross <= 0 {
+		return errors.New("cost must be greater than zero")
+	}
+
+	if dto.ReorderThreshold < 0 {
+		return errors.New("reorder threshold cannot be negative")
+	}
+
+	return nil
+}
+
+func CreateAppointmentPartDTO(dto internal.CreateAppointmentPartDTO) error {
+	if dto.PartID <= 0 {
+		return errors.New("part id must be greater than zero")
+	}
+
+	if dto.Quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	return nil
+}
+
+func CreateAdditionalItemDTO(dto internal.CreateAdditionalItemDTO) error {
+	if dto.Description == "" {
+		return errors.New("description cannot be empty")
+	}
+
+	if len(dto.Description) > 500 {
+		return errors.New("description cannot have more than 500 characters")
+	}
+
+	if dto.Price.Gross <= 0 {
+		return errors.New("price must be greater than zero")
+	}
+
+	if dto.Time < 0 {
+		return errors.New("time cannot be negative")
+	}
+
+	return nil
+}
+
+func CreateMessageDTO(dto internal.CreateMessageDTO) error {
+	if dto.Content == "" {
+		return errors.New("message cannot be empty")
+	}
+
+	if len(dto.Content) > 2000 {
+		return errors.New("message cannot have more than 2000 characters")
+	}
+
+	return nil
+}
+
+func CreateWebhookDTO(dto internal.CreateWebhookDTO) error {
+	if len(dto.URL) > 2048 {
+		return errors.New("url cannot have more than 2048 characters")
+	}
+
+	u, err := url.Parse(dto.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url")
+	}
+
+	if len(dto.Secret) < 16 {
+		return errors.New("secret must have at least 16 characters")
+	}
+
+	if len(dto.Secret) > 255 {
+		return errors.New("secret cannot have more than 255 characters")
+	}
+
+	if len(dto.EventTypes) == 0 {
+		return errors.New("event types cannot be empty")
+	}
+
+	for i, eventType := range dto.EventTypes {
+		if !slices.Contains(events.Types, events.Type(eventType)) {
+			return errors.New("unknown event type")
+		}
+		if slices.Contains(dto.EventTypes[:i], eventType) {
+			return errors.New("event types cannot be duplicated")
+		}
+	}
+
+	return nil
+}
+
+func CreateWaitlistEntryDTO(dto internal.CreateWaitlistEntryDTO) error {
+	if dto.ServiceID <= 0 {
+		return errors.New("service ID is required")
+	}
+
+	from, err := time.Parse("2006-01-02", dto.From)
+	if err != nil {
+		return errors.New("invalid from date")
+	}
+
+	to, err := time.Parse("2006-01-02", dto.To)
+	if err != nil {
+		return errors.New("invalid to date")
+	}
+
+	if to.Before(from) {
+		return errors.New("to date cannot be before from date")
+	}
+
+	if to.Before(time.Now().Truncate(24 * time.Hour)) {
+		return errors.New("to date cannot be in the past")
+	}
+
+	if to.Sub(from) > 60*24*time.Hour {
+		return errors.New("date range cannot be longer than 60 days")
+	}
+
+	return nil
+}
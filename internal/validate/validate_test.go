@@ -285,7 +285,7 @@ func TestCreateGarageDTO(t *testing.T) {
 			Latitude:    10,
 			Longitude:   10,
 			Services: []internal.ServiceDTO{
-				{Name: "", Time: 1, Price: 1},
+				{Name: "", Time: 1, Price: internal.MoneyDTO{Gross: 1}},
 			},
 		}
 		err := CreateGarageDTO(dto)
@@ -303,7 +303,7 @@ func TestCreateGarageDTO(t *testing.T) {
 			Latitude:    10,
 			Longitude:   10,
 			Services: []internal.ServiceDTO{
-				{Name: "Service", Time: 0, Price: 1},
+				{Name: "Service", Time: 0, Price: internal.MoneyDTO{Gross: 1}},
 			},
 		}
 		err := CreateGarageDTO(dto)
@@ -321,7 +321,7 @@ func TestCreateGarageDTO(t *testing.T) {
 			Latitude:    10,
 			Longitude:   10,
 			Services: []internal.ServiceDTO{
-				{Name: "Service", Time: 1, Price: 0},
+				{Name: "Service", Time: 1, Price: internal.MoneyDTO{Gross: 0}},
 			},
 		}
 		err := CreateGarageDTO(dto)
@@ -346,6 +346,55 @@ func TestCreateGarageDTO(t *testing.T) {
 		assert.EqualError(t, err, "invalid email format")
 	})
 
+	t.Run("should return error when currency is invalid", func(t *testing.T) {
+		dto := internal.CreateGarageDTO{
+			Name:        "Name",
+			City:        "City",
+			Street:      "Street",
+			Number:      "Number",
+			PostalCode:  "12-345",
+			PhoneNumber: "123456789",
+			Latitude:    10,
+			Longitude:   10,
+			Currency:    "zl",
+		}
+		err := CreateGarageDTO(dto)
+		assert.EqualError(t, err, "currency must be a three-letter ISO 4217 code")
+	})
+
+	t.Run("should return error when tax rate exceeds 100%", func(t *testing.T) {
+		taxRate := 10001
+		dto := internal.CreateGarageDTO{
+			Name:        "Name",
+			City:        "City",
+			Street:      "Street",
+			Number:      "Number",
+			PostalCode:  "12-345",
+			PhoneNumber: "123456789",
+			Latitude:    10,
+			Longitude:   10,
+			TaxRate:     &taxRate,
+		}
+		err := CreateGarageDTO(dto)
+		assert.EqualError(t, err, "tax rate must be between 0 and 10000 basis points")
+	})
+
+	t.Run("should return error when waitlist mode is unknown", func(t *testing.T) {
+		dto := internal.CreateGarageDTO{
+			Name:         "Name",
+			City:         "City",
+			Street:       "Street",
+			Number:       "Number",
+			PostalCode:   "12-345",
+			PhoneNumber:  "123456789",
+			Latitude:     10,
+			Longitude:    10,
+			WaitlistMode: "RANDOM",
+		}
+		err := CreateGarageDTO(dto)
+		assert.EqualError(t, err, "unknown waitlist mode")
+	})
+
 	t.Run("should pass with valid input", func(t *testing.T) {
 		dto := internal.CreateGarageDTO{
 			Name:        "Name",
@@ -357,7 +406,7 @@ func TestCreateGarageDTO(t *testing.T) {
 			Latitude:    10,
 			Longitude:   10,
 			Services: []internal.ServiceDTO{
-				{Name: "Service", Time: 1, Price: 1},
+				{Name: "Service", Time: 1, Price: internal.MoneyDTO{Gross: 1}},
 			},
 			EmployeeEmails: []string{
 				"john@example.com",
@@ -578,6 +627,30 @@ func TestCreateAppointmentDTO(t *testing.T) {
 		assert.EqualError(t, err, "service ID must be greater than zero")
 	})
 
+	t.Run("should return error when one of service IDs is less than or equal to zero", func(t *testing.T) {
+		dto := internal.CreateAppointmentDTO{
+			StartTime:  time.Now().Add(time.Hour),
+			EndTime:    time.Now().Add(2 * time.Hour),
+			ServiceIDs: []int{1, 0},
+			EmployeeID: 1,
+			ModelID:    1,
+		}
+		err := CreateAppointmentDTO(dto)
+		assert.EqualError(t, err, "service ID must be greater than zero")
+	})
+
+	t.Run("should pass with service IDs only", func(t *testing.T) {
+		dto := internal.CreateAppointmentDTO{
+			StartTime:  time.Now().Add(time.Hour),
+			EndTime:    time.Now().Add(2 * time.Hour),
+			ServiceIDs: []int{1, 2},
+			EmployeeID: 1,
+			ModelID:    1,
+		}
+		err := CreateAppointmentDTO(dto)
+		assert.NoError(t, err)
+	})
+
 	t.Run("should return error when employee ID is less than or equal to zero", func(t *testing.T) {
 		dto := internal.CreateAppointmentDTO{
 			StartTime:  time.Now().Add(time.Hour),
@@ -590,7 +663,7 @@ func TestCreateAppointmentDTO(t *testing.T) {
 		assert.EqualError(t, err, "employee ID must be greater than zero")
 	})
 
-	t.Run("should return error when model ID is less than or equal to zero", func(t *testing.T) {
+	t.Run("should return error when neither model ID nor vehicle ID is given", func(t *testing.T) {
 		dto := internal.CreateAppointmentDTO{
 			StartTime:  time.Now().Add(time.Hour),
 			EndTime:    time.Now().Add(2 * time.Hour),
@@ -599,7 +672,7 @@ func TestCreateAppointmentDTO(t *testing.T) {
 			ModelID:    0,
 		}
 		err := CreateAppointmentDTO(dto)
-		assert.EqualError(t, err, "model ID must be greater than zero")
+		assert.EqualError(t, err, "model ID or vehicle ID must be greater than zero")
 	})
 
 	t.Run("should pass with valid input", func(t *testing.T) {
@@ -615,6 +688,171 @@ func TestCreateAppointmentDTO(t *testing.T) {
 	})
 }
 
+func TestCreateStaffAppointmentDTO(t *testing.T) {
+	appointment := internal.CreateAppointmentDTO{
+		StartTime:  time.Now().Add(time.Hour),
+		EndTime:    time.Now().Add(2 * time.Hour),
+		ServiceID:  1,
+		EmployeeID: 1,
+		ModelID:    1,
+	}
+
+	t.Run("should return error when appointment is invalid", func(t *testing.T) {
+		dto := internal.CreateStaffAppointmentDTO{CustomerEmail: "john.doe@example.com"}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.EqualError(t, err, "start time and end time cannot be empty")
+	})
+
+	t.Run("should return error when neither customer nor guest is given", func(t *testing.T) {
+		dto := internal.CreateStaffAppointmentDTO{Appointment: appointment}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.EqualError(t, err, "either customer email or guest is required")
+	})
+
+	t.Run("should return error when both customer and guest are given", func(t *testing.T) {
+		dto := internal.CreateStaffAppointmentDTO{
+			Appointment:   appointment,
+			CustomerEmail: "john.doe@example.com",
+			Guest:         &internal.GuestDTO{Name: "John", PhoneNumber: "123456789"},
+		}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.EqualError(t, err, "either customer email or guest is required")
+	})
+
+	t.Run("should return error for invalid customer email", func(t *testing.T) {
+		dto := internal.CreateStaffAppointmentDTO{Appointment: appointment, CustomerEmail: "john.doe"}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.EqualError(t, err, "invalid email format")
+	})
+
+	t.Run("should return error when guest books a saved vehicle", func(t *testing.T) {
+		vehicleAppointment := appointment
+		vehicleAppointment.VehicleID = 1
+		dto := internal.CreateStaffAppointmentDTO{
+			Appointment: vehicleAppointment,
+			Guest:       &internal.GuestDTO{Name: "John", PhoneNumber: "123456789"},
+		}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.EqualError(t, err, "guests cannot book saved vehicles")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CreateStaffAppointmentDTO{
+			Appointment: appointment,
+			Guest:       &internal.GuestDTO{Name: "John", PhoneNumber: "123456789"},
+		}
+		err := CreateStaffAppointmentDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGuestDTO(t *testing.T) {
+	t.Run("should return error when phone number is empty", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John"}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "name and phone number cannot be empty")
+	})
+
+	t.Run("should return error when surname contains numbers", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John", Surname: "Doe2", PhoneNumber: "123456789"}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "name and surname cannot contain numbers")
+	})
+
+	t.Run("should return error for invalid phone number", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John", PhoneNumber: "12345"}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "invalid phone number format")
+	})
+
+	t.Run("should return error for invalid email", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John", PhoneNumber: "123456789", Email: "john.doe"}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "invalid email format")
+	})
+
+	t.Run("should return error when invited guest has no email", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John", PhoneNumber: "123456789", Invite: true}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "email is required to invite the guest")
+	})
+
+	t.Run("should return error when plate number is too long", func(t *testing.T) {
+		dto := internal.GuestDTO{Name: "John", PhoneNumber: "123456789", PlateNumber: "WA1234567890ABCD"}
+		err := GuestDTO(dto)
+		assert.EqualError(t, err, "plate number cannot have more than 15 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.GuestDTO{
+			Name:        "John",
+			Surname:     "Doe",
+			PhoneNumber: "123456789",
+			Email:       "john.doe@example.com",
+			PlateNumber: "WA12345",
+			Invite:      true,
+		}
+		err := GuestDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRecurrenceDTO(t *testing.T) {
+	t.Run("should return error when frequency is unknown", func(t *testing.T) {
+		dto := internal.RecurrenceDTO{Frequency: "DAILY", Interval: 1, Count: 4}
+		err := RecurrenceDTO(dto)
+		assert.EqualError(t, err, "unknown recurrence frequency")
+	})
+
+	t.Run("should return error when interval is out of range", func(t *testing.T) {
+		dto := internal.RecurrenceDTO{Frequency: internal.WeeklyRecurrence, Interval: 0, Count: 4}
+		err := RecurrenceDTO(dto)
+		assert.EqualError(t, err, "recurrence interval must be between 1 and 12")
+	})
+
+	t.Run("should return error when count is out of range", func(t *testing.T) {
+		dto := internal.RecurrenceDTO{Frequency: internal.MonthlyRecurrence, Interval: 1, Count: 1}
+		err := RecurrenceDTO(dto)
+		assert.EqualError(t, err, "recurrence count must be between 2 and 52")
+	})
+
+	t.Run("should return error from create appointment DTO", func(t *testing.T) {
+		dto := internal.CreateAppointmentDTO{
+			StartTime:  time.Now().Add(time.Hour),
+			EndTime:    time.Now().Add(2 * time.Hour),
+			ServiceID:  1,
+			EmployeeID: 1,
+			ModelID:    1,
+			Recurrence: &internal.RecurrenceDTO{Frequency: internal.WeeklyRecurrence, Interval: 2, Count: 53},
+		}
+		err := CreateAppointmentDTO(dto)
+		assert.EqualError(t, err, "recurrence count must be between 2 and 52")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.RecurrenceDTO{Frequency: internal.WeeklyRecurrence, Interval: 2, Count: 6}
+		err := RecurrenceDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRescheduleAppointmentDTO(t *testing.T) {
+	t.Run("should return error when start time is zero", func(t *testing.T) {
+		err := RescheduleAppointmentDTO(internal.RescheduleAppointmentDTO{})
+		assert.EqualError(t, err, "start time cannot be empty")
+	})
+
+	t.Run("should return error when start time is in the past", func(t *testing.T) {
+		err := RescheduleAppointmentDTO(internal.RescheduleAppointmentDTO{StartTime: time.Now().Add(-time.Hour)})
+		assert.EqualError(t, err, "start time cannot be in the past")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := RescheduleAppointmentDTO(internal.RescheduleAppointmentDTO{StartTime: time.Now().Add(time.Hour)})
+		assert.NoError(t, err)
+	})
+}
+
 func TestCreateReviewDTO(t *testing.T) {
 	t.Run("should return error when rating is less than 1", func(t *testing.T) {
 		dto := internal.CreateReviewDTO{
@@ -659,7 +897,7 @@ func TestCreateServiceDTO(t *testing.T) {
 		dto := internal.ServiceDTO{
 			Name:  "",
 			Time:  1,
-			Price: 1,
+			Price: internal.MoneyDTO{Gross: 1},
 		}
 		err := CreateServiceDTO(dto)
 		assert.EqualError(t, err, "service name cannot be empty")
@@ -669,7 +907,7 @@ func TestCreateServiceDTO(t *testing.T) {
 		dto := internal.ServiceDTO{
 			Name:  "service",
 			Time:  0,
-			Price: 1,
+			Price: internal.MoneyDTO{Gross: 1},
 		}
 		err := CreateServiceDTO(dto)
 		assert.EqualError(t, err, "service time must be greater than zero")
@@ -679,19 +917,684 @@ func TestCreateServiceDTO(t *testing.T) {
 		dto := internal.ServiceDTO{
 			Name:  "service",
 			Time:  1,
-			Price: 0,
+			Price: internal.MoneyDTO{Gross: 0},
 		}
 		err := CreateServiceDTO(dto)
 		assert.EqualError(t, err, "service price must be greater than zero")
 	})
 
+	t.Run("should return error when maximum price is lower than price", func(t *testing.T) {
+		maxPrice := internal.MoneyDTO{Gross: 50}
+		dto := internal.ServiceDTO{
+			Name:     "service",
+			Time:     1,
+			Price:    internal.MoneyDTO{Gross: 100},
+			MaxPrice: &maxPrice,
+		}
+		err := CreateServiceDTO(dto)
+		assert.EqualError(t, err, "maximum price cannot be lower than price")
+	})
+
+	t.Run("should return error when description exceeds 2000 characters", func(t *testing.T) {
+		dto := internal.ServiceDTO{
+			Name:        "service",
+			Description: strings.Repeat("a", 2001),
+			Time:        1,
+			Price:       internal.MoneyDTO{Gross: 1},
+		}
+		err := CreateServiceDTO(dto)
+		assert.EqualError(t, err, "service description cannot have more than 2000 characters")
+	})
+
 	t.Run("should pass with valid input", func(t *testing.T) {
 		dto := internal.ServiceDTO{
 			Name:  "service",
 			Time:  1,
-			Price: 1,
+			Price: internal.MoneyDTO{Gross: 1},
 		}
 		err := CreateServiceDTO(dto)
 		assert.NoError(t, err)
 	})
 }
+
+func TestUpdateServiceDTO(t *testing.T) {
+	t.Run("should return error when service price is zero", func(t *testing.T) {
+		dto := internal.UpdateServiceDTO{
+			Name:  "service",
+			Time:  1,
+			Price: internal.MoneyDTO{Gross: 0},
+		}
+		err := UpdateServiceDTO(dto)
+		assert.EqualError(t, err, "service price must be greater than zero")
+	})
+
+	t.Run("should return error when effective date is in the past", func(t *testing.T) {
+		effectiveFrom := time.Now().Add(-time.Hour)
+		dto := internal.UpdateServiceDTO{
+			Name:          "service",
+			Time:          1,
+			Price:         internal.MoneyDTO{Gross: 1},
+			EffectiveFrom: &effectiveFrom,
+		}
+		err := UpdateServiceDTO(dto)
+		assert.EqualError(t, err, "effective date cannot be in the past")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		effectiveFrom := time.Now().Add(24 * time.Hour)
+		dto := internal.UpdateServiceDTO{
+			Name:          "service",
+			Time:          1,
+			Price:         internal.MoneyDTO{Gross: 1},
+			EffectiveFrom: &effectiveFrom,
+		}
+		err := UpdateServiceDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestServiceCategoryDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		err := ServiceCategoryDTO(internal.ServiceCategoryDTO{Name: ""})
+		assert.EqualError(t, err, "category name cannot be empty")
+	})
+
+	t.Run("should return error when name exceeds 255 characters", func(t *testing.T) {
+		err := ServiceCategoryDTO(internal.ServiceCategoryDTO{Name: strings.Repeat("a", 256)})
+		assert.EqualError(t, err, "category name cannot have more than 255 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := ServiceCategoryDTO(internal.ServiceCategoryDTO{Name: "Tyres"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGarageRoleDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		dto := internal.GarageRoleDTO{
+			Name:        "",
+			Permissions: []internal.Permission{internal.ServicesWritePermission},
+		}
+		err := GarageRoleDTO(dto)
+		assert.EqualError(t, err, "role name cannot be empty")
+	})
+
+	t.Run("should return error when name exceeds 255 characters", func(t *testing.T) {
+		dto := internal.GarageRoleDTO{
+			Name:        strings.Repeat("a", 256),
+			Permissions: []internal.Permission{internal.ServicesWritePermission},
+		}
+		err := GarageRoleDTO(dto)
+		assert.EqualError(t, err, "role name cannot have more than 255 characters")
+	})
+
+	t.Run("should return error for unknown permission", func(t *testing.T) {
+		dto := internal.GarageRoleDTO{
+			Name:        "manager",
+			Permissions: []internal.Permission{"logo:delete"},
+		}
+		err := GarageRoleDTO(dto)
+		assert.EqualError(t, err, "unknown permission")
+	})
+
+	t.Run("should return error for duplicated permission", func(t *testing.T) {
+		dto := internal.GarageRoleDTO{
+			Name: "manager",
+			Permissions: []internal.Permission{
+				internal.ServicesWritePermission,
+				internal.ServicesWritePermission,
+			},
+		}
+		err := GarageRoleDTO(dto)
+		assert.EqualError(t, err, "permissions cannot be duplicated")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.GarageRoleDTO{
+			Name: "receptionist",
+			Permissions: []internal.Permission{
+				internal.AppointmentsManagePermission,
+			},
+		}
+		err := GarageRoleDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrganizationDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		dto := internal.CreateOrganizationDTO{Name: ""}
+		err := CreateOrganizationDTO(dto)
+		assert.EqualError(t, err, "organization name cannot be empty")
+	})
+
+	t.Run("should return error when name exceeds 255 characters", func(t *testing.T) {
+		dto := internal.CreateOrganizationDTO{Name: strings.Repeat("a", 256)}
+		err := CreateOrganizationDTO(dto)
+		assert.EqualError(t, err, "organization name cannot have more than 255 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CreateOrganizationDTO{Name: "Garage Group"}
+		err := CreateOrganizationDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCustomerProfileDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		dto := internal.CustomerProfileDTO{
+			Surname:          "Doe",
+			PreferredContact: internal.EmailContact,
+		}
+		err := CustomerProfileDTO(dto)
+		assert.EqualError(t, err, "name and surname cannot be empty")
+	})
+
+	t.Run("should return error for invalid phone number", func(t *testing.T) {
+		dto := internal.CustomerProfileDTO{
+			Name:             "John",
+			Surname:          "Doe",
+			PhoneNumber:      "12345",
+			PreferredContact: internal.EmailContact,
+		}
+		err := CustomerProfileDTO(dto)
+		assert.EqualError(t, err, "invalid phone number format")
+	})
+
+	t.Run("should return error for unknown contact method", func(t *testing.T) {
+		dto := internal.CustomerProfileDTO{
+			Name:             "John",
+			Surname:          "Doe",
+			PreferredContact: "FAX",
+		}
+		err := CustomerProfileDTO(dto)
+		assert.EqualError(t, err, "unknown contact method")
+	})
+
+	t.Run("should return error when phone contact has no phone number", func(t *testing.T) {
+		dto := internal.CustomerProfileDTO{
+			Name:             "John",
+			Surname:          "Doe",
+			PreferredContact: internal.SMSContact,
+		}
+		err := CustomerProfileDTO(dto)
+		assert.EqualError(t, err, "phone number is required for phone and sms contact")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CustomerProfileDTO{
+			Name:             "John",
+			Surname:          "Doe",
+			PhoneNumber:      "123456789",
+			PreferredContact: internal.PhoneContact,
+		}
+		err := CustomerProfileDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateVehicleDTO(t *testing.T) {
+	year := 2015
+
+	t.Run("should return error when model ID is less than or equal to zero", func(t *testing.T) {
+		dto := internal.CreateVehicleDTO{PlateNumber: "WA12345"}
+		err := CreateVehicleDTO(dto)
+		assert.EqualError(t, err, "model ID must be greater than zero")
+	})
+
+	t.Run("should return error when plate number is empty", func(t *testing.T) {
+		dto := internal.CreateVehicleDTO{ModelID: 1}
+		err := CreateVehicleDTO(dto)
+		assert.EqualError(t, err, "plate number cannot be empty")
+	})
+
+	t.Run("should return error for invalid year", func(t *testing.T) {
+		invalidYear := 1850
+		dto := internal.CreateVehicleDTO{ModelID: 1, PlateNumber: "WA12345", Year: &invalidYear}
+		err := CreateVehicleDTO(dto)
+		assert.EqualError(t, err, "invalid production year")
+	})
+
+	t.Run("should return error for invalid VIN", func(t *testing.T) {
+		dto := internal.CreateVehicleDTO{ModelID: 1, PlateNumber: "WA12345", VIN: "WVWZZZ1JZXW00000O"}
+		err := CreateVehicleDTO(dto)
+		assert.EqualError(t, err, "invalid VIN format")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CreateVehicleDTO{ModelID: 1, PlateNumber: "WA12345", Year: &year, VIN: "WVWZZZ1JZXW000001"}
+		err := CreateVehicleDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAppointmentNotesDTO(t *testing.T) {
+	t.Run("should return error for negative mileage", func(t *testing.T) {
+		mileage := -1
+		dto := internal.AppointmentNotesDTO{Mileage: &mileage}
+		err := AppointmentNotesDTO(dto)
+		assert.EqualError(t, err, "mileage cannot be negative")
+	})
+
+	t.Run("should return error when notes exceed 2000 characters", func(t *testing.T) {
+		dto := internal.AppointmentNotesDTO{Notes: strings.Repeat("a", 2001)}
+		err := AppointmentNotesDTO(dto)
+		assert.EqualError(t, err, "notes cannot have more than 2000 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		mileage := 120000
+		dto := internal.AppointmentNotesDTO{Mileage: &mileage, Notes: "Replaced oil filter"}
+		err := AppointmentNotesDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateWorkOrderDTO(t *testing.T) {
+	t.Run("should return error for negative mileage", func(t *testing.T) {
+		mileage := -1
+		dto := internal.UpdateWorkOrderDTO{Mileage: &mileage}
+		err := UpdateWorkOrderDTO(dto)
+		assert.EqualError(t, err, "mileage cannot be negative")
+	})
+
+	t.Run("should return error when findings exceed 5000 characters", func(t *testing.T) {
+		dto := internal.UpdateWorkOrderDTO{Findings: strings.Repeat("a", 5001)}
+		err := UpdateWorkOrderDTO(dto)
+		assert.EqualError(t, err, "findings cannot have more than 5000 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		mileage := 120000
+		dto := internal.UpdateWorkOrderDTO{Mileage: &mileage, Notes: "Replaced oil filter", Findings: "Worn brake pads"}
+		err := UpdateWorkOrderDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatalogMakeDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		err := CatalogMakeDTO(internal.CatalogMakeDTO{})
+		assert.EqualError(t, err, "make name cannot be empty")
+	})
+
+	t.Run("should return error when name exceeds 255 characters", func(t *testing.T) {
+		err := CatalogMakeDTO(internal.CatalogMakeDTO{Name: strings.Repeat("a", 256)})
+		assert.EqualError(t, err, "make name cannot have more than 255 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CatalogMakeDTO(internal.CatalogMakeDTO{Name: "Skoda"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatalogModelDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		err := CatalogModelDTO(internal.CatalogModelDTO{})
+		assert.EqualError(t, err, "model name cannot be empty")
+	})
+
+	t.Run("should return error when name exceeds 255 characters", func(t *testing.T) {
+		err := CatalogModelDTO(internal.CatalogModelDTO{Name: strings.Repeat("a", 256)})
+		assert.EqualError(t, err, "model name cannot have more than 255 characters")
+	})
+
+	t.Run("should return error for invalid year", func(t *testing.T) {
+		yearFrom := 1850
+		err := CatalogModelDTO(internal.CatalogModelDTO{Name: "Octavia", YearFrom: &yearFrom})
+		assert.EqualError(t, err, "invalid production year")
+	})
+
+	t.Run("should return error when end year is before start year", func(t *testing.T) {
+		yearFrom, yearTo := 2004, 1996
+		err := CatalogModelDTO(internal.CatalogModelDTO{Name: "Octavia", YearFrom: &yearFrom, YearTo: &yearTo})
+		assert.EqualError(t, err, "production end year cannot be before start year")
+	})
+
+	t.Run("should return error for unknown body type", func(t *testing.T) {
+		bodyType := internal.BodyType("SPACESHIP")
+		err := CatalogModelDTO(internal.CatalogModelDTO{Name: "Octavia", BodyType: &bodyType})
+		assert.EqualError(t, err, "unknown body type")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		yearFrom, yearTo := 1996, 2004
+		bodyType := internal.EstateBody
+		err := CatalogModelDTO(internal.CatalogModelDTO{Name: "Octavia", YearFrom: &yearFrom, YearTo: &yearTo, BodyType: &bodyType})
+		assert.NoError(t, err)
+	})
+}
+
+func TestServiceRuleDTO(t *testing.T) {
+	modelID := 1
+	price := 150
+	invalidTime := 0
+
+	t.Run("should return error for unknown rule type", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: "ONLY", MakeID: 1})
+		assert.EqualError(t, err, "unknown rule type")
+	})
+
+	t.Run("should return error when make ID is less than or equal to zero", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: internal.AllowRule})
+		assert.EqualError(t, err, "make ID must be greater than zero")
+	})
+
+	t.Run("should return error for non-positive overrides", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: internal.OverrideRule, MakeID: 1, Time: &invalidTime})
+		assert.EqualError(t, err, "price and time overrides must be greater than zero")
+	})
+
+	t.Run("should return error when exclude rule overrides price", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: internal.ExcludeRule, MakeID: 1, Price: &price})
+		assert.EqualError(t, err, "exclude rule cannot override price or time")
+	})
+
+	t.Run("should return error when override rule has no overrides", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: internal.OverrideRule, MakeID: 1})
+		assert.EqualError(t, err, "override rule must override price or time")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := ServiceRuleDTO(internal.ServiceRuleDTO{Type: internal.AllowRule, MakeID: 1, ModelID: &modelID, Price: &price})
+		assert.NoError(t, err)
+	})
+}
+
+func TestEmployeeServicesDTO(t *testing.T) {
+	t.Run("should return error when service ID is less than or equal to zero", func(t *testing.T) {
+		err := EmployeeServicesDTO(internal.EmployeeServicesDTO{ServiceIDs: []int{1, 0}})
+		assert.EqualError(t, err, "service ID must be greater than zero")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := EmployeeServicesDTO(internal.EmployeeServicesDTO{ServiceIDs: []int{1, 2}})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateInvoiceDTO(t *testing.T) {
+	t.Run("should return error when kind is a service", func(t *testing.T) {
+		dto := internal.CreateInvoiceDTO{Items: []internal.InvoiceItemDTO{
+			{Kind: internal.ServiceItem, Name: "Oil change", Quantity: 1, UnitPrice: internal.MoneyDTO{Gross: 100}},
+		}}
+		err := CreateInvoiceDTO(dto)
+		assert.EqualError(t, err, "invoice item kind must be PART or LABOUR")
+	})
+
+	t.Run("should return error when quantity is zero", func(t *testing.T) {
+		dto := internal.CreateInvoiceDTO{Items: []internal.InvoiceItemDTO{
+			{Kind: internal.PartItem, Name: "Oil filter", Quantity: 0, UnitPrice: internal.MoneyDTO{Gross: 100}},
+		}}
+		err := CreateInvoiceDTO(dto)
+		assert.EqualError(t, err, "invoice item quantity must be greater than zero")
+	})
+
+	t.Run("should return error when price is zero", func(t *testing.T) {
+		dto := internal.CreateInvoiceDTO{Items: []internal.InvoiceItemDTO{
+			{Kind: internal.LabourItem, Name: "Extra hour", Quantity: 1},
+		}}
+		err := CreateInvoiceDTO(dto)
+		assert.EqualError(t, err, "invoice item price must be greater than zero")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CreateInvoiceDTO{Items: []internal.InvoiceItemDTO{
+			{Kind: internal.PartItem, Name: "Oil filter", Quantity: 2, UnitPrice: internal.MoneyDTO{Gross: 2460}},
+		}}
+		err := CreateInvoiceDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPartDTO(t *testing.T) {
+	t.Run("should return error when sku is empty", func(t *testing.T) {
+		dto := internal.PartDTO{Name: "Oil filter", Quantity: 5, Cost: internal.MoneyDTO{Gross: 2460}}
+		err := PartDTO(dto)
+		assert.EqualError(t, err, "sku cannot be empty")
+	})
+
+	t.Run("should return error when quantity is negative", func(t *testing.T) {
+		dto := internal.PartDTO{SKU: "OF-100", Name: "Oil filter", Quantity: -1, Cost: internal.MoneyDTO{Gross: 2460}}
+		err := PartDTO(dto)
+		assert.EqualError(t, err, "quantity cannot be negative")
+	})
+
+	t.Run("should return error when cost is zero", func(t *testing.T) {
+		dto := internal.PartDTO{SKU: "OF-100", Name: "Oil filter", Quantity: 5}
+		err := PartDTO(dto)
+		assert.EqualError(t, err, "cost must be greater than zero")
+	})
+
+	t.Run("should return error when reorder threshold is negative", func(t *testing.T) {
+		dto := internal.PartDTO{SKU: "OF-100", Name: "Oil filter", Quantity: 5, Cost: internal.MoneyDTO{Gross: 2460}, ReorderThreshold: -1}
+		err := PartDTO(dto)
+		assert.EqualError(t, err, "reorder threshold cannot be negative")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.PartDTO{SKU: "OF-100", Name: "Oil filter", Quantity: 5, Cost: internal.MoneyDTO{Gross: 2460}, ReorderThreshold: 2}
+		err := PartDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateAppointmentPartDTO(t *testing.T) {
+	t.Run("should return error when quantity is zero", func(t *testing.T) {
+		err := CreateAppointmentPartDTO(internal.CreateAppointmentPartDTO{PartID: 1})
+		assert.EqualError(t, err, "quantity must be greater than zero")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateAppointmentPartDTO(internal.CreateAppointmentPartDTO{PartID: 1, Quantity: 2})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateAdditionalItemDTO(t *testing.T) {
+	t.Run("should return error when description is empty", func(t *testing.T) {
+		dto := internal.CreateAdditionalItemDTO{Price: internal.MoneyDTO{Gross: 25000}, Time: 1}
+		err := CreateAdditionalItemDTO(dto)
+		assert.EqualError(t, err, "description cannot be empty")
+	})
+
+	t.Run("should return error when price is zero", func(t *testing.T) {
+		dto := internal.CreateAdditionalItemDTO{Description: "Replace brake discs", Time: 1}
+		err := CreateAdditionalItemDTO(dto)
+		assert.EqualError(t, err, "price must be greater than zero")
+	})
+
+	t.Run("should return error when time is negative", func(t *testing.T) {
+		dto := internal.CreateAdditionalItemDTO{Description: "Replace brake discs", Price: internal.MoneyDTO{Gross: 25000}, Time: -1}
+		err := CreateAdditionalItemDTO(dto)
+		assert.EqualError(t, err, "time cannot be negative")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		dto := internal.CreateAdditionalItemDTO{Description: "Replace brake discs", Price: internal.MoneyDTO{Gross: 25000}, Time: 1}
+		err := CreateAdditionalItemDTO(dto)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateMessageDTO(t *testing.T) {
+	t.Run("should return error when message is empty", func(t *testing.T) {
+		err := CreateMessageDTO(internal.CreateMessageDTO{})
+		assert.EqualError(t, err, "message cannot be empty")
+	})
+
+	t.Run("should return error when message exceeds 2000 characters", func(t *testing.T) {
+		err := CreateMessageDTO(internal.CreateMessageDTO{Content: strings.Repeat("a", 2001)})
+		assert.EqualError(t, err, "message cannot have more than 2000 characters")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateMessageDTO(internal.CreateMessageDTO{Content: "Is the car ready?"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateWebhookDTO(t *testing.T) {
+	valid := internal.CreateWebhookDTO{
+		URL:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef",
+		Active:     true,
+		EventTypes: []string{"appointment.created", "review.created"},
+	}
+
+	t.Run("should return error when url is invalid", func(t *testing.T) {
+		dto := valid
+		dto.URL = "ftp://example.com"
+		err := CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "invalid url")
+
+		dto.URL = "example.com/hooks"
+		err = CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "invalid url")
+	})
+
+	t.Run("should return error when secret is too short", func(t *testing.T) {
+		dto := valid
+		dto.Secret = "secret"
+		err := CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "secret must have at least 16 characters")
+	})
+
+	t.Run("should return error when event types are empty", func(t *testing.T) {
+		dto := valid
+		dto.EventTypes = nil
+		err := CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "event types cannot be empty")
+	})
+
+	t.Run("should return error when event type is unknown", func(t *testing.T) {
+		dto := valid
+		dto.EventTypes = []string{"appointment.deleted"}
+		err := CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "unknown event type")
+	})
+
+	t.Run("should return error when event types are duplicated", func(t *testing.T) {
+		dto := valid
+		dto.EventTypes = []string{"review.created", "review.created"}
+		err := CreateWebhookDTO(dto)
+		assert.EqualError(t, err, "event types cannot be duplicated")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateWebhookDTO(valid)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateWaitlistEntryDTO(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	nextWeek := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+
+	t.Run("should return error when service is missing", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{From: today, To: nextWeek})
+		assert.EqualError(t, err, "service ID is required")
+	})
+
+	t.Run("should return error when date is invalid", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{ServiceID: 1, From: "tomorrow", To: nextWeek})
+		assert.EqualError(t, err, "invalid from date")
+	})
+
+	t.Run("should return error when range is reversed", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{ServiceID: 1, From: nextWeek, To: today})
+		assert.EqualError(t, err, "to date cannot be before from date")
+	})
+
+	t.Run("should return error when range is in the past", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{ServiceID: 1, From: "2020-01-01", To: "2020-01-05"})
+		assert.EqualError(t, err, "to date cannot be in the past")
+	})
+
+	t.Run("should return error when range is too long", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{
+			ServiceID: 1,
+			From:      today,
+			To:        time.Now().AddDate(0, 0, 90).Format("2006-01-02"),
+		})
+		assert.EqualError(t, err, "date range cannot be longer than 60 days")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateWaitlistEntryDTO(internal.CreateWaitlistEntryDTO{ServiceID: 1, EmployeeID: 2, From: today, To: nextWeek})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateCompanyDTO(t *testing.T) {
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		err := CreateCompanyDTO(internal.CreateCompanyDTO{BillingEmail: "billing@example.com"})
+		assert.EqualError(t, err, "name and billing email cannot be empty")
+	})
+
+	t.Run("should return error when tax ID is too long", func(t *testing.T) {
+		err := CreateCompanyDTO(internal.CreateCompanyDTO{
+			Name:         "Taxi Sp. z o.o.",
+			TaxID:        strings.Repeat("1", 33),
+			BillingEmail: "billing@example.com",
+		})
+		assert.EqualError(t, err, "tax ID cannot have more than 32 characters")
+	})
+
+	t.Run("should return error when billing email is invalid", func(t *testing.T) {
+		err := CreateCompanyDTO(internal.CreateCompanyDTO{Name: "Taxi Sp. z o.o.", BillingEmail: "billing"})
+		assert.EqualError(t, err, "invalid email format")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateCompanyDTO(internal.CreateCompanyDTO{
+			Name:         "Taxi Sp. z o.o.",
+			TaxID:        "5260250274",
+			BillingEmail: "billing@example.com",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateCompanyMemberDTO(t *testing.T) {
+	t.Run("should return error when email is invalid", func(t *testing.T) {
+		err := CreateCompanyMemberDTO(internal.CreateCompanyMemberDTO{Email: "dispatcher", Role: internal.CompanyDispatcher})
+		assert.EqualError(t, err, "invalid email format")
+	})
+
+	t.Run("should return error when role is unknown", func(t *testing.T) {
+		err := CreateCompanyMemberDTO(internal.CreateCompanyMemberDTO{Email: "dispatcher@example.com", Role: "DRIVER"})
+		assert.EqualError(t, err, "unknown company role")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		err := CreateCompanyMemberDTO(internal.CreateCompanyMemberDTO{Email: "dispatcher@example.com", Role: internal.CompanyDispatcher})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateCompanyInvoiceDTO(t *testing.T) {
+	t.Run("should return error when company ID is missing", func(t *testing.T) {
+		err := CreateCompanyInvoiceDTO(internal.CreateCompanyInvoiceDTO{Month: "2026-01"})
+		assert.EqualError(t, err, "company ID must be greater than zero")
+	})
+
+	t.Run("should return error when month is invalid", func(t *testing.T) {
+		err := CreateCompanyInvoiceDTO(internal.CreateCompanyInvoiceDTO{CompanyID: 1, Month: "2026-13"})
+		assert.EqualError(t, err, "invalid month format")
+	})
+
+	t.Run("should return error when month is not over", func(t *testing.T) {
+		err := CreateCompanyInvoiceDTO(internal.CreateCompanyInvoiceDTO{CompanyID: 1, Month: time.Now().Format("2006-01")})
+		assert.EqualError(t, err, "month is not over yet")
+	})
+
+	t.Run("should pass with valid input", func(t *testing.T) {
+		month := time.Now().AddDate(0, -1, -time.Now().Day()+1).Format("2006-01")
+		err := CreateCompanyInvoiceDTO(internal.CreateCompanyInvoiceDTO{CompanyID: 1, Month: month})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	EventHeader     = "X-Garage-Event"
+	TimestampHeader = "X-Garage-Timestamp"
+	SignatureHeader = "X-Garage-Signature"
+	signaturePrefix = "sha256="
+	requestTimeout  = 10 * time.Second
+)
+
+// ErrForbiddenAddress is returned when an endpoint resolves to a loopback,
+// private, link-local or unspecified address, which webhooks must not reach.
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// Attempt is the outcome of a single delivery attempt. StatusCode is nil when
+// no response was received.
+type Attempt struct {
+	Number     int
+	StatusCode *int
+	Err        error
+}
+
+// Sender posts signed payloads to webhook endpoints.
+type Sender struct {
+	client   *http.Client
+	attempts int
+	backoff  time.Duration
+}
+
+// New returns a sender making up to attempts requests per delivery, waiting
+// backoff after the first failure and doubling the wait after every next one.
+// Endpoints are only reached on public addresses.
+func New(attempts int, backoff time.Duration) *Sender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{Timeout: requestTimeout, Control: checkAddress}).DialContext
+	return NewWithTransport(transport, attempts, backoff)
+}
+
+// NewWithTransport returns a sender like New that sends requests through the
+// transport, which is trusted to restrict the addresses it connects to.
+func NewWithTransport(transport http.RoundTripper, attempts int, backoff time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: transport,
+			// Redirects are not followed, so they cannot lead to an address
+			// other than the checked one.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		attempts: attempts,
+		backoff:  backoff,
+	}
+}
+
+// checkAddress is called with the resolved address before every connection.
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// Sign returns the signature of the payload sent at the timestamp. Receivers
+// recompute it with the shared secret to verify the origin of the request.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts the payload until the endpoint responds with a 2xx status or
+// the attempts run out, calling record after every attempt. It blocks for the
+// whole backoff, so callers run it in a separate goroutine.
+func (s *Sender) Deliver(url, secret, eventType string, payload []byte, record func(Attempt)) bool {
+	backoff := s.backoff
+	for number := 1; number <= s.attempts; number++ {
+		attempt := s.post(url, secret, eventType, payload)
+		attempt.Number = number
+		record(attempt)
+
+		if attempt.Err == nil {
+			return true
+		}
+		if number < s.attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return false
+}
+
+func (s *Sender) post(url, secret, eventType string, payload []byte) Attempt {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return Attempt{Err: err}
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, eventType)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(secret, timestamp, payload))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return Attempt{Err: err}
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	statusCode := response.StatusCode
+	if statusCode < 200 || statusCode > 299 {
+		return Attempt{StatusCode: &statusCode, Err: fmt.Errorf("unexpected status code %d", statusCode)}
+	}
+	return Attempt{StatusCode: &statusCode}
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("should sign payload with timestamp", func(t *testing.T) {
+		signature := Sign("secret", 1700000000, []byte(`{"type":"appointment.created"}`))
+
+		assert.Equal(t, signature, Sign("secret", 1700000000, []byte(`{"type":"appointment.created"}`)))
+		assert.NotEqual(t, signature, Sign("other", 1700000000, []byte(`{"type":"appointment.created"}`)))
+		assert.NotEqual(t, signature, Sign("secret", 1700000001, []byte(`{"type":"appointment.created"}`)))
+		assert.Len(t, signature, len(signaturePrefix)+64)
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	t.Run("should send signed request", func(t *testing.T) {
+		payload := []byte(`{"type":"appointment.created"}`)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+			require.NoError(t, err)
+
+			assert.Equal(t, payload, body)
+			assert.Equal(t, "appointment.created", r.Header.Get(EventHeader))
+			assert.Equal(t, Sign("secret", timestamp, body), r.Header.Get(SignatureHeader))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		var attempts []Attempt
+		ok := NewWithTransport(server.Client().Transport, 3, time.Millisecond).Deliver(server.URL, "secret", "appointment.created", payload, func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		assert.True(t, ok)
+		require.Len(t, attempts, 1)
+		assert.Equal(t, 1, attempts[0].Number)
+		assert.Equal(t, http.StatusNoContent, *attempts[0].StatusCode)
+		assert.NoError(t, attempts[0].Err)
+	})
+
+	t.Run("should retry failed deliveries", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		var attempts []Attempt
+		ok := NewWithTransport(server.Client().Transport, 5, time.Millisecond).Deliver(server.URL, "secret", "appointment.created", []byte(`{}`), func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		assert.True(t, ok)
+		require.Len(t, attempts, 3)
+		assert.Equal(t, http.StatusInternalServerError, *attempts[0].StatusCode)
+		assert.Error(t, attempts[0].Err)
+		assert.Equal(t, 3, attempts[2].Number)
+		assert.Equal(t, http.StatusOK, *attempts[2].StatusCode)
+	})
+
+	t.Run("should give up after all attempts", func(t *testing.T) {
+		var attempts []Attempt
+		ok := NewWithTransport(http.DefaultTransport, 2, time.Millisecond).Deliver("http://127.0.0.1:1", "secret", "appointment.created", []byte(`{}`), func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		assert.False(t, ok)
+		require.Len(t, attempts, 2)
+		assert.Nil(t, attempts[1].StatusCode)
+		assert.Error(t, attempts[1].Err)
+	})
+
+	t.Run("should not follow redirects", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			http.Redirect(w, r, "/other", http.StatusFound)
+		}))
+		defer server.Close()
+
+		var attempts []Attempt
+		ok := NewWithTransport(server.Client().Transport, 1, time.Millisecond).Deliver(server.URL, "secret", "appointment.created", []byte(`{}`), func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		assert.False(t, ok)
+		assert.Equal(t, int32(1), calls.Load())
+		require.Len(t, attempts, 1)
+		assert.Equal(t, http.StatusFound, *attempts[0].StatusCode)
+	})
+
+	t.Run("should refuse local and private addresses", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		for _, url := range []string{server.URL, "http://10.0.0.1", "http://169.254.169.254/latest/meta-data", "http://[::1]:1", "http://0.0.0.0:1"} {
+			var attempts []Attempt
+			ok := New(1, time.Millisecond).Deliver(url, "secret", "appointment.created", []byte(`{}`), func(attempt Attempt) {
+				attempts = append(attempts, attempt)
+			})
+
+			assert.False(t, ok)
+			require.Len(t, attempts, 1)
+			assert.Nil(t, attempts[0].StatusCode)
+			assert.ErrorIs(t, attempts[0].Err, ErrForbiddenAddress)
+		}
+		assert.Zero(t, calls.Load())
+	})
+}
@@ -0,0 +1,5 @@
+ALTER TABLE employees DROP COLUMN garage_role_id;
+
+DROP TABLE garage_role_permissions;
+
+DROP TABLE garage_roles;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS garage_roles
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    garage_id INT REFERENCES garages(id)
+);
+
+CREATE TABLE IF NOT EXISTS garage_role_permissions
+(
+    garage_role_id INT REFERENCES garage_roles(id) ON DELETE CASCADE,
+    permission VARCHAR(255) NOT NULL,
+    PRIMARY KEY (garage_role_id, permission)
+);
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS garage_role_id INT REFERENCES garage_roles(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+DROP TABLE ownership_transfers;
+
+DROP TABLE garage_owners;
@@ -0,0 +1,26 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_tables WHERE tablename = 'garage_owners') THEN
+        CREATE TABLE garage_owners
+        (
+            garage_id INT REFERENCES garages(id),
+            employee_id INT REFERENCES employees(id),
+            PRIMARY KEY (garage_id, employee_id)
+        );
+
+        INSERT INTO garage_owners (garage_id, employee_id)
+        SELECT id, owner_id FROM garages WHERE owner_id IS NOT NULL;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS ownership_transfers
+(
+    id SERIAL PRIMARY KEY,
+    garage_id INT REFERENCES garages(id),
+    from_employee_id INT REFERENCES employees(id),
+    to_employee_id INT REFERENCES employees(id),
+    from_confirmed BOOLEAN DEFAULT FALSE,
+    to_confirmed BOOLEAN DEFAULT FALSE,
+    completed BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,7 @@
+ALTER TABLE employees DROP COLUMN active_garage_id;
+
+DROP TABLE employee_garages;
+
+ALTER TABLE garages DROP COLUMN organization_id;
+
+DROP TABLE organizations;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL
+);
+
+ALTER TABLE garages ADD COLUMN IF NOT EXISTS organization_id INT REFERENCES organizations(id);
+
+CREATE TABLE IF NOT EXISTS employee_garages
+(
+    employee_id INT REFERENCES employees(id),
+    garage_id INT REFERENCES garages(id),
+    PRIMARY KEY (employee_id, garage_id)
+);
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS active_garage_id INT REFERENCES garages(id);
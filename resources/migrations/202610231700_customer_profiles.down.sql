@@ -0,0 +1,8 @@
+ALTER TABLE appointments DROP COLUMN vehicle_id;
+
+DROP TABLE vehicles;
+
+ALTER TABLE customers DROP COLUMN preferred_contact;
+ALTER TABLE customers DROP COLUMN phone_number;
+ALTER TABLE customers DROP COLUMN surname;
+ALTER TABLE customers DROP COLUMN name;
@@ -0,0 +1,17 @@
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS surname VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS phone_number VARCHAR(15) NOT NULL DEFAULT '';
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS preferred_contact VARCHAR(15) NOT NULL DEFAULT 'EMAIL';
+
+CREATE TABLE IF NOT EXISTS vehicles
+(
+    id SERIAL PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customers(id),
+    model_id INT NOT NULL REFERENCES models(id),
+    year INT,
+    plate_number VARCHAR(15) NOT NULL,
+    vin VARCHAR(17) NOT NULL DEFAULT '',
+    is_deleted BOOLEAN DEFAULT FALSE
+);
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS vehicle_id INT REFERENCES vehicles(id);
@@ -0,0 +1,4 @@
+ALTER TABLE appointments DROP COLUMN notes;
+ALTER TABLE appointments DROP COLUMN mileage;
+
+ALTER TABLE vehicles DROP COLUMN share_history;
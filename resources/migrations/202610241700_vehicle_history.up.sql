@@ -0,0 +1,4 @@
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS share_history BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS mileage INT CHECK (mileage >= 0);
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS notes TEXT;
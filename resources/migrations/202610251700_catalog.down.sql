@@ -0,0 +1,8 @@
+DROP INDEX models_make_id_name_key;
+
+ALTER TABLE models DROP COLUMN merged_into_id;
+ALTER TABLE makes DROP COLUMN merged_into_id;
+
+ALTER TABLE models DROP COLUMN body_type;
+ALTER TABLE models DROP COLUMN year_to;
+ALTER TABLE models DROP COLUMN year_from;
@@ -0,0 +1,34 @@
+ALTER TABLE models ADD COLUMN IF NOT EXISTS year_from INT;
+ALTER TABLE models ADD COLUMN IF NOT EXISTS year_to INT;
+ALTER TABLE models ADD COLUMN IF NOT EXISTS body_type VARCHAR(50);
+
+-- Merged makes and models are kept as tombstones pointing at the entry they were
+-- merged into. Catalog imports resolve their names to that entry, and the seed
+-- migration, which runs again on every start, skips their ids instead of
+-- bringing them back.
+ALTER TABLE makes ADD COLUMN IF NOT EXISTS merged_into_id INT REFERENCES makes(id);
+ALTER TABLE models ADD COLUMN IF NOT EXISTS merged_into_id INT REFERENCES models(id);
+
+-- Duplicated models are merged into the oldest one before the catalog gets a
+-- unique key on make and model name.
+UPDATE appointments AS a
+SET model_id = d.keep_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY make_id, name) AS keep_id FROM models WHERE merged_into_id IS NULL) AS d
+WHERE a.model_id = d.id AND d.id <> d.keep_id;
+
+UPDATE vehicles AS v
+SET model_id = d.keep_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY make_id, name) AS keep_id FROM models WHERE merged_into_id IS NULL) AS d
+WHERE v.model_id = d.id AND d.id <> d.keep_id;
+
+UPDATE models AS m
+SET merged_into_id = d.keep_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY make_id, name) AS keep_id FROM models WHERE merged_into_id IS NULL) AS d
+WHERE m.id = d.id AND d.id <> d.keep_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS models_make_id_name_key ON models (make_id, name) WHERE merged_into_id IS NULL;
+
+-- Makes and models were seeded with explicit ids, so the sequences have to be
+-- moved past them before new rows can be inserted.
+SELECT setval('makes_id_seq', COALESCE((SELECT MAX(id) FROM makes), 1));
+SELECT setval('models_id_seq', COALESCE((SELECT MAX(id) FROM models), 1));
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS service_rules
+(
+    id SERIAL PRIMARY KEY,
+    service_id INT NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('ALLOW', 'EXCLUDE', 'OVERRIDE')),
+    make_id INT NOT NULL REFERENCES makes(id),
+    model_id INT REFERENCES models(id),
+    price INT CHECK (price > 0),
+    time INT CHECK (time > 0)
+);
+
+CREATE INDEX IF NOT EXISTS service_rules_service_id_idx ON service_rules (service_id);
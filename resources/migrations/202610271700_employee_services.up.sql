@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS employee_services
+(
+    employee_id INT REFERENCES employees(id) ON DELETE CASCADE,
+    service_id INT REFERENCES services(id) ON DELETE CASCADE,
+    PRIMARY KEY (employee_id, service_id)
+);
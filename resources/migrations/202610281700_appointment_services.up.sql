@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS appointment_services
+(
+    id SERIAL PRIMARY KEY,
+    appointment_id INT NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    service_id INT NOT NULL REFERENCES services(id),
+    name VARCHAR(255) NOT NULL,
+    price INT NOT NULL,
+    time INT NOT NULL,
+    UNIQUE (appointment_id, service_id)
+);
+
+INSERT INTO appointment_services (appointment_id, service_id, name, price, time)
+SELECT a.id, s.id, s.name, s.price, s.time
+FROM appointments AS a
+JOIN services AS s ON s.id = a.service_id
+WHERE NOT EXISTS (SELECT 1 FROM appointment_services WHERE appointment_id = a.id);
@@ -0,0 +1,5 @@
+ALTER TABLE services DROP COLUMN max_price;
+ALTER TABLE services DROP COLUMN category_id;
+ALTER TABLE services DROP COLUMN description;
+
+DROP TABLE service_categories;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS service_categories
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE
+);
+
+INSERT INTO service_categories (name)
+SELECT name FROM (VALUES
+    ('Maintenance'),
+    ('Tyres'),
+    ('Diagnostics'),
+    ('Brakes'),
+    ('Bodywork'),
+    ('Electrical'),
+    ('Air conditioning')
+) AS defaults (name)
+WHERE NOT EXISTS (SELECT 1 FROM service_categories);
+
+ALTER TABLE services ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE services ADD COLUMN IF NOT EXISTS category_id INT REFERENCES service_categories(id) ON DELETE SET NULL;
+ALTER TABLE services ADD COLUMN IF NOT EXISTS max_price INT CHECK (max_price >= price);
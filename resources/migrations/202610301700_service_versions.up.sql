@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS service_versions
+(
+    id SERIAL PRIMARY KEY,
+    service_id INT NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    price INT NOT NULL,
+    max_price INT,
+    time INT NOT NULL,
+    effective_from TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS service_versions_service_id_effective_from_idx ON service_versions (service_id, effective_from);
+
+INSERT INTO service_versions (service_id, price, max_price, time, effective_from)
+SELECT s.id, s.price, s.max_price, s.time, NOW()
+FROM services AS s
+WHERE NOT EXISTS (SELECT 1 FROM service_versions WHERE service_id = s.id);
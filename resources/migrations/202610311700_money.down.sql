@@ -0,0 +1,10 @@
+ALTER TABLE appointment_services DROP COLUMN tax_rate;
+ALTER TABLE appointment_services DROP COLUMN currency;
+
+ALTER TABLE garages DROP COLUMN tax_rate;
+ALTER TABLE garages DROP COLUMN currency;
+
+UPDATE appointment_services SET price = price / 100;
+UPDATE service_rules SET price = price / 100 WHERE price IS NOT NULL;
+UPDATE service_versions SET price = price / 100, max_price = max_price / 100;
+UPDATE services SET price = price / 100, max_price = max_price / 100;
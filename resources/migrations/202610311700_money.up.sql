@@ -0,0 +1,15 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'garages' AND column_name = 'currency') THEN
+        UPDATE services SET price = price * 100, max_price = max_price * 100;
+        UPDATE service_versions SET price = price * 100, max_price = max_price * 100;
+        UPDATE service_rules SET price = price * 100 WHERE price IS NOT NULL;
+        UPDATE appointment_services SET price = price * 100;
+    END IF;
+END $$;
+
+ALTER TABLE garages ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'PLN';
+ALTER TABLE garages ADD COLUMN IF NOT EXISTS tax_rate INT NOT NULL DEFAULT 2300 CHECK (tax_rate >= 0 AND tax_rate <= 10000);
+
+ALTER TABLE appointment_services ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'PLN';
+ALTER TABLE appointment_services ADD COLUMN IF NOT EXISTS tax_rate INT NOT NULL DEFAULT 2300;
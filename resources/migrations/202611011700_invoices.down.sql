@@ -0,0 +1,4 @@
+DROP TABLE invoice_items;
+DROP TABLE invoices;
+
+ALTER TABLE garages DROP COLUMN last_invoice_number;
@@ -0,0 +1,30 @@
+ALTER TABLE garages ADD COLUMN IF NOT EXISTS last_invoice_number INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS invoices
+(
+    id SERIAL PRIMARY KEY,
+    garage_id INT NOT NULL REFERENCES garages(id),
+    appointment_id INT NOT NULL UNIQUE REFERENCES appointments(id),
+    customer_id INT NOT NULL,
+    number INT NOT NULL,
+    issued_at TIMESTAMP NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    seller_name VARCHAR(255) NOT NULL,
+    seller_address VARCHAR(255) NOT NULL,
+    buyer_name VARCHAR(255) NOT NULL,
+    buyer_email VARCHAR(255) NOT NULL,
+    UNIQUE (garage_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_items
+(
+    id SERIAL PRIMARY KEY,
+    invoice_id INT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price INT NOT NULL,
+    tax_rate INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS invoice_items_invoice_id_idx ON invoice_items (invoice_id);
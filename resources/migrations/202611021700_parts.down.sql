@@ -0,0 +1,2 @@
+DROP TABLE appointment_parts;
+DROP TABLE parts;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS parts
+(
+    id SERIAL PRIMARY KEY,
+    garage_id INT NOT NULL REFERENCES garages(id),
+    sku VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    cost INT NOT NULL,
+    reorder_threshold INT NOT NULL DEFAULT 0,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS parts_garage_id_sku_key ON parts (garage_id, sku) WHERE NOT is_deleted;
+
+CREATE TABLE IF NOT EXISTS appointment_parts
+(
+    id SERIAL PRIMARY KEY,
+    appointment_id INT NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    part_id INT NOT NULL REFERENCES parts(id),
+    quantity INT NOT NULL CHECK (quantity > 0),
+    cost INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS appointment_parts_appointment_id_idx ON appointment_parts (appointment_id);
+CREATE INDEX IF NOT EXISTS appointment_parts_part_id_idx ON appointment_parts (part_id);
@@ -0,0 +1,2 @@
+DROP TABLE work_order_photos;
+DROP TABLE work_orders;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS work_orders
+(
+    id SERIAL PRIMARY KEY,
+    appointment_id INT NOT NULL UNIQUE REFERENCES appointments(id) ON DELETE CASCADE,
+    findings TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS work_order_photos
+(
+    id SERIAL PRIMARY KEY,
+    work_order_id INT NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    photo BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS work_order_photos_work_order_id_idx ON work_order_photos (work_order_id);
@@ -0,0 +1 @@
+DROP TABLE additional_items;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS additional_items
+(
+    id SERIAL PRIMARY KEY,
+    appointment_id INT NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    description VARCHAR(500) NOT NULL,
+    price INT NOT NULL,
+    time INT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS additional_items_appointment_id_idx ON additional_items (appointment_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS messages
+(
+    id SERIAL PRIMARY KEY,
+    appointment_id INT NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    sender_role VARCHAR(16) NOT NULL,
+    customer_id INT REFERENCES customers(id),
+    employee_id INT REFERENCES employees(id),
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS messages_appointment_id_idx ON messages (appointment_id);
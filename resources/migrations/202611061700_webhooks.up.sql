@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id SERIAL PRIMARY KEY,
+    garage_id INT NOT NULL REFERENCES garages(id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_event_types
+(
+    webhook_id INT REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    PRIMARY KEY (webhook_id, event_type)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id SERIAL PRIMARY KEY,
+    webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS calendar_tokens
+(
+    id VARCHAR(36) PRIMARY KEY,
+    employee_id INT UNIQUE REFERENCES employees(id) ON DELETE CASCADE,
+    garage_id INT UNIQUE REFERENCES garages(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((employee_id IS NULL) <> (garage_id IS NULL))
+);
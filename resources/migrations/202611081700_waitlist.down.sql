@@ -0,0 +1,5 @@
+DROP TABLE slot_holds;
+
+DROP TABLE waitlist_entries;
+
+ALTER TABLE garages DROP COLUMN waitlist_mode;
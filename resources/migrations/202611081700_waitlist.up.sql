@@ -0,0 +1,30 @@
+ALTER TABLE garages ADD COLUMN IF NOT EXISTS waitlist_mode VARCHAR(16) NOT NULL DEFAULT 'FIRST_COME';
+
+CREATE TABLE IF NOT EXISTS waitlist_entries
+(
+    id SERIAL PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    garage_id INT NOT NULL REFERENCES garages(id) ON DELETE CASCADE,
+    service_id INT NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    employee_id INT REFERENCES employees(id) ON DELETE CASCADE,
+    from_time TIMESTAMP NOT NULL,
+    to_time TIMESTAMP NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'WAITING' CHECK (status IN ('WAITING', 'OFFERED', 'BOOKED', 'EXPIRED')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_garage_id_idx ON waitlist_entries (garage_id, status);
+
+CREATE TABLE IF NOT EXISTS slot_holds
+(
+    id SERIAL PRIMARY KEY,
+    employee_id INT NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    customer_id INT NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    waitlist_entry_id INT REFERENCES waitlist_entries(id) ON DELETE CASCADE,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS slot_holds_employee_id_idx ON slot_holds (employee_id, expires_at);
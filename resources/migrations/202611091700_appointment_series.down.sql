@@ -0,0 +1,3 @@
+ALTER TABLE appointments DROP COLUMN series_id;
+
+DROP TABLE appointment_series;
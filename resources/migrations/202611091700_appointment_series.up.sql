@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS appointment_series
+(
+    id SERIAL PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    frequency VARCHAR(16) NOT NULL CHECK (frequency IN ('WEEKLY', 'MONTHLY')),
+    "interval" INT NOT NULL CHECK ("interval" > 0),
+    count INT NOT NULL CHECK (count > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS series_id INT REFERENCES appointment_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS appointments_series_id_idx ON appointments (series_id);
@@ -0,0 +1,16 @@
+DROP TABLE invoice_appointments;
+
+DROP INDEX invoices_company_period_idx;
+ALTER TABLE invoices DROP COLUMN period;
+ALTER TABLE invoices DROP COLUMN company_id;
+DELETE FROM invoices WHERE appointment_id IS NULL;
+ALTER TABLE invoices ALTER COLUMN customer_id SET NOT NULL;
+ALTER TABLE invoices ALTER COLUMN appointment_id SET NOT NULL;
+
+ALTER TABLE appointments DROP COLUMN company_id;
+
+ALTER TABLE vehicles DROP COLUMN company_id;
+
+DROP TABLE company_members;
+
+DROP TABLE companies;
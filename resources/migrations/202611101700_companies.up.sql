@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS companies
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    tax_id VARCHAR(32) NOT NULL DEFAULT '',
+    billing_email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS company_members
+(
+    company_id INT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    customer_id INT NOT NULL UNIQUE REFERENCES customers(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('ADMIN', 'DISPATCHER')),
+    PRIMARY KEY (company_id, customer_id)
+);
+
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS company_id INT REFERENCES companies(id);
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS company_id INT REFERENCES companies(id);
+
+CREATE INDEX IF NOT EXISTS appointments_company_id_idx ON appointments (company_id);
+
+ALTER TABLE invoices ALTER COLUMN appointment_id DROP NOT NULL;
+ALTER TABLE invoices ALTER COLUMN customer_id DROP NOT NULL;
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS company_id INT REFERENCES companies(id);
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS period DATE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS invoices_company_period_idx ON invoices (garage_id, company_id, period) WHERE company_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS invoice_appointments
+(
+    invoice_id INT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    appointment_id INT NOT NULL UNIQUE REFERENCES appointments(id),
+    PRIMARY KEY (invoice_id, appointment_id)
+);
+
+INSERT INTO invoice_appointments (invoice_id, appointment_id)
+SELECT id, appointment_id FROM invoices WHERE appointment_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+DROP TABLE customer_invitations;
+
+UPDATE customers SET email = 'guest-' || id WHERE email = '';
+DROP INDEX customers_email_key;
+ALTER TABLE customers ADD CONSTRAINT customers_email_key UNIQUE (email);
+
+ALTER TABLE customers DROP COLUMN guest;
@@ -0,0 +1,10 @@
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS guest BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS customers_email_key ON customers (email) WHERE email <> '';
+
+CREATE TABLE IF NOT EXISTS customer_invitations
+(
+    id UUID PRIMARY KEY,
+    customer_id INT NOT NULL REFERENCES customers(id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DELETE FROM service_categories WHERE garage_id IS NOT NULL;
+
+DROP INDEX service_categories_garage_id_name_key;
+DROP INDEX service_categories_name_key;
+ALTER TABLE service_categories ADD CONSTRAINT service_categories_name_key UNIQUE (name);
+
+ALTER TABLE service_categories DROP COLUMN garage_id;
@@ -0,0 +1,7 @@
+-- Categories without a garage are shared by every garage, the others belong to
+-- the garage that created them.
+ALTER TABLE service_categories ADD COLUMN IF NOT EXISTS garage_id INT REFERENCES garages(id) ON DELETE CASCADE;
+
+ALTER TABLE service_categories DROP CONSTRAINT IF EXISTS service_categories_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS service_categories_name_key ON service_categories (name) WHERE garage_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS service_categories_garage_id_name_key ON service_categories (garage_id, name) WHERE garage_id IS NOT NULL;
@@ -18,6 +18,7 @@ import TabSwitcher from "@/components/TabSwitcher";
 import {CustomerAppointment, Appointments} from "@/types";
 import {CUSTOMER_JWT} from "@/constants/constants";
 import {formatDateTime} from "@/utils/time";
+import {formatMoney} from "@/utils/money";
 import CustomButton from "@/components/CustomButton";
 import {AirbnbRating} from "react-native-ratings";
 import {useRouter} from "expo-router";
@@ -129,7 +130,7 @@ const AppointmentsScreen = () => {
                             {formatDateTime(item.startTime)} - {formatDateTime(item.endTime)}
                         </Text>
                         <Text className="text-sm text-[#ddd]">
-                            Cena: {item.service.price} zł
+                            Cena: {formatMoney(item.service.price)}
                         </Text>
                         <Text className="text-sm text-[#ddd]">
                             Samochód: {item.car.make} {item.car.model}
@@ -213,7 +213,7 @@ const CreatorScreen = () => {
             services: services.map(service => ({
                 name: service.name,
                 time: parseInt(service.time, 10),
-                price: parseInt(service.price, 10)
+                price: parseInt(service.price, 10) * 100
             })),
             employeeEmails
         };
@@ -12,6 +12,7 @@ import {
 } from "react-native";
 import BusinessMenu from "@/components/BusinessMenu";
 import {Garage, Service} from "@/types";
+import {formatMoney} from "@/utils/money";
 import CustomButton from "@/components/CustomButton";
 
 const ServicesScreen = () => {
@@ -110,7 +111,7 @@ const ServicesScreen = () => {
         const data = {
             name: name,
             time: parseInt(time, 10),
-            price: parseInt(price, 10)
+            price: parseInt(price, 10) * 100
         };
         await axios.post("/api/services", data, {headers: {"Authorization": `Bearer ${token}`}})
             .then(() => {
@@ -151,7 +152,7 @@ const ServicesScreen = () => {
                         Czas: {item.time} godz.
                     </Text>
                     <Text className="text-sm text-[#ddd]">
-                        Cena: {item.price} zł
+                        Cena: {formatMoney(item.price)}
                     </Text>
                 </View>
 
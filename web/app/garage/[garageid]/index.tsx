@@ -17,6 +17,7 @@ import MenuModal from "@/components/MenuModal";
 import {Garage, Review, Service} from "@/types";
 import {CUSTOMER_JWT} from "@/constants/constants";
 import {formatDate} from "@/utils/time";
+import {formatMoney} from "@/utils/money";
 import {AirbnbRating} from "react-native-ratings";
 
 const GarageScreen = () => {
@@ -105,7 +106,7 @@ const GarageScreen = () => {
             <View className="p-2 my-2 mx-3 bg-[#2d2d2d] rounded-lg">
                 <Text className="text-xl font-bold text-white">{item.name}</Text>
                 <Text className="text-[#ddd] mt-1">Czas: {item.time} godz.</Text>
-                <Text className="text-[#ddd] mt-0.5">Cena: {item.price} zł</Text>
+                <Text className="text-[#ddd] mt-0.5">Cena: {formatMoney(item.price)}</Text>
             </View>
         </TouchableOpacity>
     );
@@ -12,6 +12,7 @@ import MenuModal from "@/components/MenuModal";
 import {Employee, Make, Model, Service, TimeSlot} from "@/types";
 import {CUSTOMER_JWT} from "@/constants/constants";
 import {formatDateTime, formatTime} from "@/utils/time";
+import {formatMoney} from "@/utils/money";
 import {Picker} from "@react-native-picker/picker";
 
 moment.locale("pl");
@@ -204,7 +205,7 @@ const AppointmentScreen = () => {
                     <View className="p-6 bg-[#1a1a1a] rounded-lg mx-4 mt-4 shadow-lg">
                         <Text className="text-3xl font-extrabold text-white mb-2">{service.name}</Text>
                         <Text className="text-xl text-[#aaa]">Czas: {service.time} godz.</Text>
-                        <Text className="text-xl text-[#aaa]">Cena: {service.price} zł</Text>
+                        <Text className="text-xl text-[#aaa]">Cena: {formatMoney(service.price)}</Text>
                         <Text className="text-xl text-[#aaa]">Mechanik: {employee?.name} {employee?.surname}</Text>
                     </View>
                 )
@@ -276,7 +277,7 @@ const AppointmentScreen = () => {
                                 </View>
                                 <View className="text-white text-xl mb-2 flex-row justify-between">
                                     <Text className="text-white text-xl mb-2">Cena:</Text>
-                                    <Text className="text-white text-xl mb-2">{service.price} zł</Text>
+                                    <Text className="text-white text-xl mb-2">{formatMoney(service.price)}</Text>
                                 </View>
                                 <View className="text-white text-xl mb-2 flex-row justify-between">
                                     <Text className="text-white text-xl mb-2">Mechanik:</Text>
@@ -7,6 +7,7 @@ import EmailDisplay from "@/components/EmailDisplay";
 import MenuModal from "@/components/MenuModal";
 import {Employee, Service} from "@/types";
 import {CUSTOMER_JWT} from "@/constants/constants";
+import {formatMoney} from "@/utils/money";
 
 const ServiceScreen = () => {
     const router = useRouter();
@@ -102,7 +103,7 @@ const ServiceScreen = () => {
                     <View className="p-6 bg-[#1a1a1a] rounded-lg mx-4 mt-4 shadow-lg">
                         <Text className="text-3xl font-extrabold text-white mb-2">{service.name}</Text>
                         <Text className="text-xl text-[#aaa]">Czas: {service.time} godz.</Text>
-                        <Text className="text-xl text-[#aaa]">Cena: {service.price} zł</Text>
+                        <Text className="text-xl text-[#aaa]">Cena: {formatMoney(service.price)}</Text>
                     </View>
                 )
             )}
@@ -22,11 +22,18 @@ export interface Employee {
     profilePicture: string;
 }
 
+export interface Money {
+    gross: number;
+    net: number;
+    tax: number;
+    currency: string;
+}
+
 export interface Service {
     id: number;
     name: string;
     time: string;
-    price: string;
+    price: Money;
 }
 
 export interface Appointments {
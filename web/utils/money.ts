@@ -0,0 +1,8 @@
+import {Money} from "@/types";
+
+export const formatMoney = (money: Money): string => {
+    const sign = money.gross < 0 ? "-" : "";
+    const amount = Math.abs(money.gross);
+    const minor = (amount % 100).toString().padStart(2, "0");
+    return `${sign}${Math.floor(amount / 100)},${minor} ${money.currency}`;
+};